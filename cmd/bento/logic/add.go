@@ -19,17 +19,23 @@ type CatalogEntry struct {
 // BrickRegistry returns the list of available bricks.
 func BrickRegistry() []CatalogEntry {
 	return []CatalogEntry{
-		{Name: "surface", Desc: "Full-terminal paint surface with UV cell buffer", Files: []string{"surface.go"}},
+		{Name: "surface", Desc: "Full-terminal paint surface with UV cell buffer", Files: []string{"surface.go", "damage.go", "layers.go", "draw.go", "export.go"}},
 		{Name: "card", Desc: "Content container — raised (default) or flat via Flat() option", Files: []string{"card.go"}},
 		{Name: "bar", Desc: "Header/footer row with keybind cards", Files: []string{"bar.go"}},
-		{Name: "dialog", Desc: "Modal manager, Confirm, Custom, ThemePicker, CommandPalette", Files: []string{"dialog.go", "theme_picker.go", "command_palette.go"}},
+		{Name: "dialog", Desc: "Modal manager, Confirm, Prompt, Choice, Form, Custom, ThemePicker, CommandPalette", Files: []string{"dialog.go", "theme_picker.go", "command_palette.go", "prompt.go", "choice.go", "form.go", "palette_history.go", "palette_args.go"}},
 		{Name: "filepicker", Desc: "File and directory picker wrapping bubbles/filepicker", Files: []string{"filepicker.go"}},
 		{Name: "list", Desc: "Scrollable list wrapping bubbles/list", Files: []string{"list.go"}},
 		{Name: "table", Desc: "Data table wrapping bubbles/table", Files: []string{"table.go"}},
+		{Name: "viewport", Desc: "Pager for long text with search, wrap and follow-tail", Files: []string{"viewport.go"}},
+		{Name: "tree", Desc: "Expandable tree with lazy children, guides and filtering", Files: []string{"tree.go"}},
+		{Name: "diff", Desc: "Unified diff viewer with side-by-side mode and intraline highlights", Files: []string{"diff.go", "parse.go"}},
+		{Name: "code", Desc: "Syntax-highlighted source view with line numbers and a marker gutter", Files: []string{"code.go", "lexer.go"}},
 		{Name: "text", Desc: "Static text label", Files: []string{"text.go"}},
 		{Name: "input", Desc: "Single-line text field wrapping bubbles/textinput", Files: []string{"input.go"}},
+		{Name: "textarea", Desc: "Multi-line editor with soft wrap, line numbers and undo/redo", Files: []string{"textarea.go"}},
 		{Name: "badge", Desc: "Inline themed label", Files: []string{"badge.go"}},
 		{Name: "kbd", Desc: "Keyboard shortcut command+label pair", Files: []string{"kbd.go"}},
+		{Name: "help", Desc: "Key binding cheat sheet with a compact one-line mode", Files: []string{"help.go"}},
 		{Name: "wordmark", Desc: "Themed heading/title block", Files: []string{"wordmark.go"}},
 		{Name: "select", Desc: "Single-choice picker wrapping bubbles/list", Files: []string{"select.go"}},
 		{Name: "checkbox", Desc: "Boolean toggle using bubbles key bindings", Files: []string{"checkbox.go"}},
@@ -0,0 +1,260 @@
+// Package focus moves keyboard focus between the bricks of a page. A
+// Manager knows the focusable bricks in order, keeps exactly one of them
+// focused, cycles with tab/shift+tab, and moves left, right, up or down by
+// where the bricks sit in the rendered layout. The same layout answers
+// which brick is under the mouse, for apps that turn mouse reporting on.
+//
+// Register the card around a brick with it and the card shows the theme's
+// CardFocusEdge while the brick has focus:
+//
+//	fm := focus.New()
+//	fm.Add("list", list, listCard)
+//	fm.Add("table", table, tableCard)
+//	fm.SetLayout(func() string {
+//	    return rooms.HSplit(w, h, fm.Region("list", listCard), fm.Region("table", tableCard))
+//	})
+package focus
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+)
+
+// Target is anything that can hold focus: bricks, cards, recipes.
+type Target interface {
+	Focus()
+	Blur()
+	IsFocused() bool
+}
+
+// Sizable is a layout cell, the same shape rooms lay out.
+type Sizable interface {
+	SetSize(width, height int)
+	View() tea.View
+}
+
+// Direction is a move through the layout.
+type Direction int
+
+const (
+	Left Direction = iota
+	Right
+	Up
+	Down
+)
+
+// Scope and action IDs the focus bindings register under in a
+// keymap.Registry.
+const (
+	Scope       keymap.Scope = "focus"
+	ActionNext               = "focus.next"
+	ActionPrev               = "focus.prev"
+	ActionLeft               = "focus.left"
+	ActionRight              = "focus.right"
+	ActionUp                 = "focus.up"
+	ActionDown               = "focus.down"
+)
+
+// DefaultBindings are the focus keys. Plain arrows are left to the focused
+// brick; alt+arrows move between bricks.
+func DefaultBindings() []keymap.Binding {
+	return []keymap.Binding{
+		{Action: ActionNext, Keys: []string{"tab"}, Help: "next pane"},
+		{Action: ActionPrev, Keys: []string{"shift+tab"}, Help: "previous pane"},
+		{Action: ActionLeft, Keys: []string{"alt+left"}, Help: "pane left"},
+		{Action: ActionRight, Keys: []string{"alt+right"}, Help: "pane right"},
+		{Action: ActionUp, Keys: []string{"alt+up"}, Help: "pane above"},
+		{Action: ActionDown, Keys: []string{"alt+down"}, Help: "pane below"},
+	}
+}
+
+type item struct {
+	id       string
+	targets  []Target
+	disabled bool
+}
+
+// Manager owns focus for one page. The zero value is not usable; call New.
+type Manager struct {
+	items   []*item
+	current int // index into items, -1 when nothing can be focused
+	keys    *keymap.Registry
+	layout  func() string
+	rects   map[string]Rect
+	probing map[string]rune // set while Measure renders the layout
+
+	regions []string          // Region ids in the order they were first seen
+	sizes   map[string][2]int // last size each region was given
+	stale   bool              // a region was resized since the last Measure
+}
+
+func New() *Manager {
+	m := &Manager{current: -1}
+	m.SetKeymap(nil)
+	return m
+}
+
+// SetKeymap reads the focus keys from r.
+func (m *Manager) SetKeymap(r *keymap.Registry) {
+	if r == nil {
+		r = keymap.NewRegistry()
+	}
+	r.Register(Scope, DefaultBindings()...)
+	m.keys = r
+}
+
+// Add registers a focusable brick under id, after the ones already added;
+// tab order is add order. with are focused and blurred along with it —
+// usually the card around the brick. The first brick added takes focus.
+// Adding an existing id replaces its targets.
+func (m *Manager) Add(id string, t Target, with ...Target) {
+	targets := append([]Target{t}, with...)
+	if i := m.index(id); i >= 0 {
+		m.items[i].targets = targets
+	} else {
+		m.items = append(m.items, &item{id: id, targets: targets})
+	}
+	if m.current < 0 {
+		m.current = m.nextEnabled(0, 1)
+	}
+	m.sync()
+}
+
+// Remove forgets id. If it had focus, the next brick takes it.
+func (m *Manager) Remove(id string) {
+	i := m.index(id)
+	if i < 0 {
+		return
+	}
+	for _, t := range m.items[i].targets {
+		t.Blur()
+	}
+	m.items = slices.Delete(m.items, i, i+1)
+	switch {
+	case m.current > i:
+		m.current--
+	case m.current == i:
+		m.current = m.nextEnabled(min(i, len(m.items)-1), 1)
+	}
+	m.sync()
+}
+
+// SetEnabled skips a brick while cycling (false) or makes it focusable
+// again. Disabling the focused brick moves focus on.
+func (m *Manager) SetEnabled(id string, enabled bool) {
+	i := m.index(id)
+	if i < 0 {
+		return
+	}
+	m.items[i].disabled = !enabled
+	if m.current < 0 || (m.current == i && !enabled) {
+		m.current = m.nextEnabled(max(i, 0), 1)
+	}
+	m.sync()
+}
+
+// Focus moves focus to id. It reports false for unknown or disabled ids.
+func (m *Manager) Focus(id string) bool {
+	i := m.index(id)
+	if i < 0 || m.items[i].disabled {
+		return false
+	}
+	m.current = i
+	m.sync()
+	return true
+}
+
+// Focused returns the id of the focused brick, or "" when there is none.
+func (m *Manager) Focused() string {
+	if m.current < 0 {
+		return ""
+	}
+	return m.items[m.current].id
+}
+
+// IDs returns the registered ids in tab order.
+func (m *Manager) IDs() []string {
+	ids := make([]string, len(m.items))
+	for i, it := range m.items {
+		ids[i] = it.id
+	}
+	return ids
+}
+
+// Next focuses the next enabled brick in tab order, wrapping around.
+func (m *Manager) Next() { m.step(1) }
+
+// Prev focuses the previous enabled brick in tab order, wrapping around.
+func (m *Manager) Prev() { m.step(-1) }
+
+// HandleKey applies a focus binding. It reports whether msg was one, so the
+// caller can stop routing it; otherwise send it on to the focused brick.
+func (m *Manager) HandleKey(msg tea.KeyMsg) bool {
+	switch {
+	case m.keys.Matches(msg, ActionNext):
+		m.Next()
+	case m.keys.Matches(msg, ActionPrev):
+		m.Prev()
+	case m.keys.Matches(msg, ActionLeft):
+		m.Move(Left)
+	case m.keys.Matches(msg, ActionRight):
+		m.Move(Right)
+	case m.keys.Matches(msg, ActionUp):
+		m.Move(Up)
+	case m.keys.Matches(msg, ActionDown):
+		m.Move(Down)
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *Manager) step(dir int) {
+	if len(m.items) == 0 {
+		return
+	}
+	start := 0
+	if m.current >= 0 {
+		start = (m.current + dir + len(m.items)) % len(m.items)
+	}
+	if i := m.nextEnabled(start, dir); i >= 0 {
+		m.current = i
+	}
+	m.sync()
+}
+
+// nextEnabled returns the first enabled item from start walking by dir,
+// wrapping around, or -1.
+func (m *Manager) nextEnabled(start, dir int) int {
+	n := len(m.items)
+	for k := range n {
+		i := ((start+dir*k)%n + n) % n
+		if !m.items[i].disabled {
+			return i
+		}
+	}
+	return -1
+}
+
+// sync blurs every target, then focuses the current brick's, so a target
+// shared by two bricks ends up focused when either one is.
+func (m *Manager) sync() {
+	for i, it := range m.items {
+		if i != m.current {
+			for _, t := range it.targets {
+				t.Blur()
+			}
+		}
+	}
+	if m.current >= 0 {
+		for _, t := range m.items[m.current].targets {
+			t.Focus()
+		}
+	}
+}
+
+func (m *Manager) index(id string) int {
+	return slices.IndexFunc(m.items, func(it *item) bool { return it.id == id })
+}
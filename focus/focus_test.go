@@ -0,0 +1,244 @@
+package focus
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/registry/rooms"
+)
+
+// pane is a focusable brick that renders its name.
+type pane struct {
+	name    string
+	focused bool
+	w, h    int
+}
+
+func (p *pane) Focus()              { p.focused = true }
+func (p *pane) Blur()               { p.focused = false }
+func (p *pane) IsFocused() bool     { return p.focused }
+func (p *pane) SetSize(w, h int)    { p.w, p.h = w, h }
+func (p *pane) View() tea.View      { return tea.NewView(p.name) }
+func (p *pane) GetSize() (int, int) { return p.w, p.h }
+
+func panes(names ...string) map[string]*pane {
+	out := make(map[string]*pane, len(names))
+	for _, n := range names {
+		out[n] = &pane{name: n}
+	}
+	return out
+}
+
+// requireOnly fails unless exactly want is focused among ps.
+func requireOnly(t *testing.T, m *Manager, ps map[string]*pane, want string) {
+	t.Helper()
+	if m.Focused() != want {
+		t.Fatalf("Focused() = %q, want %q", m.Focused(), want)
+	}
+	for name, p := range ps {
+		if p.focused != (name == want) {
+			t.Fatalf("%s focused = %v with %s current", name, p.focused, want)
+		}
+	}
+}
+
+func press(s string) tea.KeyPressMsg {
+	switch s {
+	case "tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab}
+	case "shift+tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift}
+	case "alt+left":
+		return tea.KeyPressMsg{Code: tea.KeyLeft, Mod: tea.ModAlt}
+	case "alt+right":
+		return tea.KeyPressMsg{Code: tea.KeyRight, Mod: tea.ModAlt}
+	case "alt+up":
+		return tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModAlt}
+	case "alt+down":
+		return tea.KeyPressMsg{Code: tea.KeyDown, Mod: tea.ModAlt}
+	}
+	return tea.KeyPressMsg{Code: rune(s[0]), Text: s}
+}
+
+func TestTabCyclesAndKeepsOneFocused(t *testing.T) {
+	ps := panes("a", "b", "c")
+	card := &pane{name: "card"}
+	m := New()
+	m.Add("a", ps["a"])
+	m.Add("b", ps["b"], card)
+	m.Add("c", ps["c"])
+	requireOnly(t, m, ps, "a")
+
+	m.HandleKey(press("tab"))
+	requireOnly(t, m, ps, "b")
+	if !card.focused {
+		t.Fatal("the card added with b should be focused with it")
+	}
+	m.HandleKey(press("tab"))
+	requireOnly(t, m, ps, "c")
+	if card.focused {
+		t.Fatal("the card should blur when b loses focus")
+	}
+	m.HandleKey(press("tab"))
+	requireOnly(t, m, ps, "a")
+	m.HandleKey(press("shift+tab"))
+	requireOnly(t, m, ps, "c")
+
+	if m.HandleKey(press("x")) {
+		t.Fatal("other keys should be left to the bricks")
+	}
+}
+
+func TestDisabledAndRemovedAreSkipped(t *testing.T) {
+	ps := panes("a", "b", "c")
+	m := New()
+	for _, id := range []string{"a", "b", "c"} {
+		m.Add(id, ps[id])
+	}
+	m.SetEnabled("b", false)
+	m.Next()
+	requireOnly(t, m, ps, "c")
+	if m.Focus("b") {
+		t.Fatal("a disabled brick cannot take focus")
+	}
+
+	m.SetEnabled("c", false)
+	requireOnly(t, m, ps, "a")
+
+	m.Remove("a")
+	if m.Focused() != "" || ps["a"].focused {
+		t.Fatalf("with every brick disabled nothing is focused, got %q", m.Focused())
+	}
+	m.SetEnabled("b", true)
+	requireOnly(t, m, ps, "b")
+}
+
+// grid is a 2x2 layout with a full-width footer:
+//
+//	nw | ne
+//	sw | se
+//	 footer
+func grid(m *Manager, ps map[string]*pane) func() string {
+	return func() string {
+		r := func(id string) rooms.Sizable { return m.Region(id, ps[id]) }
+		return rooms.Dashboard2x2Footer(40, 12, r("nw"), r("ne"), r("sw"), r("se"), r("footer"))
+	}
+}
+
+func TestMoveFollowsLayout(t *testing.T) {
+	ps := panes("nw", "ne", "sw", "se", "footer")
+	m := New()
+	for _, id := range []string{"nw", "ne", "sw", "se", "footer"} {
+		m.Add(id, ps[id])
+	}
+	m.SetLayout(grid(m, ps))
+
+	m.Measure()
+	if r, ok := m.Rect("se"); !ok || r.X != 20 || r.Y != 5 || r.W != 20 || r.H != 6 {
+		t.Fatalf("se rect = %+v, %v", r, ok)
+	}
+
+	steps := []struct{ key, want string }{
+		{"alt+right", "ne"},
+		{"alt+right", "ne"}, // nothing further right
+		{"alt+down", "se"},
+		{"alt+left", "sw"},
+		{"alt+down", "footer"},
+		{"alt+up", "sw"}, // the footer spans both columns; ties go to tab order
+		{"alt+up", "nw"},
+	}
+	for _, s := range steps {
+		m.HandleKey(press(s.key))
+		requireOnly(t, m, ps, s.want)
+	}
+
+	if out := grid(m, ps)(); !strings.Contains(out, "nw") || strings.ContainsRune(out, probeBase) {
+		t.Fatalf("regions should render their brick outside Measure:\n%s", out)
+	}
+}
+
+func TestSetKeymapRemapsFocusKeys(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(Scope, keymap.Binding{Action: ActionNext, Keys: []string{"n"}})
+	ps := panes("a", "b")
+	m := New()
+	m.SetKeymap(r)
+	m.Add("a", ps["a"])
+	m.Add("b", ps["b"])
+
+	if m.HandleKey(press("tab")) {
+		t.Fatal("tab should no longer be bound")
+	}
+	m.HandleKey(press("n"))
+	requireOnly(t, m, ps, "b")
+	if _, ok := r.Lookup(ActionLeft); !ok {
+		t.Fatal("SetKeymap should register the remaining defaults")
+	}
+}
+
+func TestMouseFocusesAndTranslates(t *testing.T) {
+	ps := panes("nw", "ne", "sw", "se", "footer")
+	m := New()
+	for _, id := range []string{"nw", "ne", "sw", "se"} {
+		m.Add(id, ps[id])
+	}
+	m.SetLayout(grid(m, ps)) // the footer is a region but not focusable
+
+	id, local, ok := m.HandleMouse(tea.MouseClickMsg{X: 25, Y: 7, Button: tea.MouseLeft})
+	if !ok || id != "se" {
+		t.Fatalf("click hit %q, %v", id, ok)
+	}
+	requireOnly(t, m, ps, "se")
+	if c, isClick := local.(tea.MouseClickMsg); !isClick || c.X != 5 || c.Y != 2 {
+		t.Fatalf("local event = %#v, want a click at 5,2", local)
+	}
+
+	if id, local, _ := m.HandleMouse(tea.MouseWheelMsg{X: 3, Y: 1, Button: tea.MouseWheelDown}); id != "nw" {
+		t.Fatalf("wheel hit %q", id)
+	} else if _, isWheel := local.(tea.MouseWheelMsg); !isWheel {
+		t.Fatalf("wheel became %T", local)
+	}
+	requireOnly(t, m, ps, "se") // the wheel scrolls without moving focus
+
+	if id, _, ok := m.HandleMouse(tea.MouseClickMsg{X: 3, Y: 11, Button: tea.MouseLeft}); !ok || id != "footer" {
+		t.Fatalf("footer click hit %q, %v", id, ok)
+	}
+	requireOnly(t, m, ps, "se")
+}
+
+func TestMouseReusesMeasureUntilResized(t *testing.T) {
+	ps := panes("nw", "ne", "sw", "se", "footer")
+	m := New()
+	w, renders := 40, 0
+	m.SetLayout(func() string {
+		renders++
+		r := func(id string) rooms.Sizable { return m.Region(id, ps[id]) }
+		return rooms.Dashboard2x2Footer(w, 12, r("nw"), r("ne"), r("sw"), r("se"), r("footer"))
+	})
+
+	for x := range 5 {
+		m.HandleMouse(tea.MouseMotionMsg{X: x, Y: 1})
+	}
+	if renders != 1 {
+		t.Fatalf("layout rendered %d times for 5 motion events, want 1", renders)
+	}
+
+	w = 60
+	m.layout() // the app's next frame resizes every region
+	if id, _, _ := m.HandleMouse(tea.MouseMotionMsg{X: 45, Y: 1}); id != "ne" || renders != 3 {
+		t.Fatalf("hit %q after %d renders, want ne after a fresh measure", id, renders)
+	}
+}
+
+func TestHitPrefersTheFirstRegionWhereRectsOverlap(t *testing.T) {
+	m := New()
+	for range 20 {
+		m.regions = []string{"a", "b"}
+		m.rects = map[string]Rect{"b": {0, 0, 10, 10}, "a": {5, 5, 10, 10}}
+		if id, _ := m.Hit(6, 6); id != "a" {
+			t.Fatalf("overlap hit %q, want a", id)
+		}
+	}
+}
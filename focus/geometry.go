@@ -0,0 +1,215 @@
+package focus
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Rect is where a brick sits in the rendered layout, in cells.
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Contains reports whether the cell at x, y is inside r.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
+// probeBase is the first of the private-use runes Measure paints regions
+// with. They are one cell wide and never appear in real content.
+const probeBase = '\ue000'
+
+// Region wraps the layout cell that belongs to id — the brick itself, or
+// the card around it — and renders it unchanged. Pass it to the room in
+// place of v so Measure can find where id sits on screen. id need not be
+// registered with Add: a region for a footer or a status bar is found by
+// Hit but never takes focus.
+func (m *Manager) Region(id string, v Sizable) Sizable {
+	if !slices.Contains(m.regions, id) {
+		m.regions = append(m.regions, id)
+		m.stale = true
+	}
+	return &region{m: m, id: id, inner: v}
+}
+
+type region struct {
+	m             *Manager
+	id            string
+	inner         Sizable
+	width, height int
+}
+
+func (r *region) SetSize(width, height int) {
+	r.width, r.height = width, height
+	r.inner.SetSize(width, height)
+	if size := [2]int{width, height}; r.m.sizes[r.id] != size {
+		if r.m.sizes == nil {
+			r.m.sizes = make(map[string][2]int)
+		}
+		r.m.sizes[r.id] = size
+		r.m.stale = true
+	}
+}
+
+func (r *region) View() tea.View {
+	if r.m.probing == nil {
+		return r.inner.View()
+	}
+	probe, ok := r.m.probing[r.id]
+	if !ok {
+		probe = probeBase + rune(len(r.m.probing))
+		r.m.probing[r.id] = probe
+	}
+	row := strings.Repeat(string(probe), max(0, r.width))
+	rows := make([]string, max(0, r.height))
+	for i := range rows {
+		rows[i] = row
+	}
+	return tea.NewView(strings.Join(rows, "\n"))
+}
+
+// SetLayout sets the function that renders the page, with every focusable
+// brick wrapped in Region. Directional moves and mouse hits call it to
+// measure the layout; without it only tab order works.
+func (m *Manager) SetLayout(render func() string) {
+	m.layout = render
+	m.stale = true
+}
+
+// Measure renders the layout with each Region painted solid and records
+// where each one landed. Move calls it, and HandleMouse calls it when a
+// region has been resized since; call it yourself before Rect or Hit, or
+// when the layout moves a region without resizing it.
+func (m *Manager) Measure() {
+	m.rects = make(map[string]Rect)
+	if m.layout == nil {
+		return
+	}
+	m.probing = make(map[string]rune, len(m.items))
+	out := m.layout()
+	owner := make(map[rune]string, len(m.probing))
+	for id, r := range m.probing {
+		owner[r] = id
+	}
+	m.probing = nil
+
+	type span struct{ x0, y0, x1, y1 int }
+	found := make(map[string]*span)
+	for y, line := range strings.Split(ansi.Strip(out), "\n") {
+		x := 0
+		for _, c := range line {
+			if id, ok := owner[c]; ok {
+				if s := found[id]; s == nil {
+					found[id] = &span{x, y, x, y}
+				} else {
+					s.x0, s.y0 = min(s.x0, x), min(s.y0, y)
+					s.x1, s.y1 = max(s.x1, x), max(s.y1, y)
+				}
+			}
+			x += ansi.StringWidth(string(c))
+		}
+	}
+	for id, s := range found {
+		m.rects[id] = Rect{X: s.x0, Y: s.y0, W: s.x1 - s.x0 + 1, H: s.y1 - s.y0 + 1}
+	}
+	m.stale = false
+}
+
+// Rect returns where id was found by the last Measure. Bricks the layout
+// did not render have no rect.
+func (m *Manager) Rect(id string) (Rect, bool) {
+	r, ok := m.rects[id]
+	return r, ok
+}
+
+// Hit returns the region under the cell at x, y, as of the last Measure.
+// Where regions overlap, the one the layout rendered first wins.
+func (m *Manager) Hit(x, y int) (string, bool) {
+	for _, id := range m.regions {
+		if r, ok := m.rects[id]; ok && r.Contains(x, y) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// Move focuses the nearest enabled brick in direction d from the focused
+// one, measured from the layout. It reports false when there is none.
+func (m *Manager) Move(d Direction) bool {
+	if m.current < 0 {
+		return false
+	}
+	m.Measure()
+	from, ok := m.rects[m.items[m.current].id]
+	if !ok {
+		return false
+	}
+
+	best, bestScore := -1, [3]int{}
+	for i, it := range m.items {
+		r, ok := m.rects[it.id]
+		if i == m.current || it.disabled || !ok {
+			continue
+		}
+		score, ok := distance(from, r, d)
+		if ok && (best < 0 || less(score, bestScore)) {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return false
+	}
+	m.current = best
+	m.sync()
+	return true
+}
+
+// distance scores a move from a to b in direction d: first how far apart
+// they are across the move (0 when they overlap), then the gap along it,
+// then how far their centers are off-axis. ok is false when b is not in
+// direction d at all.
+func distance(a, b Rect, d Direction) (score [3]int, ok bool) {
+	// Rotate so the move always goes along +x.
+	type span struct{ lo, hi int }
+	var along, alongB, across, acrossB span
+	switch d {
+	case Right:
+		along, alongB = span{a.X, a.X + a.W}, span{b.X, b.X + b.W}
+		across, acrossB = span{a.Y, a.Y + a.H}, span{b.Y, b.Y + b.H}
+	case Left:
+		along, alongB = span{-a.X - a.W, -a.X}, span{-b.X - b.W, -b.X}
+		across, acrossB = span{a.Y, a.Y + a.H}, span{b.Y, b.Y + b.H}
+	case Down:
+		along, alongB = span{a.Y, a.Y + a.H}, span{b.Y, b.Y + b.H}
+		across, acrossB = span{a.X, a.X + a.W}, span{b.X, b.X + b.W}
+	case Up:
+		along, alongB = span{-a.Y - a.H, -a.Y}, span{-b.Y - b.H, -b.Y}
+		across, acrossB = span{a.X, a.X + a.W}, span{b.X, b.X + b.W}
+	}
+	if alongB.lo < along.hi {
+		return score, false
+	}
+	score[0] = max(0, max(acrossB.lo-across.hi, across.lo-acrossB.hi))
+	score[1] = alongB.lo - along.hi
+	score[2] = abs((across.lo + across.hi) - (acrossB.lo + acrossB.hi))
+	return score, true
+}
+
+func less(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
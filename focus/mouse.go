@@ -0,0 +1,46 @@
+package focus
+
+import tea "charm.land/bubbletea/v2"
+
+// HandleMouse finds the region under a mouse event. It reuses the last
+// Measure until a region is resized, so motion events do not render the
+// layout again. A left click focuses the brick registered under that region's id.
+// It returns the id and the event moved into the region's own coordinates,
+// ready to send to the brick; ok is false when no region is under the
+// pointer.
+//
+// msg must be relative to the layout's top-left cell; shift it with
+// Translate when the layout is drawn below a header or inside a frame.
+func (m *Manager) HandleMouse(msg tea.MouseMsg) (id string, local tea.MouseMsg, ok bool) {
+	if m.stale || m.rects == nil {
+		m.Measure()
+	}
+	mouse := msg.Mouse()
+	id, ok = m.Hit(mouse.X, mouse.Y)
+	if !ok {
+		return "", msg, false
+	}
+	if _, click := msg.(tea.MouseClickMsg); click && mouse.Button == tea.MouseLeft {
+		m.Focus(id)
+	}
+	r := m.rects[id]
+	return id, Translate(msg, -r.X, -r.Y), true
+}
+
+// Translate moves a mouse event by dx, dy cells, keeping its kind.
+func Translate(msg tea.MouseMsg, dx, dy int) tea.MouseMsg {
+	mouse := msg.Mouse()
+	mouse.X += dx
+	mouse.Y += dy
+	switch msg.(type) {
+	case tea.MouseClickMsg:
+		return tea.MouseClickMsg(mouse)
+	case tea.MouseReleaseMsg:
+		return tea.MouseReleaseMsg(mouse)
+	case tea.MouseWheelMsg:
+		return tea.MouseWheelMsg(mouse)
+	case tea.MouseMotionMsg:
+		return tea.MouseMotionMsg(mouse)
+	}
+	return msg
+}
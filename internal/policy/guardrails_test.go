@@ -51,6 +51,38 @@ func TestBricksDoNotImportOtherBricks(t *testing.T) {
 	}
 }
 
+func TestKeymapStaysStandalone(t *testing.T) {
+	root := repoRoot(t)
+	files := mustGoFiles(t, filepath.Join(root, "keymap"))
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		for _, imp := range mustImports(t, file) {
+			if strings.HasPrefix(imp, "github.com/cloudboy-jh/bentotui/") {
+				t.Fatalf("keymap is a shared import and cannot depend on %q in %s", imp, rel(root, file))
+			}
+		}
+	}
+}
+
+func TestFocusOnlyImportsKeymap(t *testing.T) {
+	root := repoRoot(t)
+	files := mustGoFiles(t, filepath.Join(root, "focus"))
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		for _, imp := range mustImports(t, file) {
+			if strings.HasPrefix(imp, "github.com/cloudboy-jh/bentotui/") && imp != "github.com/cloudboy-jh/bentotui/keymap" {
+				t.Fatalf("focus is a shared import and cannot depend on %q in %s", imp, rel(root, file))
+			}
+		}
+	}
+}
+
 func TestBentosAvoidRawBubblesImports(t *testing.T) {
 	root := repoRoot(t)
 	files := mustGoFiles(t, filepath.Join(root, "registry", "bentos"))
@@ -0,0 +1,187 @@
+package keymap
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrUnknownAction reports a remap of an action nobody registered.
+var ErrUnknownAction = errors.New("unknown action")
+
+// LineError is a problem on one line of a keymap file.
+type LineError struct {
+	Path string // empty when loaded from a reader
+	Line int
+	Err  error
+}
+
+func (e LineError) Error() string {
+	where := strconv.Itoa(e.Line)
+	if e.Path != "" {
+		where = e.Path + ":" + where
+	}
+	return fmt.Sprintf("keymap: %s: %v", where, e.Err)
+}
+
+func (e LineError) Unwrap() error { return e.Err }
+
+// DefaultPath returns the per-app keymap file:
+// $XDG_CONFIG_HOME/<app>/keymap.conf, or the platform config folder.
+func DefaultPath(app string) (string, error) {
+	if app == "" {
+		return "", errors.New("keymap: path needs an app name")
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, app, "keymap.conf"), nil
+}
+
+// Remap replaces the keys of a registered action. No keys unbinds it. The
+// binding's HelpKey is dropped so hints show the new first key.
+func (r *Registry) Remap(action string, keys ...string) error {
+	for _, k := range keys {
+		if err := ValidKey(k); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i, ok := r.index[action]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownAction, action)
+	}
+	r.bindings[i].Keys = slices.Clone(keys)
+	r.bindings[i].HelpKey = ""
+	return nil
+}
+
+// LoadFile applies the keymap file at path; see Load for the format. A
+// missing file is not an error.
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return r.load(f, path)
+}
+
+// Load applies a keymap file to bindings that are already registered, so
+// register every brick first. Each line names an action and its new keys,
+// separated by spaces; an empty list unbinds the action:
+//
+//	# vim-style tabs
+//	tabs.prev = h ctrl+b
+//	tabs.next = l ctrl+f
+//	app.quit  =
+//
+// Valid lines are applied even when others fail. The returned error joins a
+// LineError for every unknown action, invalid key string and malformed line.
+func (r *Registry) Load(rd io.Reader) error { return r.load(rd, "") }
+
+func (r *Registry) load(rd io.Reader, path string) error {
+	var errs []error
+	fail := func(line int, err error) { errs = append(errs, LineError{Path: path, Line: line, Err: err}) }
+
+	sc := bufio.NewScanner(rd)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		action, keys, ok := strings.Cut(line, "=")
+		action = strings.TrimSpace(action)
+		if !ok || action == "" {
+			fail(n, fmt.Errorf("want \"action = keys\", got %q", line))
+			continue
+		}
+		if err := r.Remap(action, strings.Fields(keys)...); err != nil {
+			fail(n, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// modifiers in the order tea.KeyMsg.String() writes them.
+var modifiers = []string{"ctrl", "alt", "shift", "meta", "hyper", "super"}
+
+// namedKeys are the non-printable key names tea.KeyMsg.String() reports.
+var namedKeys = map[string]bool{
+	"enter": true, "tab": true, "backspace": true, "esc": true, "space": true,
+	"up": true, "down": true, "left": true, "right": true, "begin": true,
+	"find": true, "insert": true, "delete": true, "select": true,
+	"pgup": true, "pgdown": true, "home": true, "end": true,
+	"kpenter": true, "kpequal": true, "kpmul": true, "kpplus": true,
+	"kpcomma": true, "kpminus": true, "kpperiod": true, "kpdiv": true,
+	"kpsep": true, "kpup": true, "kpdown": true, "kpleft": true,
+	"kpright": true, "kppgup": true, "kppgdown": true, "kphome": true,
+	"kpend": true, "kpinsert": true, "kpdelete": true, "kpbegin": true,
+	"kp0": true, "kp1": true, "kp2": true, "kp3": true, "kp4": true,
+	"kp5": true, "kp6": true, "kp7": true, "kp8": true, "kp9": true,
+}
+
+// ValidKey reports whether s is a key string tea.KeyMsg.String() can
+// produce: optional modifiers in ctrl+alt+shift+meta+hyper+super order, then
+// a single character or a named key such as "pgup", "f5" or "kp7".
+func ValidKey(s string) error {
+	invalid := func(why string) error { return fmt.Errorf("invalid key %q: %s", s, why) }
+	if s == "" {
+		return invalid("empty")
+	}
+
+	base, mods := s, []string(nil)
+	if i := strings.LastIndex(s[:len(s)-1], "+"); i >= 0 {
+		base, mods = s[i+1:], strings.Split(s[:i], "+")
+	}
+
+	last := -1
+	for _, m := range mods {
+		pos := slices.Index(modifiers, m)
+		switch {
+		case pos < 0:
+			return invalid("unknown modifier " + strconv.Quote(m))
+		case pos <= last:
+			return invalid("write modifiers in the order " + strings.Join(modifiers, "+"))
+		}
+		last = pos
+	}
+
+	if utf8.RuneCountInString(base) == 1 {
+		r, _ := utf8.DecodeRuneInString(base)
+		switch {
+		case unicode.IsSpace(r) || !unicode.IsPrint(r):
+			return invalid("use \"space\" or a key name for invisible keys")
+		case len(mods) == 1 && mods[0] == "shift" && unicode.IsLetter(r):
+			return invalid(fmt.Sprintf("shifted letters arrive as text; use %q", strings.ToUpper(base)))
+		}
+		return nil
+	}
+	if namedKeys[base] || isFunctionKey(base) {
+		return nil
+	}
+	return invalid("unknown key name " + strconv.Quote(base))
+}
+
+func isFunctionKey(s string) bool {
+	digits, ok := strings.CutPrefix(s, "f")
+	n, err := strconv.Atoi(digits)
+	return ok && err == nil && n >= 1 && n <= 63 && strconv.Itoa(n) == digits
+}
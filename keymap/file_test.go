@@ -0,0 +1,99 @@
+package keymap
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidKey(t *testing.T) {
+	for _, k := range []string{"a", "A", "?", "+", ",", "ctrl++", "ctrl+a", "ctrl+alt+shift+up", "space", "esc", "pgdown", "f12", "kp7", "alt+enter", "é"} {
+		if err := ValidKey(k); err != nil {
+			t.Errorf("ValidKey(%q) = %v", k, err)
+		}
+	}
+	for k, why := range map[string]string{
+		"":            "empty",
+		" ":           "space",
+		"escape":      "unknown key name",
+		"f0":          "unknown key name",
+		"f01":         "unknown key name",
+		"cmd+a":       "unknown modifier",
+		"alt+ctrl+a":  "order",
+		"ctrl+ctrl+a": "order",
+		"shift+a":     `use "A"`,
+	} {
+		err := ValidKey(k)
+		if err == nil || !strings.Contains(err.Error(), why) {
+			t.Errorf("ValidKey(%q) = %v, want error mentioning %q", k, err, why)
+		}
+	}
+}
+
+func TestLoadRemapsAndReports(t *testing.T) {
+	r := NewRegistry()
+	r.Register("tabs",
+		Binding{Action: "tabs.prev", Keys: []string{"left", "h"}, HelpKey: "left", Help: "prev tab"},
+		Binding{Action: "tabs.next", Keys: []string{"right", "l"}, Help: "next tab"},
+	)
+	r.Register(Global, Binding{Action: "app.quit", Keys: []string{"q"}, Help: "quit"})
+
+	err := r.Load(strings.NewReader(`
+# emacs
+tabs.prev = ctrl+b alt+ctrl+x
+tabs.next = ctrl+f
+list.down = ctrl+n
+app.quit =
+nonsense
+`))
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	var le LineError
+	if !errors.As(err, &le) || le.Line != 3 {
+		t.Fatalf("first error should be on line 3, got %v", err)
+	}
+	if !errors.Is(err, ErrUnknownAction) {
+		t.Fatalf("unknown action not reported: %v", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{`3: invalid key "alt+ctrl+x"`, `5: unknown action "list.down"`, `7: want "action = keys"`} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q missing %q", msg, want)
+		}
+	}
+
+	if b, _ := r.Lookup("tabs.prev"); strings.Join(b.Keys, " ") != "left h" {
+		t.Fatalf("a line with an invalid key should be skipped, got %v", b.Keys)
+	}
+	if b, _ := r.Lookup("tabs.next"); strings.Join(b.Keys, " ") != "ctrl+f" || b.Label() != "ctrl+f" {
+		t.Fatalf("tabs.next = %+v", b)
+	}
+	if b, _ := r.Lookup("app.quit"); len(b.Keys) != 0 || r.Key("app.quit").Enabled() {
+		t.Fatal("an empty key list should unbind the action")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	r := NewRegistry()
+	r.Register("tabs", Binding{Action: "tabs.next", Keys: []string{"right"}})
+
+	dir := t.TempDir()
+	if err := r.LoadFile(filepath.Join(dir, "missing.conf")); err != nil {
+		t.Fatalf("missing file should be ignored, got %v", err)
+	}
+
+	path := filepath.Join(dir, "keymap.conf")
+	if err := os.WriteFile(path, []byte("tabs.next = l\ntabs.nxt = n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := r.LoadFile(path)
+	if err == nil || !strings.Contains(err.Error(), path+":2:") {
+		t.Fatalf("error should name the file and line, got %v", err)
+	}
+	if b, _ := r.Lookup("tabs.next"); strings.Join(b.Keys, " ") != "l" {
+		t.Fatalf("tabs.next = %v", b.Keys)
+	}
+}
@@ -0,0 +1,214 @@
+// Package keymap is the app-level keybinding registry. Bricks register their
+// default bindings under a scope, the app checks the scopes that are active
+// together for conflicts, and the same bindings feed footer cards, kbd hints
+// and the command palette's Keybind column.
+//
+// Every remappable brick follows the same convention. SetKeymap(r) registers
+// the brick's default bindings under its Scope and keeps r; the brick then
+// reads each key from r through KeyOr whenever it handles input, so a keymap
+// file loaded afterwards, or a later Remap, applies without calling SetKeymap
+// again. Load rejects actions nobody registered, so call SetKeymap before
+// loading. A nil r puts the brick back on its own KeyMap.
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"charm.land/bubbles/v2/key"
+)
+
+// Scope groups the bindings of one component, e.g. "tabs" or "palette".
+// Keys only conflict between scopes that are active at the same time.
+type Scope string
+
+// Global holds app-wide bindings that stay active under every focus.
+const Global Scope = "global"
+
+// Binding is one action and the keys that trigger it.
+type Binding struct {
+	Action  string   // stable ID, e.g. "tabs.next"
+	Scope   Scope    // set by Registry.Register
+	Keys    []string // key strings as reported by tea.KeyMsg.String()
+	HelpKey string   // key label in hints — empty = first of Keys
+	Help    string   // short description, e.g. "next tab"
+}
+
+// FromKey converts a bubbles key.Binding, keeping its keys and help text.
+func FromKey(action string, k key.Binding) Binding {
+	h := k.Help()
+	return Binding{Action: action, Keys: k.Keys(), HelpKey: h.Key, Help: h.Desc}
+}
+
+// Label is the key text shown in hints.
+func (b Binding) Label() string {
+	if b.HelpKey != "" {
+		return b.HelpKey
+	}
+	if len(b.Keys) > 0 {
+		return b.Keys[0]
+	}
+	return ""
+}
+
+// Key returns the binding as a bubbles key.Binding. A binding with no keys
+// is disabled.
+func (b Binding) Key() key.Binding {
+	k := key.NewBinding(key.WithKeys(b.Keys...), key.WithHelp(b.Label(), b.Help))
+	if len(b.Keys) == 0 {
+		k.SetEnabled(false)
+	}
+	return k
+}
+
+// Matches reports whether msg is one of the binding's keys.
+func (b Binding) Matches(msg fmt.Stringer) bool {
+	return slices.Contains(b.Keys, msg.String())
+}
+
+// Conflict is a key bound to more than one action in the checked scopes.
+type Conflict struct {
+	Key      string
+	Bindings []Binding
+}
+
+func (c Conflict) Error() string {
+	names := make([]string, len(c.Bindings))
+	for i, b := range c.Bindings {
+		names[i] = fmt.Sprintf("%s (%s)", b.Action, b.Scope)
+	}
+	return fmt.Sprintf("keymap: %q is bound to %s", c.Key, strings.Join(names, " and "))
+}
+
+// Registry holds every registered binding in registration order. It is safe
+// for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	bindings []Binding
+	index    map[string]int // action → position in bindings
+}
+
+func NewRegistry() *Registry {
+	return &Registry{index: make(map[string]int)}
+}
+
+// Register adds bindings under scope. An action that is already registered
+// keeps its current keys, so bricks can register their defaults every time
+// they are wired up without undoing app-level changes.
+func (r *Registry) Register(scope Scope, bindings ...Binding) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, b := range bindings {
+		if b.Action == "" {
+			continue
+		}
+		if _, ok := r.index[b.Action]; ok {
+			continue
+		}
+		b.Scope = scope
+		b.Keys = append([]string(nil), b.Keys...)
+		r.index[b.Action] = len(r.bindings)
+		r.bindings = append(r.bindings, b)
+	}
+}
+
+// Lookup returns the binding registered for action.
+func (r *Registry) Lookup(action string) (Binding, bool) {
+	if r == nil {
+		return Binding{}, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, ok := r.index[action]
+	if !ok {
+		return Binding{}, false
+	}
+	return r.bindings[i], true
+}
+
+// Key returns the key.Binding for action, disabled if action is unknown.
+func (r *Registry) Key(action string) key.Binding {
+	b, _ := r.Lookup(action)
+	return b.Key()
+}
+
+// KeyOr is Key, except that a nil r returns fallback. Bricks read their keys
+// through it so a nil registry means their own KeyMap.
+func (r *Registry) KeyOr(action string, fallback key.Binding) key.Binding {
+	if r == nil {
+		return fallback
+	}
+	return r.Key(action)
+}
+
+// Matches reports whether msg triggers action.
+func (r *Registry) Matches(msg fmt.Stringer, action string) bool {
+	b, ok := r.Lookup(action)
+	return ok && b.Matches(msg)
+}
+
+// Bindings returns the bindings in the given scopes, or every binding when
+// no scope is given, in registration order.
+func (r *Registry) Bindings(scopes ...Scope) []Binding {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Binding, 0, len(r.bindings))
+	for _, b := range r.bindings {
+		if len(scopes) == 0 || slices.Contains(scopes, b.Scope) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// Help returns the bindings in the given scopes that have help text, for
+// footer cards and hint rows.
+func (r *Registry) Help(scopes ...Scope) []Binding {
+	all := r.Bindings(scopes...)
+	out := all[:0]
+	for _, b := range all {
+		if b.Help != "" && len(b.Keys) > 0 {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+// Conflicts returns every key bound to more than one action across scopes,
+// which should be the scopes active together (for example Global plus the
+// focused component). With no scopes every binding is checked.
+func (r *Registry) Conflicts(scopes ...Scope) []Conflict {
+	byKey := make(map[string][]Binding)
+	for _, b := range r.Bindings(scopes...) {
+		for _, k := range b.Keys {
+			if n := len(byKey[k]); n > 0 && byKey[k][n-1].Action == b.Action {
+				continue // the same key listed twice on one binding
+			}
+			byKey[k] = append(byKey[k], b)
+		}
+	}
+	var out []Conflict
+	for k, bs := range byKey {
+		if len(bs) > 1 {
+			out = append(out, Conflict{Key: k, Bindings: bs})
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Key < out[b].Key })
+	return out
+}
+
+// Check returns the conflicts in scopes joined into one error, or nil.
+func (r *Registry) Check(scopes ...Scope) error {
+	var errs []error
+	for _, c := range r.Conflicts(scopes...) {
+		errs = append(errs, c)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package keymap
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func bind(action string, keys ...string) Binding {
+	return Binding{Action: action, Keys: keys, Help: action}
+}
+
+func TestRegisterKeepsExistingKeys(t *testing.T) {
+	r := NewRegistry()
+	r.Register("tabs", bind("tabs.next", "n"))
+	r.Register("tabs", bind("tabs.next", "right", "l"), bind("tabs.prev", "left"))
+
+	b, ok := r.Lookup("tabs.next")
+	if !ok || strings.Join(b.Keys, ",") != "n" || b.Scope != "tabs" {
+		t.Fatalf("tabs.next = %+v, want the first registration kept", b)
+	}
+	if got := len(r.Bindings("tabs")); got != 2 {
+		t.Fatalf("tabs has %d bindings, want 2", got)
+	}
+	if !r.Matches(tea.KeyPressMsg{Code: 'n', Text: "n"}, "tabs.next") || r.Matches(tea.KeyPressMsg{Code: tea.KeyRight}, "tabs.next") {
+		t.Fatal("Matches should use the registered keys")
+	}
+	if r.Key("missing").Enabled() {
+		t.Fatal("unknown actions should give a disabled key.Binding")
+	}
+
+	fallback := bind("tabs.next", "x").Key()
+	if got := r.KeyOr("tabs.next", fallback).Keys(); strings.Join(got, ",") != "n" {
+		t.Fatalf("KeyOr = %v, want the registered keys", got)
+	}
+	var none *Registry
+	if got := none.KeyOr("tabs.next", fallback).Keys(); strings.Join(got, ",") != "x" {
+		t.Fatalf("KeyOr on a nil registry = %v, want the fallback", got)
+	}
+}
+
+func TestConflictsOnlyBetweenActiveScopes(t *testing.T) {
+	r := NewRegistry()
+	r.Register(Global, bind("app.quit", "q", "q"), bind("app.theme", "t"))
+	r.Register("list", bind("list.down", "j", "down"))
+	r.Register("tabs", bind("tabs.next", "l", "t"))
+
+	if err := r.Check(Global, "list"); err != nil {
+		t.Fatalf("Global and list do not overlap, got %v", err)
+	}
+	cs := r.Conflicts(Global, "tabs")
+	if len(cs) != 1 || cs[0].Key != "t" || len(cs[0].Bindings) != 2 {
+		t.Fatalf("conflicts = %+v, want one on t", cs)
+	}
+	want := `keymap: "t" is bound to app.theme (global) and tabs.next (tabs)`
+	if err := r.Check(Global, "tabs"); err == nil || err.Error() != want {
+		t.Fatalf("Check = %v, want %s", err, want)
+	}
+}
+
+func TestHelpSkipsUndocumentedAndUnbound(t *testing.T) {
+	r := NewRegistry()
+	r.Register(Global,
+		Binding{Action: "a", Keys: []string{"a"}, Help: "alpha", HelpKey: "A"},
+		Binding{Action: "b", Keys: []string{"b"}},
+		Binding{Action: "c", Help: "unbound"},
+	)
+	help := r.Help(Global)
+	if len(help) != 1 || help[0].Label() != "A" {
+		t.Fatalf("Help = %+v", help)
+	}
+}
@@ -1,14 +1,27 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/registry/bentos/app-shell/state"
+	"github.com/cloudboy-jh/bentotui/registry/bricks/dialog"
 )
 
 func main() {
+	mouse := flag.Bool("mouse", false, "click to focus cards and run footer cards, scroll with the wheel")
+	flag.Parse()
+
 	m := state.NewModel()
+	m.SetMouse(*mouse)
+	if h, err := dialog.OpenHistory("bentotui-app-shell"); err == nil {
+		m.SetHistory(h)
+	}
+	if path, err := keymap.DefaultPath("bentotui-app-shell"); err == nil {
+		_ = m.LoadKeymap(path) // problems are shown in the status bar
+	}
 	if _, err := tea.NewProgram(m).Run(); err != nil {
 		fmt.Printf("error: %v\n", err)
 	}
@@ -6,6 +6,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/focus"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/card"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/list"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/progress"
@@ -25,8 +26,19 @@ type centerDeck struct {
 	services *table.Model
 	queue    *list.Model
 	progress *progressPane
+
+	focus *focus.Manager
 }
 
+// Focus region ids, one per card, plus the footer, which the shell wraps so
+// mouse clicks can find it.
+const (
+	focusServices = "services"
+	focusQueue    = "queue"
+	focusProgress = "progress"
+	regionFooter  = "footer"
+)
+
 func newCenterDeck() *centerDeck {
 	t := table.New("SERVICE", "OWNER", "P95", "ERR%", "DEPLOY")
 	t.SetCompact(true)
@@ -55,22 +67,31 @@ func newCenterDeck() *centerDeck {
 
 	p := newProgressPane()
 
-	return &centerDeck{
+	d := &centerDeck{
 		tableCard:    card.New(card.Title("Services"), card.Meta("stable view of key metrics"), card.Content(t), card.Inset(1)),
 		queueCard:    card.New(card.Title("Queue"), card.Meta("lightweight task lane"), card.Content(q), card.Inset(1)),
 		progressCard: card.New(card.Title("Progress"), card.Meta("simple throughput snapshot"), card.Content(p), card.Inset(1)),
 		services:     t,
 		queue:        q,
 		progress:     p,
+		focus:        focus.New(),
 	}
+	d.focus.Add(focusServices, t, d.tableCard)
+	d.focus.Add(focusQueue, q, d.queueCard)
+	d.focus.Add(focusProgress, d.progressCard)
+	d.focus.SetLayout(d.render)
+	return d
 }
 
 func (d *centerDeck) Init() tea.Cmd                           { return nil }
 func (d *centerDeck) Update(msg tea.Msg) (tea.Model, tea.Cmd) { return d, nil }
 
-func (d *centerDeck) View() tea.View {
+func (d *centerDeck) View() tea.View { return tea.NewView(d.render()) }
+
+// render lays out the cards, each as a focus region.
+func (d *centerDeck) render() string {
 	if d.width <= 0 || d.height <= 0 {
-		return tea.NewView("")
+		return ""
 	}
 	topH := max(6, (d.height*3)/5)
 	if topH >= d.height {
@@ -81,8 +102,11 @@ func (d *centerDeck) View() tea.View {
 	}
 	bottomH := max(1, d.height-topH)
 
-	bottom := rooms.HSplit(d.width, bottomH, d.queueCard, d.progressCard, rooms.WithGutter(1))
-	return tea.NewView(rooms.BigTopStrip(d.width, d.height, bottomH, d.tableCard, rooms.Static(bottom)))
+	bottom := rooms.HSplit(d.width, bottomH,
+		d.focus.Region(focusQueue, d.queueCard),
+		d.focus.Region(focusProgress, d.progressCard),
+		rooms.WithGutter(1))
+	return rooms.BigTopStrip(d.width, d.height, bottomH, d.focus.Region(focusServices, d.tableCard), rooms.Static(bottom))
 }
 
 func (d *centerDeck) SetSize(width, height int) {
@@ -108,17 +132,28 @@ func (d *centerDeck) SetSize(width, height int) {
 	d.progress.SetSize(max(18, rightW-2), max(4, bottomH-4))
 }
 
+// SetActiveSection focuses the card for section. Overview focuses the
+// services card, the first one on the page.
 func (d *centerDeck) SetActiveSection(section string) {
-
 	switch section {
-	case "Services":
-
 	case "Queue":
-
+		d.focus.Focus(focusQueue)
 	case "Progress":
-
+		d.focus.Focus(focusProgress)
 	default:
+		d.focus.Focus(focusServices)
+	}
+}
 
+// FocusedSection is the section of the focused card.
+func (d *centerDeck) FocusedSection() string {
+	switch d.focus.Focused() {
+	case focusQueue:
+		return "Queue"
+	case focusProgress:
+		return "Progress"
+	default:
+		return "Services"
 	}
 }
 
@@ -126,6 +161,19 @@ func (d *centerDeck) SetQueueCursor(i int) {
 	d.queue.SetCursor(i)
 }
 
+// QueueCursor is the selected queue item.
+func (d *centerDeck) QueueCursor() int { return d.queue.Cursor() }
+
+// Scroll sends a wheel event to the brick in the card region id.
+func (d *centerDeck) Scroll(id string, msg tea.MouseWheelMsg) {
+	switch id {
+	case focusServices:
+		d.services.Update(msg)
+	case focusQueue:
+		d.queue.Update(msg)
+	}
+}
+
 func (d *centerDeck) SetCompact(v bool) {
 	d.services.SetCompact(v)
 	if v {
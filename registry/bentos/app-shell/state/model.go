@@ -1,14 +1,17 @@
 package state
 
 import (
+	"errors"
 	"fmt"
-	"strconv"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/focus"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/registry/bentos/app-shell/ui"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/bar"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/dialog"
+	"github.com/cloudboy-jh/bentotui/registry/bricks/help"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/surface"
 	"github.com/cloudboy-jh/bentotui/registry/rooms"
 	"github.com/cloudboy-jh/bentotui/theme"
@@ -19,6 +22,7 @@ type setSectionMsg struct{ Index int }
 type toggleCompactMsg struct{}
 type pulseProgressMsg struct{}
 type openThemePickerMsg struct{}
+type clearHistoryMsg struct{}
 
 type Model struct {
 	theme  theme.Theme
@@ -31,10 +35,13 @@ type Model struct {
 	compact    bool
 	progress   float64
 	status     string
+	mouse      bool
 
 	centerDeck *centerDeck
 	footer     *bar.Model
 	dialogs    *dialog.Manager
+	history    *dialog.History
+	keys       *keymap.Registry
 
 	themeOrder []string
 	themeIdx   int
@@ -65,12 +72,18 @@ func NewModel() *Model {
 		progress:   0.62,
 		status:     "ready",
 		dialogs:    dialog.New(),
+		history:    dialog.NewHistory(),
 	}
+	m.keys = ui.Keymap(m.sections)
+	m.centerDeck.focus.SetKeymap(m.keys)
+	m.dialogs.SetKeymap(m.keys)
+	m.centerDeck.focus.SetLayout(m.screen)
+	m.reportKeymap(nil)
 
 	m.footer = bar.New(
 		bar.FooterAnchored(),
 		bar.Left("workspace"),
-		bar.Cards(ui.FooterCards()...),
+		bar.Cards(ui.FooterCards(m.keys)...),
 		bar.CompactCards(),
 	)
 	m.footer.SetTheme(m.theme)
@@ -85,8 +98,10 @@ func (m *Model) Init() tea.Cmd { return nil }
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.dialogs.IsOpen() {
 		switch msg.(type) {
-		case dialog.OpenMsg, dialog.CloseMsg:
-			// Handle lifecycle messages in the switch below.
+		case dialog.OpenMsg, dialog.CloseMsg, dialog.CloseAllMsg,
+			setThemeMsg, setSectionMsg, toggleCompactMsg, pulseProgressMsg, openThemePickerMsg, clearHistoryMsg:
+			// Lifecycle and app messages are handled in the switch below, so
+			// results from a closing dialog still reach the shell.
 		default:
 			u, cmd := m.dialogs.Update(msg)
 			m.dialogs = u.(*dialog.Manager)
@@ -105,7 +120,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.dialogs.SetSize(msg.Width, msg.Height)
 		return m, nil
 
-	case dialog.OpenMsg, dialog.CloseMsg:
+	case dialog.OpenMsg, dialog.CloseMsg, dialog.CloseAllMsg:
 		u, cmd := m.dialogs.Update(msg)
 		m.dialogs = u.(*dialog.Manager)
 		return m, cmd
@@ -138,36 +153,28 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.status = fmt.Sprintf("progress %.0f%%", m.progress*100)
 		return m, nil
 
+	case clearHistoryMsg:
+		if err := m.history.Clear(); err != nil {
+			m.status = "history error: " + err.Error()
+		} else {
+			m.status = "recent commands cleared"
+		}
+		return m, nil
+
+	case ui.ActionMsg:
+		return m, m.runAction(msg.Action)
+
+	case tea.MouseMsg:
+		return m, m.handleMouse(msg)
+
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
-		case "up":
-			m.setSection(m.sectionIdx - 1)
-		case "down":
-			m.setSection(m.sectionIdx + 1)
-		case "left":
-			m.queueIdx = max(0, m.queueIdx-1)
-			m.status = "queue cursor <-"
-		case "right":
-			m.queueIdx = min(3, m.queueIdx+1)
-			m.status = "queue cursor ->"
-		case "enter":
-			m.progress += 0.05
-			if m.progress > 1 {
-				m.progress = 0.1
-			}
-			m.status = fmt.Sprintf("pulse %.0f%%", m.progress*100)
-		case "t":
-			m.shiftTheme(1)
-		case "c":
-			m.compact = !m.compact
-			m.status = ternary(m.compact, "table compact", "table comfortable")
-		case "ctrl+k":
-			return m, m.openPalette()
-		default:
-			if n, err := strconv.Atoi(msg.String()); err == nil {
-				m.setSection(n - 1)
+		if m.centerDeck.focus.HandleKey(msg) {
+			m.focusSection(m.centerDeck.FocusedSection())
+			return m, nil
+		}
+		for _, b := range m.keys.Bindings(keymap.Global) {
+			if b.Matches(msg) {
+				return m, m.runAction(b.Action)
 			}
 		}
 		return m, nil
@@ -176,6 +183,84 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// runAction does what a global binding does. Keys and footer card clicks
+// both end up here.
+func (m *Model) runAction(action string) tea.Cmd {
+	switch action {
+	case ui.ActionQuit:
+		return tea.Quit
+	case ui.ActionSectionPrev:
+		m.setSection(m.sectionIdx - 1)
+	case ui.ActionSectionNext:
+		m.setSection(m.sectionIdx + 1)
+	case ui.ActionQueuePrev:
+		m.queueIdx = max(0, m.queueIdx-1)
+		m.status = "queue cursor <-"
+	case ui.ActionQueueNext:
+		m.queueIdx = min(3, m.queueIdx+1)
+		m.status = "queue cursor ->"
+	case ui.ActionPulse:
+		m.progress += 0.05
+		if m.progress > 1 {
+			m.progress = 0.1
+		}
+		m.status = fmt.Sprintf("pulse %.0f%%", m.progress*100)
+	case ui.ActionNextTheme:
+		m.shiftTheme(1)
+	case ui.ActionCompact:
+		m.compact = !m.compact
+		m.status = ternary(m.compact, "table compact", "table comfortable")
+	case ui.ActionPalette:
+		return m.openPalette()
+	case ui.ActionHelp:
+		return m.openHelp()
+	case focus.ActionNext:
+		m.centerDeck.focus.Next()
+		m.focusSection(m.centerDeck.FocusedSection())
+	default:
+		for i := range m.sections {
+			if action == ui.GotoAction(i) {
+				m.setSection(i)
+			}
+		}
+	}
+	return nil
+}
+
+// handleMouse sends a mouse event to whatever is under it. A click focuses
+// the card under it and picks its section, the wheel scrolls the table or
+// queue without moving focus, and a click on a footer card runs its action.
+func (m *Model) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	id, local, ok := m.centerDeck.focus.HandleMouse(msg)
+	if !ok {
+		return nil
+	}
+	if id == regionFooter {
+		_, cmd := m.footer.Update(local)
+		return cmd
+	}
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		if msg.Button == tea.MouseLeft {
+			m.focusSection(m.centerDeck.FocusedSection())
+		}
+	case tea.MouseWheelMsg:
+		m.centerDeck.Scroll(id, msg)
+		m.queueIdx = m.centerDeck.QueueCursor()
+	}
+	return nil
+}
+
+// SetMouse turns mouse reporting on or off. It is off by default so the
+// terminal keeps its own text selection.
+func (m *Model) SetMouse(on bool) { m.mouse = on }
+
+// screen lays out the deck above the footer. The footer is a focus region
+// so mouse events can find it; it never takes focus.
+func (m *Model) screen() string {
+	return rooms.AppShell(m.width, m.height, m.centerDeck, m.centerDeck.focus.Region(regionFooter, m.footer))
+}
+
 func (m *Model) View() tea.View {
 	t := m.theme
 	canvas := t.Background()
@@ -189,18 +274,21 @@ func (m *Model) View() tea.View {
 
 	m.syncAll()
 
-	screen := rooms.AppShell(m.width, m.height, m.centerDeck, m.footer)
+	screen := m.screen()
 
 	surf := surface.New(m.width, m.height)
 	surf.Fill(canvas)
 	surf.Draw(0, 0, screen)
 	if m.dialogs.IsOpen() {
-		surf.DrawCenter(viewString(m.dialogs.View()))
+		surf.Layer(surface.LayerModal).DrawCenter(viewString(m.dialogs.View()))
 	}
 
 	v := tea.NewView(surf.Render())
 	v.AltScreen = true
 	v.BackgroundColor = canvas
+	if m.mouse {
+		v.MouseMode = tea.MouseModeCellMotion
+	}
 	return v
 }
 
@@ -245,6 +333,16 @@ func (m *Model) setSection(idx int) {
 	m.status = "section -> " + strings.ToLower(m.sections[idx])
 }
 
+// focusSection selects the section whose card took focus.
+func (m *Model) focusSection(section string) {
+	for i, s := range m.sections {
+		if s == section {
+			m.setSection(i)
+			return
+		}
+	}
+}
+
 func (m *Model) shiftTheme(step int) {
 	if len(m.themeOrder) == 0 {
 		m.status = "theme registry empty"
@@ -289,34 +387,68 @@ func (m *Model) onThemeChange(msg theme.ThemeChangedMsg) {
 	}
 }
 
+// LoadKeymap applies a keymap file (see keymap.Registry.Load) on top of the
+// shell's bindings and refreshes the footer. Problems are returned and the
+// first one is shown in the status bar.
+func (m *Model) LoadKeymap(path string) error {
+	err := m.keys.LoadFile(path)
+	m.footer.SetCards(ui.FooterCards(m.keys))
+	return m.reportKeymap(err)
+}
+
+// reportKeymap adds conflicts between active scopes to err and shows the
+// first problem in the status bar.
+func (m *Model) reportKeymap(err error) error {
+	err = errors.Join(err, m.keys.Check(keymap.Global, focus.Scope), m.keys.Check(dialog.PaletteScope))
+	if err != nil {
+		m.status, _, _ = strings.Cut(err.Error(), "\n")
+	}
+	return err
+}
+
+// SetHistory replaces the palette's in-memory command history, e.g. with
+// one from dialog.OpenHistory so recents survive restarts.
+func (m *Model) SetHistory(h *dialog.History) {
+	if h != nil {
+		m.history = h
+	}
+}
+
 func (m *Model) openPalette() tea.Cmd {
-	commands := make([]dialog.Command, 0, len(m.sections)+len(m.themeOrder)+4)
+	commands := make([]dialog.Command, 0, len(m.sections)+5)
 	for i, section := range m.sections {
 		idx := i
 		commands = append(commands, dialog.Command{
-			Label:   "Go to " + section,
-			Group:   "Navigate",
-			Keybind: strconv.Itoa(i + 1),
-			Action:  func() tea.Msg { return setSectionMsg{Index: idx} },
+			ID:     ui.GotoAction(i),
+			Label:  "Go to " + section,
+			Group:  "Navigate",
+			Action: func() tea.Msg { return setSectionMsg{Index: idx} },
 		})
 	}
 	commands = append(commands,
-		dialog.Command{Label: "Toggle compact table", Group: "View", Keybind: "c", Action: func() tea.Msg { return toggleCompactMsg{} }},
-		dialog.Command{Label: "Pulse progress", Group: "View", Keybind: "enter", Action: func() tea.Msg { return pulseProgressMsg{} }},
-		dialog.Command{Label: "Theme picker", Group: "Theme", Keybind: "t", Action: func() tea.Msg { return openThemePickerMsg{} }},
+		dialog.Command{ID: ui.ActionCompact, Label: "Toggle compact table", Group: "View", Action: func() tea.Msg { return toggleCompactMsg{} }},
+		dialog.Command{ID: ui.ActionPulse, Label: "Pulse progress", Group: "View", Action: func() tea.Msg { return pulseProgressMsg{} }},
+		dialog.Command{Label: "Theme picker", Group: "Theme", Aliases: []string{"colors"}, Keybind: "t", Action: func() tea.Msg { return openThemePickerMsg{} }},
+		dialog.Command{Label: "Clear recent commands", Group: "App", Aliases: []string{"history"}, Action: func() tea.Msg { return clearHistoryMsg{} }},
 	)
+	themeOptions := make([]dialog.ChoiceOption, 0, len(m.themeOrder))
 	for _, name := range m.themeOrder {
-		themeName := name
-		commands = append(commands, dialog.Command{
-			Label:   "Switch to " + themeName,
-			Group:   "Theme",
-			Keybind: "t",
-			Action:  func() tea.Msg { return setThemeMsg{Name: themeName} },
-		})
+		themeOptions = append(themeOptions, dialog.ChoiceOption{Label: name})
 	}
+	commands = append(commands, dialog.Command{
+		Label: "Switch theme",
+		Group: "Theme",
+		Args: []dialog.Arg{{
+			Key: "theme", Label: "Theme", Kind: dialog.ArgEnum,
+			Options: themeOptions, Default: m.theme.Name(),
+		}},
+		Run: func(a dialog.Args) tea.Msg { return setThemeMsg{Name: a.Get("theme")} },
+	})
 
 	return func() tea.Msg {
 		palette := dialog.NewCommandPalette(commands)
+		palette.SetHistory(m.history)
+		palette.SetKeymap(m.keys)
 		h := clamp(min(24, m.height-4), 12, 24)
 		return dialog.Open(dialog.Custom{
 			DialogTitle: "Command Palette",
@@ -327,6 +459,26 @@ func (m *Model) openPalette() tea.Cmd {
 	}
 }
 
+// openHelp lists the shell's bindings and the palette's, read from the
+// keymap so remapped keys show up.
+func (m *Model) openHelp() tea.Cmd {
+	groups := []help.Group{
+		help.FromBindings("Workspace", m.keys.Help(keymap.Global)...),
+		help.FromBindings("Panes", m.keys.Help(focus.Scope)...),
+		help.FromBindings("Command palette", m.keys.Help(dialog.PaletteScope)...),
+	}
+	return func() tea.Msg {
+		sheet := help.New(help.Groups(groups...), help.OnClose(dialog.Close))
+		sheet.Focus()
+		return dialog.Open(dialog.Custom{
+			DialogTitle: "Keyboard shortcuts",
+			Content:     sheet,
+			Width:       48,
+			Height:      clamp(m.height-4, 12, 30),
+		})
+	}
+}
+
 func (m *Model) openThemePicker() tea.Cmd {
 	return func() tea.Msg {
 		h := len(m.themeOrder) + 8
@@ -1,13 +1,18 @@
 package state
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/dialog"
+	"github.com/cloudboy-jh/bentotui/testkit"
 	"github.com/cloudboy-jh/bentotui/theme"
 )
 
@@ -96,6 +101,182 @@ func TestPaletteThemePickerFlowOpensDialog(t *testing.T) {
 	}
 }
 
+func TestPaletteToThemePickerFlowHeadless(t *testing.T) {
+	original := theme.CurrentThemeName()
+	t.Cleanup(func() { _, _ = theme.SetTheme(original) })
+
+	d := testkit.Boot(t, NewModel(), 100, 30).RequireSize()
+	d.Press("ctrl+k").RequireContains("Command Palette", "Theme picker")
+
+	d.Type("theme pic").RequireContains("Theme picker").RequireNotContains("Go to Services")
+	d.Press("enter").RequireContains("Themes").RequireNotContains("Command Palette").RequireSize()
+
+	d.Press("down")
+	changed := testkit.Emitted[theme.ThemeChangedMsg](d)
+	if len(changed) == 0 {
+		t.Fatalf("expected theme picker navigation to preview a theme")
+	}
+	preview := changed[len(changed)-1].Name
+	if preview == original {
+		t.Fatalf("expected preview to move off %q", original)
+	}
+	d.RequireContains("theme:" + preview)
+
+	d.Press("esc").RequireNotContains("Themes").RequireContains("theme:" + original)
+	if d.Model().(*Model).dialogs.IsOpen() {
+		t.Fatalf("expected dialogs closed after esc")
+	}
+}
+
+func TestPaletteRecentsAndClearHistory(t *testing.T) {
+	d := testkit.Boot(t, NewModel(), 100, 30).RequireSize()
+	d.Press("ctrl+k").Type("compact").Press("enter").RequireContains("table comfortable")
+
+	d.Press("ctrl+k").RequireContains("Recent", "Toggle compact table")
+	d.Type("clear recent").Press("enter").RequireContains("recent commands cleared")
+
+	d.Press("ctrl+k").RequireNotContains("Recent")
+}
+
+func TestPaletteSwitchThemeStep(t *testing.T) {
+	original := theme.CurrentThemeName()
+	t.Cleanup(func() { _, _ = theme.SetTheme(original) })
+
+	var target string
+	for _, name := range theme.AvailableThemes() {
+		if name != original {
+			target = name
+			break
+		}
+	}
+
+	d := testkit.Boot(t, NewModel(), 100, 30).RequireSize()
+	d.Press("ctrl+k").Type("switch theme").Press("enter").RequireContains("Switch theme › Theme")
+	d.Type(target).Press("enter").RequireNotContains("Command Palette").RequireContains("theme:" + target)
+}
+
+func TestKeymapFeedsFooterAndPalette(t *testing.T) {
+	m := NewModel()
+	if err := m.keys.Check(keymap.Global); err != nil {
+		t.Fatalf("global bindings conflict: %v", err)
+	}
+	if strings.HasPrefix(m.status, "keymap:") {
+		t.Fatalf("status reports a conflict: %s", m.status)
+	}
+
+	d := testkit.Boot(t, m, 100, 30).RequireSize().RequireContains("up/down", "ctrl+k")
+	d.Press("3").RequireContains("section -> queue")
+	d.Press("ctrl+k").Type("go to over")
+	row := "Overview"
+	for _, line := range d.Frame().Lines() {
+		if strings.Contains(line, "Go to Overview") {
+			row = line
+		}
+	}
+	if f := strings.Fields(row[strings.Index(row, "Overview"):]); len(f) < 2 || f[1] != "1" {
+		t.Fatalf("palette should show the keymap's key for Go to Overview, got %q", row)
+	}
+}
+
+func TestLoadKeymapRemapsShell(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keymap.conf")
+	if err := os.WriteFile(path, []byte("app.pulse = p\napp.bogus = x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m := NewModel()
+	err := m.LoadKeymap(path)
+	if !errors.Is(err, keymap.ErrUnknownAction) {
+		t.Fatalf("LoadKeymap = %v, want an unknown action error", err)
+	}
+
+	if !strings.Contains(m.status, `:2: unknown action "app.bogus"`) {
+		t.Fatalf("status = %q", m.status)
+	}
+
+	d := testkit.Boot(t, m, 100, 30)
+	d.Press("p").RequireContains("pulse 67%", "left/right p ctrl+k")
+	d.Press("enter").RequireContains("pulse 67%")
+}
+
+func TestHelpListsKeymap(t *testing.T) {
+	m := NewModel()
+	if err := m.keys.Load(strings.NewReader("app.pulse = p")); err != nil {
+		t.Fatal(err)
+	}
+
+	d := testkit.Boot(t, m, 100, 40).Press("?")
+	d.RequireContains("Keyboard shortcuts", "Workspace", "Command palette")
+	key := ""
+	for _, line := range d.Frame().Lines() {
+		if before, _, ok := strings.Cut(line, " pulse "); ok {
+			f := strings.Fields(before)
+			key = f[len(f)-1]
+		}
+	}
+	if key != "p" {
+		t.Fatalf("help should show the remapped pulse key, got %q", key)
+	}
+	d.Press("esc").RequireNotContains("Keyboard shortcuts")
+}
+
+func TestFocusFollowsSectionsAndLayout(t *testing.T) {
+	m := NewModel()
+	deck := m.centerDeck
+	if !deck.tableCard.IsFocused() || deck.queueCard.IsFocused() {
+		t.Fatal("overview should focus the services card")
+	}
+
+	d := testkit.Boot(t, m, 100, 30)
+	d.Press("tab").RequireContains("section -> queue")
+	if !deck.queueCard.IsFocused() || !deck.queue.IsFocused() || deck.tableCard.IsFocused() {
+		t.Fatal("tab should move focus, and the focus edge, to the queue card")
+	}
+	d.Press("alt+right").RequireContains("section -> progress")
+	d.Press("alt+up").RequireContains("section -> services")
+	d.Press("3")
+	if deck.focus.Focused() != focusQueue {
+		t.Fatalf("choosing the queue section should focus its card, got %q", deck.focus.Focused())
+	}
+}
+
+func TestMouseFocusesScrollsAndRunsFooterCards(t *testing.T) {
+	m := NewModel()
+	d := testkit.Boot(t, m, 100, 30)
+	if d.View().MouseMode != tea.MouseModeNone {
+		t.Fatal("mouse reporting should be off until SetMouse")
+	}
+	m.SetMouse(true)
+	if d.View().MouseMode != tea.MouseModeCellMotion {
+		t.Fatal("SetMouse(true) should turn on mouse reporting")
+	}
+
+	deck := m.centerDeck
+	deck.focus.Measure()
+	queue, _ := deck.focus.Rect(focusQueue)
+	d.Send(tea.MouseClickMsg{X: queue.X + 2, Y: queue.Y + 2, Button: tea.MouseLeft})
+	d.RequireContains("section -> queue")
+	if !deck.queueCard.IsFocused() {
+		t.Fatal("clicking the queue card should focus it")
+	}
+
+	services, _ := deck.focus.Rect(focusServices)
+	d.Send(tea.MouseWheelMsg{X: queue.X + 2, Y: queue.Y + 2, Button: tea.MouseWheelDown})
+	d.RequireContains("queue:2")
+	d.Send(tea.MouseWheelMsg{X: services.X + 2, Y: services.Y + 2, Button: tea.MouseWheelDown})
+	d.RequireContains("queue:2")
+	if deck.focus.Focused() != focusQueue {
+		t.Fatal("the wheel should not move focus")
+	}
+
+	footer := d.Frame().Lines()[29]
+	x := strings.Index(footer, "ctrl+k")
+	if x < 0 {
+		t.Fatalf("palette card missing from footer %q", footer)
+	}
+	d.Send(tea.MouseClickMsg{X: len([]rune(footer[:x])), Y: 29, Button: tea.MouseLeft})
+	d.RequireContains("Command Palette")
+}
+
 func TestThemeChangedUpdatesModelTheme(t *testing.T) {
 	m := NewModel()
 	original := m.theme.Name()
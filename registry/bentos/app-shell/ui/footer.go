@@ -1,15 +1,40 @@
 package ui
 
-import "github.com/cloudboy-jh/bentotui/registry/bricks/bar"
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/focus"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/registry/bricks/bar"
+)
 
-func FooterCards() []bar.Card {
+// FooterCards builds the footer hints from the keymap, so remapped keys show
+// up in the bar. Clicking a card sends an ActionMsg for its action; a pair
+// of keys runs the second one.
+func FooterCards(keys *keymap.Registry) []bar.Card {
+	card := func(action string, variant bar.CardVariant, priority int) bar.Card {
+		b, _ := keys.Lookup(action)
+		c := bar.BindingCard(b)
+		c.Variant, c.Priority = variant, priority
+		c.Action = func() tea.Msg { return ActionMsg{Action: action} }
+		return c
+	}
+	pair := func(prev, next, label string, variant bar.CardVariant, priority int) bar.Card {
+		c := card(next, variant, priority)
+		p, _ := keys.Lookup(prev)
+		n, _ := keys.Lookup(next)
+		c.Command = p.Label() + "/" + n.Label()
+		c.Label = label
+		return c
+	}
 	return []bar.Card{
-		{Command: "up/down", Label: "section", Variant: bar.CardPrimary, Enabled: true, Priority: 7},
-		{Command: "left/right", Label: "queue", Variant: bar.CardNormal, Enabled: true, Priority: 6},
-		{Command: "enter", Label: "pulse", Variant: bar.CardNormal, Enabled: true, Priority: 5},
-		{Command: "ctrl+k", Label: "palette", Variant: bar.CardNormal, Enabled: true, Priority: 4},
-		{Command: "t", Label: "theme", Variant: bar.CardNormal, Enabled: true, Priority: 4},
-		{Command: "c", Label: "compact", Variant: bar.CardNormal, Enabled: true, Priority: 3},
-		{Command: "q", Label: "quit", Variant: bar.CardMuted, Enabled: true, Priority: 2},
+		pair(ActionSectionPrev, ActionSectionNext, "section", bar.CardPrimary, 7),
+		pair(ActionQueuePrev, ActionQueueNext, "queue", bar.CardNormal, 6),
+		card(ActionPulse, bar.CardNormal, 5),
+		card(ActionPalette, bar.CardNormal, 4),
+		card(ActionNextTheme, bar.CardNormal, 4),
+		card(focus.ActionNext, bar.CardNormal, 3),
+		card(ActionCompact, bar.CardNormal, 3),
+		card(ActionHelp, bar.CardNormal, 3),
+		card(ActionQuit, bar.CardMuted, 2),
 	}
 }
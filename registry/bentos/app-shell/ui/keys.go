@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/registry/bricks/dialog"
+)
+
+// Action IDs for the shell's global bindings.
+const (
+	ActionQuit        = "app.quit"
+	ActionSectionPrev = "app.section.prev"
+	ActionSectionNext = "app.section.next"
+	ActionQueuePrev   = "app.queue.prev"
+	ActionQueueNext   = "app.queue.next"
+	ActionPulse       = "app.pulse"
+	ActionPalette     = "app.palette"
+	ActionNextTheme   = "app.theme.next"
+	ActionCompact     = "app.compact"
+	ActionHelp        = "app.help"
+)
+
+// ActionMsg runs a bound action as if its key was pressed. Footer cards send
+// it when clicked.
+type ActionMsg struct{ Action string }
+
+// GotoAction is the action ID that jumps to section i.
+func GotoAction(i int) string { return "app.goto." + strconv.Itoa(i+1) }
+
+// Keymap registers the shell's global bindings, including a number key for
+// each section, and the palette's keys so a keymap file can remap them
+// before the palette first opens.
+func Keymap(sections []string) *keymap.Registry {
+	r := keymap.NewRegistry()
+	r.Register(keymap.Global,
+		keymap.Binding{Action: ActionSectionPrev, Keys: []string{"up"}, Help: "previous section"},
+		keymap.Binding{Action: ActionSectionNext, Keys: []string{"down"}, Help: "next section"},
+		keymap.Binding{Action: ActionQueuePrev, Keys: []string{"left"}, Help: "queue left"},
+		keymap.Binding{Action: ActionQueueNext, Keys: []string{"right"}, Help: "queue right"},
+		keymap.Binding{Action: ActionPulse, Keys: []string{"enter"}, Help: "pulse"},
+		keymap.Binding{Action: ActionPalette, Keys: []string{"ctrl+k"}, Help: "palette"},
+		keymap.Binding{Action: ActionNextTheme, Keys: []string{"t"}, Help: "theme"},
+		keymap.Binding{Action: ActionCompact, Keys: []string{"c"}, Help: "compact"},
+		keymap.Binding{Action: ActionHelp, Keys: []string{"?"}, Help: "help"},
+		keymap.Binding{Action: ActionQuit, Keys: []string{"q", "ctrl+c"}, Help: "quit"},
+	)
+	for i, s := range sections {
+		r.Register(keymap.Global, keymap.Binding{
+			Action: GotoAction(i),
+			Keys:   []string{strconv.Itoa(i + 1)},
+			Help:   "go to " + strings.ToLower(s),
+		})
+	}
+	r.Register(dialog.PaletteScope, dialog.DefaultPaletteKeyMap().Bindings()...)
+	return r
+}
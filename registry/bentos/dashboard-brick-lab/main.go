@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/focus"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/bar"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/card"
 	"github.com/cloudboy-jh/bentotui/registry/bricks/filepicker"
@@ -19,7 +20,7 @@ type model struct {
 	theme  theme.Theme
 	width  int
 	height int
-	active int
+	focus  *focus.Manager
 
 	// theme cycling
 	themeOrder []string
@@ -85,7 +86,7 @@ func newModel() *model {
 
 	m := &model{
 		theme:      theme.CurrentTheme(),
-		active:     0,
+		focus:      focus.New(),
 		themeOrder: themes,
 		themeIdx:   themeIdx,
 		list:       l,
@@ -96,7 +97,9 @@ func newModel() *model {
 			bar.FooterAnchored(),
 			bar.Left("focus: list"),
 			bar.Cards(
-				bar.Card{Command: "arrows", Label: "focus", Variant: bar.CardPrimary, Enabled: true, Priority: 5},
+				bar.Card{Command: "tab", Label: "focus", Variant: bar.CardPrimary, Enabled: true, Priority: 5},
+				bar.Card{Command: "alt+arrows", Label: "move", Variant: bar.CardNormal, Enabled: true, Priority: 4},
+				bar.Card{Command: "click", Label: "focus", Variant: bar.CardNormal, Enabled: true, Priority: 3},
 				bar.Card{Command: "t", Label: "theme", Variant: bar.CardNormal, Enabled: true, Priority: 4},
 				bar.Card{Command: "T", Label: "theme←", Variant: bar.CardNormal, Enabled: true, Priority: 3},
 				bar.Card{Command: "enter", Label: "select", Variant: bar.CardNormal, Enabled: true, Priority: 2},
@@ -111,7 +114,15 @@ func newModel() *model {
 	m.fpCard = card.New(card.Title("File Picker"), card.Content(m.filepicker))
 	m.pkgCard = card.New(card.Title("Package Manager"), card.Content(m.pkg))
 	m.applyTheme()
-	m.syncFocus()
+
+	// Each brick is focused together with its card, so the card shows the
+	// focus edge. The package manager has no keys, but its card can still
+	// take focus.
+	m.focus.Add("list", m.list, m.listCard)
+	m.focus.Add("table", m.table, m.tblCard)
+	m.focus.Add("filepicker", m.filepicker, m.fpCard)
+	m.focus.Add("package-manager", m.pkgCard)
+	m.focus.SetLayout(m.body)
 	return m
 }
 
@@ -135,36 +146,13 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if m.focus.HandleKey(msg) {
+			return m, nil
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
 
-		// panel focus navigation
-		case "left":
-			if m.active%2 == 1 {
-				m.active--
-				m.syncFocus()
-			}
-			return m, nil
-		case "right":
-			if m.active%2 == 0 {
-				m.active++
-				m.syncFocus()
-			}
-			return m, nil
-		case "up":
-			if m.active >= 2 {
-				m.active -= 2
-				m.syncFocus()
-			}
-			return m, nil
-		case "down":
-			if m.active <= 1 {
-				m.active += 2
-				m.syncFocus()
-			}
-			return m, nil
-
 		// theme cycling
 		case "t":
 			m.shiftTheme(1)
@@ -174,7 +162,16 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		return m, m.updateActive(msg)
+		return m, m.updateBrick(m.focus.Focused(), msg)
+
+	case tea.MouseMsg:
+		// Clicking a card focuses it; the wheel scrolls the brick under
+		// the pointer, focused or not.
+		id, local, ok := m.focus.HandleMouse(msg)
+		if wheel, isWheel := local.(tea.MouseWheelMsg); ok && isWheel {
+			return m, m.updateBrick(id, wheel)
+		}
+		return m, nil
 	}
 
 	// Non-key messages: always route to filepicker and package-manager
@@ -200,12 +197,10 @@ func (m *model) View() tea.View {
 		return v
 	}
 
-	bodyH := max(1, m.height-1)
 	m.layout()
 	m.syncFooter()
 
-	body := rooms.Dashboard2x2(m.width, bodyH, m.listCard, m.tblCard, m.fpCard, m.pkgCard)
-	screen := rooms.Focus(m.width, m.height, rooms.Static(body), m.footer)
+	screen := rooms.Focus(m.width, m.height, rooms.Static(m.body()), m.footer)
 
 	surf := surface.New(m.width, m.height)
 	surf.Fill(canvas)
@@ -213,11 +208,23 @@ func (m *model) View() tea.View {
 	v := tea.NewView(surf.Render())
 	v.AltScreen = true
 	v.BackgroundColor = canvas
+	v.MouseMode = tea.MouseModeCellMotion
 	return v
 }
 
+// body lays out the four cards. Each card is a focus region, so alt+arrows
+// move between them by position.
+func (m *model) body() string {
+	return rooms.Dashboard2x2(m.width, max(1, m.height-1),
+		m.focus.Region("list", m.listCard),
+		m.focus.Region("table", m.tblCard),
+		m.focus.Region("filepicker", m.fpCard),
+		m.focus.Region("package-manager", m.pkgCard),
+	)
+}
+
 func (m *model) syncFooter() {
-	m.footer.SetLeft("focus: " + m.activeLabel())
+	m.footer.SetLeft("focus: " + m.focus.Focused())
 	m.footer.SetRight("theme: " + m.theme.Name())
 
 	if m.filepicker.Status() != "" {
@@ -244,27 +251,6 @@ func (m *model) layout() {
 	m.pkgCard.SetSize(rightW, bottomH)
 }
 
-func (m *model) syncFocus() {
-
-	m.list.Blur()
-	m.table.Blur()
-	m.filepicker.Blur()
-
-	switch m.active {
-	case 0:
-		m.list.Focus()
-
-	case 1:
-		m.table.Focus()
-
-	case 2:
-		m.filepicker.Focus()
-
-	case 3:
-
-	}
-}
-
 func (m *model) shiftTheme(step int) {
 	if len(m.themeOrder) == 0 {
 		return
@@ -291,23 +277,24 @@ func (m *model) applyTheme() {
 	m.pkgCard.SetTheme(m.theme)
 }
 
-func (m *model) updateActive(msg tea.Msg) tea.Cmd {
-	switch m.active {
-	case 0:
+// updateBrick sends msg to the brick registered under focus id.
+func (m *model) updateBrick(id string, msg tea.Msg) tea.Cmd {
+	switch id {
+	case "list":
 		u, cmd := m.list.Update(msg)
 		if next, ok := u.(*list.Model); ok {
 			m.list = next
 		}
 		return cmd
-	case 1:
+	case "table":
 		u, cmd := m.table.Update(msg)
 		if next, ok := u.(*table.Model); ok {
 			m.table = next
 		}
 		return cmd
-	case 2:
+	case "filepicker":
 		return m.updateFilepicker(msg)
-	case 3:
+	case "package-manager":
 		return m.updatePackageManager(msg)
 	}
 	return nil
@@ -329,21 +316,6 @@ func (m *model) updatePackageManager(msg tea.Msg) tea.Cmd {
 	return cmd
 }
 
-func (m *model) activeLabel() string {
-	switch m.active {
-	case 0:
-		return "list"
-	case 1:
-		return "table"
-	case 2:
-		return "filepicker"
-	case 3:
-		return "package-manager"
-	default:
-		return "unknown"
-	}
-}
-
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -50,6 +50,7 @@ type model struct {
 	width  int
 	height int
 
+	surf   surface.Surface
 	botBar *bar.Model
 
 	metricA *card.Model
@@ -192,10 +193,10 @@ func (m *model) View() tea.View {
 	}
 
 	screen := rooms.Focus(m.width, m.height, rooms.Static(body), m.botBar)
-	surf := surface.New(m.width, m.height)
-	surf.Fill(canvas)
-	surf.Draw(0, 0, screen)
-	v := tea.NewView(surf.Render())
+	m.surf.Begin(m.width, m.height)
+	m.surf.Fill(canvas)
+	m.surf.Draw(0, 0, screen)
+	v := tea.NewView(m.surf.Render())
 	v.AltScreen = true
 	v.BackgroundColor = canvas
 	return v
@@ -13,6 +13,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 	"github.com/cloudboy-jh/bentotui/theme/styles"
 )
@@ -55,6 +56,24 @@ type Card struct {
 	Variant  CardVariant
 	Enabled  bool
 	Priority int
+	// Action runs when the card is clicked while enabled. Leave it nil for
+	// cards that only show a hint.
+	Action func() tea.Msg
+}
+
+// BindingCard turns a keymap binding into a card showing its key and help.
+func BindingCard(b keymap.Binding) Card {
+	return Card{Command: b.Label(), Label: b.Help, Variant: CardNormal, Enabled: len(b.Keys) > 0}
+}
+
+// BindingCards turns bindings, e.g. Registry.Help(scope), into cards. When
+// the bar runs out of room the last bindings are dropped first.
+func BindingCards(bindings ...keymap.Binding) []Card {
+	cards := make([]Card, len(bindings))
+	for i, b := range bindings {
+		cards[i] = BindingCard(b)
+	}
+	return cards
 }
 
 type Option func(*Model)
@@ -116,27 +135,63 @@ func WithTheme(t theme.Theme) Option {
 func (m *Model) Init() tea.Cmd { return nil }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if ws, ok := msg.(tea.WindowSizeMsg); ok {
-		m.SetSize(ws.Width, 1)
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, 1)
+	case tea.MouseClickMsg:
+		if msg.Button != tea.MouseLeft || msg.Y != 0 {
+			return m, nil
+		}
+		if c, ok := m.CardAt(msg.X); ok && c.Enabled && c.Action != nil {
+			return m, c.Action
+		}
 	}
 	return m, nil
 }
 
 func (m *Model) View() tea.View {
 	t := m.activeTheme()
-
-	left := m.renderLeftSegment(t)
-	cards := m.renderCardBlock(t, -1)
-	rightRaw := m.renderRightSegment(t)
-
 	if m.width == 0 {
-		line := strings.TrimSpace(strings.Join(nonEmpty(left, cards, rightRaw), "  "))
+		line := strings.TrimSpace(strings.Join(nonEmpty(
+			m.renderLeftSegment(t),
+			m.renderCardBlock(t, -1),
+			m.renderRightSegment(t),
+		), "  "))
 		bg, fg := m.rowColors(t)
 		return tea.NewView(lipgloss.NewStyle().
 			Foreground(fg).
 			Background(bg).
 			Render(line))
 	}
+	line, _ := m.layout(t)
+	return m.renderLine(t, line)
+}
+
+// CardAt returns the card drawn at column x, as laid out by View. Cards
+// clipped by the bar's width are not found.
+func (m *Model) CardAt(x int) (Card, bool) {
+	if m.width == 0 {
+		return Card{}, false
+	}
+	_, spans := m.layout(m.activeTheme())
+	for _, s := range spans {
+		if x >= s.x0 && x < s.x1 && s.x1 <= m.width {
+			return s.card, true
+		}
+	}
+	return Card{}, false
+}
+
+// cardSpan is where a card landed on the row, in columns [x0, x1).
+type cardSpan struct {
+	card   Card
+	x0, x1 int
+}
+
+// layout composes the row for a sized bar and records where each card is.
+func (m *Model) layout(t theme.Theme) (string, []cardSpan) {
+	left := m.renderLeftSegment(t)
+	rightRaw := m.renderRightSegment(t)
 
 	right := rightRaw
 	rightWidth := lipgloss.Width(right)
@@ -145,29 +200,52 @@ func (m *Model) View() tea.View {
 		rightWidth = m.width
 	}
 	if rightWidth >= m.width {
-		return m.renderLine(t, right)
+		return right, nil
 	}
 
 	leftArea := max(0, m.width-rightWidth)
 	if rightWidth > 0 && leftArea > 0 {
 		leftArea--
 	}
+	var spans []cardSpan
 	leftBlock := ""
 	cardBlock := ""
 	if left != "" && leftArea > 0 {
 		leftBlock = clipWidth(left, leftArea)
 		leftArea -= lipgloss.Width(leftBlock)
+		if m.leftCard != nil {
+			x0 := 0
+			if m.statusPill != "" {
+				x0 = lipgloss.Width(m.renderStatusPill(t)) + 1
+			}
+			x1 := x0 + lipgloss.Width(renderCard(t, *m.leftCard, true, m.compactMode(), m.anchoredMode()))
+			if x1 <= lipgloss.Width(leftBlock) {
+				spans = append(spans, cardSpan{card: *m.leftCard, x0: x0, x1: x1})
+			}
+		}
 	}
 	if leftArea > 0 {
+		offset := 0
 		if leftBlock != "" {
 			leftArea--
+			offset = lipgloss.Width(leftBlock) + 1
+		}
+		var cardSpans []cardSpan
+		cardBlock, cardSpans = m.layoutCardBlock(t, leftArea)
+		for _, s := range cardSpans {
+			s.x0, s.x1 = s.x0+offset, s.x1+offset
+			spans = append(spans, s)
 		}
-		cardBlock = m.renderCardBlock(t, leftArea)
 	}
 
 	leftSide := strings.TrimSpace(strings.Join(nonEmpty(leftBlock, cardBlock), " "))
 	line := composeAlignedLine(m.width, leftSide, right)
-	return m.renderLine(t, line)
+	if m.rightCard != nil && right == rightRaw {
+		end := lipgloss.Width(line)
+		w := lipgloss.Width(renderCard(t, *m.rightCard, true, m.compactMode(), m.anchoredMode()))
+		spans = append(spans, cardSpan{card: *m.rightCard, x0: end - w, x1: end})
+	}
+	return line, spans
 }
 
 func (m *Model) SetSize(width, _ int) { m.width = width }
@@ -227,18 +305,13 @@ func renderRow(width int, bg, fg color.Color, content string) string {
 	if width <= 0 {
 		return ""
 	}
-	return styles.Row(bg, fg, width, styles.ClipANSI(content, width))
+	return styles.Row(bg, fg, width, styles.Reopen(styles.ClipANSI(content, width), bg, fg))
 }
 
 func (m *Model) renderLeftSegment(t theme.Theme) string {
 	parts := make([]string, 0, 2)
 	if m.statusPill != "" {
-		parts = append(parts, lipgloss.NewStyle().
-			Bold(true).
-			Padding(0, 1).
-			Foreground(t.Text()).
-			Background(t.BackgroundPanel()).
-			Render(m.statusPill))
+		parts = append(parts, m.renderStatusPill(t))
 	}
 	if m.leftCard != nil {
 		parts = append(parts, renderCard(t, *m.leftCard, true, m.compactMode(), m.anchoredMode()))
@@ -249,6 +322,15 @@ func (m *Model) renderLeftSegment(t theme.Theme) string {
 	return strings.TrimSpace(strings.Join(parts, " "))
 }
 
+func (m *Model) renderStatusPill(t theme.Theme) string {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Padding(0, 1).
+		Foreground(t.Text()).
+		Background(t.BackgroundPanel()).
+		Render(m.statusPill)
+}
+
 func (m *Model) renderRightSegment(t theme.Theme) string {
 	parts := make([]string, 0, 2)
 	if m.right != "" {
@@ -261,8 +343,16 @@ func (m *Model) renderRightSegment(t theme.Theme) string {
 }
 
 func (m *Model) renderCardBlock(t theme.Theme, width int) string {
+	block, _ := m.layoutCardBlock(t, width)
+	return block
+}
+
+// layoutCardBlock renders the cards into width columns (any width when
+// negative), dropping labels and then whole cards by priority, and records
+// where each shown card starts within the block.
+func (m *Model) layoutCardBlock(t theme.Theme, width int) (string, []cardSpan) {
 	if len(m.cards) == 0 {
-		return ""
+		return "", nil
 	}
 	entries := make([]cardEntry, 0, len(m.cards))
 	for i, c := range m.cards {
@@ -272,21 +362,19 @@ func (m *Model) renderCardBlock(t theme.Theme, width int) string {
 		entries = append(entries, cardEntry{card: c, index: i, showLabel: true})
 	}
 	if len(entries) == 0 {
-		return ""
+		return "", nil
 	}
-	if width < 0 {
-		return joinEntries(t, entries, m.compactMode(), m.anchoredMode())
-	}
-	rendered := joinEntries(t, entries, m.compactMode(), m.anchoredMode())
-	if lipgloss.Width(rendered) <= width {
-		return rendered
+	compact, anchored := m.compactMode(), m.anchoredMode()
+	rendered, spans := joinEntries(t, entries, nil, compact, anchored)
+	if width < 0 || lipgloss.Width(rendered) <= width {
+		return rendered, spans
 	}
 	order := truncateOrder(entries)
 	for _, idx := range order {
 		entries[idx].showLabel = false
-		rendered = joinEntries(t, entries, m.compactMode(), m.anchoredMode())
+		rendered, spans = joinEntries(t, entries, nil, compact, anchored)
 		if lipgloss.Width(rendered) <= width {
-			return rendered
+			return rendered, spans
 		}
 	}
 	keep := make([]bool, len(entries))
@@ -295,12 +383,12 @@ func (m *Model) renderCardBlock(t theme.Theme, width int) string {
 	}
 	for _, idx := range order {
 		keep[idx] = false
-		rendered = joinKeptEntries(t, entries, keep, m.compactMode(), m.anchoredMode())
+		rendered, spans = joinEntries(t, entries, keep, compact, anchored)
 		if lipgloss.Width(rendered) <= width {
-			return rendered
+			return rendered, spans
 		}
 	}
-	return clipWidth(rendered, width)
+	return clipWidth(rendered, width), spans
 }
 
 func (m *Model) compactMode() bool {
@@ -383,23 +471,23 @@ type cardEntry struct {
 	showLabel bool
 }
 
-func joinEntries(t theme.Theme, entries []cardEntry, compact bool, anchored bool) string {
-	parts := make([]string, len(entries))
-	for i, e := range entries {
-		parts[i] = renderCard(t, e.card, e.showLabel, compact, anchored)
-	}
-	return strings.Join(parts, " ")
-}
-
-func joinKeptEntries(t theme.Theme, entries []cardEntry, keep []bool, compact bool, anchored bool) string {
+// joinEntries renders the entries kept (all of them when keep is nil)
+// separated by spaces, and where each one landed.
+func joinEntries(t theme.Theme, entries []cardEntry, keep []bool, compact bool, anchored bool) (string, []cardSpan) {
 	parts := make([]string, 0, len(entries))
+	spans := make([]cardSpan, 0, len(entries))
+	x := 0
 	for i, e := range entries {
-		if !keep[i] {
+		if keep != nil && !keep[i] {
 			continue
 		}
-		parts = append(parts, renderCard(t, e.card, e.showLabel, compact, anchored))
+		part := renderCard(t, e.card, e.showLabel, compact, anchored)
+		w := lipgloss.Width(part)
+		parts = append(parts, part)
+		spans = append(spans, cardSpan{card: e.card, x0: x, x1: x + w})
+		x += w + 1
 	}
-	return strings.Join(parts, " ")
+	return strings.Join(parts, " "), spans
 }
 
 func truncateOrder(entries []cardEntry) []int {
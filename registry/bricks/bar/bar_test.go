@@ -8,6 +8,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/testkit"
 	"github.com/cloudboy-jh/bentotui/theme"
 )
 
@@ -37,6 +38,47 @@ func TestCompactCardsAndPriorityOverflow(t *testing.T) {
 	}
 }
 
+func TestClickRunsCardAction(t *testing.T) {
+	type saved struct{}
+	b := New(
+		FooterAnchored(),
+		Left("app"),
+		Cards(
+			Card{Command: "r", Label: "refresh", Enabled: true},
+			Card{Command: "s", Label: "save", Enabled: true, Action: func() tea.Msg { return saved{} }},
+			Card{Command: "x", Label: "off", Action: func() tea.Msg { return saved{} }},
+		),
+		RightCard(Card{Command: "q", Label: "quit", Enabled: true}),
+	)
+	b.SetSize(40, 1)
+
+	out := ansi.Strip(viewString(b.View()))
+	at := strings.Index(out, "s save")
+	if at < 0 {
+		t.Fatalf("save card missing from %q", out)
+	}
+	_, cmd := b.Update(tea.MouseClickMsg{X: at + 2, Button: tea.MouseLeft})
+	if cmd == nil {
+		t.Fatalf("clicking the save card in %q should run its action", out)
+	}
+	if _, ok := cmd().(saved); !ok {
+		t.Fatalf("click emitted %T", cmd())
+	}
+
+	if c, ok := b.CardAt(strings.Index(out, "refresh")); !ok || c.Command != "r" {
+		t.Fatalf("CardAt(refresh) = %+v, %v", c, ok)
+	}
+	if c, ok := b.CardAt(strings.Index(out, "quit")); !ok || c.Command != "q" {
+		t.Fatalf("CardAt(quit) = %+v, %v", c, ok)
+	}
+	if _, cmd := b.Update(tea.MouseClickMsg{X: strings.Index(out, "off"), Button: tea.MouseLeft}); cmd != nil {
+		t.Fatal("disabled cards should not run their action")
+	}
+	if _, ok := b.CardAt(1); ok {
+		t.Fatal("the left text is not a card")
+	}
+}
+
 func TestAnchoredIgnoredForNonFooterRole(t *testing.T) {
 	b := New(RoleTopBar(), Left("top"), Right("meta"))
 	b.SetAnchored(true)
@@ -88,6 +130,19 @@ func TestAnchoredCardStyleModes(t *testing.T) {
 	}
 }
 
+func TestFooterSnapshotEveryTheme(t *testing.T) {
+	testkit.SweepThemes(t, "footer", func() testkit.Viewer {
+		return New(
+			FooterAnchored(),
+			Left("app"),
+			Cards(
+				Card{Command: "/", Label: "search", Variant: CardPrimary, Enabled: true},
+				Card{Command: "q", Label: "quit", Variant: CardMuted, Enabled: true},
+			),
+		)
+	}, testkit.Case{Width: 40, Height: 1})
+}
+
 func viewString(v tea.View) string {
 	if v.Content == nil {
 		return ""
@@ -100,3 +155,13 @@ func viewString(v tea.View) string {
 	}
 	return fmt.Sprint(v.Content)
 }
+
+func TestConformance(t *testing.T) {
+	testkit.RunConformance(t, testkit.Conformance{
+		New: func() testkit.Brick {
+			return New(FooterAnchored(), Left("app"), Right("ready"), Cards(Card{Command: "q", Label: "quit", Enabled: true}))
+		},
+		FixedHeight: 1,
+		WindowSize:  true,
+	})
+}
@@ -0,0 +1,24 @@
+package card
+
+import (
+	"testing"
+
+	"github.com/cloudboy-jh/bentotui/registry/bricks/text"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func TestConformance(t *testing.T) {
+	for _, elevation := range []struct {
+		name string
+		opt  Option
+	}{{"raised", Raised()}, {"flat", Flat()}} {
+		t.Run(elevation.name, func(t *testing.T) {
+			testkit.RunConformance(t, testkit.Conformance{
+				New: func() testkit.Brick {
+					return New(Title("Services"), Meta("3 up"), Footer("updated 2s ago"), Content(text.New("all healthy")), elevation.opt)
+				},
+				WindowSize: true,
+			})
+		})
+	}
+}
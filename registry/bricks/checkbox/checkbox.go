@@ -13,6 +13,7 @@ import (
 
 	bubbleskey "charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 	"github.com/cloudboy-jh/bentotui/theme/styles"
 )
@@ -23,16 +24,29 @@ type KeyMap struct {
 
 func DefaultKeyMap() KeyMap {
 	return KeyMap{
-		Toggle: bubbleskey.NewBinding(bubbleskey.WithKeys(" ", "enter"), bubbleskey.WithHelp("space", "toggle")),
+		Toggle: bubbleskey.NewBinding(bubbleskey.WithKeys("space", "enter"), bubbleskey.WithHelp("space", "toggle")),
 	}
 }
 
+// Scope and action ID the checkbox binding registers under in a
+// keymap.Registry.
+const (
+	Scope        keymap.Scope = "checkbox"
+	ActionToggle              = "checkbox.toggle"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{keymap.FromKey(ActionToggle, km.Toggle)}
+}
+
 type Model struct {
 	label   string
 	checked bool
 	focused bool
 	width   int
 	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
 }
 
 func New(label string) *Model {
@@ -52,6 +66,19 @@ func (m *Model) SetSize(width, _ int) {
 }
 func (m *Model) GetSize() (int, int) { return m.width, 1 }
 
+// SetKeymap reads the checkbox keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{Toggle: r.KeyOr(ActionToggle, k.Toggle)}
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if !m.focused {
 		return m, nil
@@ -60,7 +87,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if !ok {
 		return m, nil
 	}
-	if bubbleskey.Matches(k, m.keys.Toggle) {
+	if bubbleskey.Matches(k, m.keyMap().Toggle) {
 		m.Toggle()
 	}
 	return m, nil
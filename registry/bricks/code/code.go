@@ -0,0 +1,426 @@
+// Brick: Code
+// +-----------------------------------+
+// |  1 package main                   |
+// |  2                                |
+// |● 3 func main() {                  |
+// |  4     fmt.Println("hi", 42)      |
+// |  5 }                              |
+// +-----------------------------------+
+// Read-only source view: tokens colored from the theme's Syntax slots,
+// line numbers, a marker gutter and horizontal scrolling for long lines.
+// Copy this file into your project: bento add code
+package code
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+type KeyMap struct {
+	Up       bubbleskey.Binding
+	Down     bubbleskey.Binding
+	PageUp   bubbleskey.Binding
+	PageDown bubbleskey.Binding
+	Top      bubbleskey.Binding
+	Bottom   bubbleskey.Binding
+	Left     bubbleskey.Binding
+	Right    bubbleskey.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:       bubbleskey.NewBinding(bubbleskey.WithKeys("up", "k"), bubbleskey.WithHelp("↑/k", "scroll up")),
+		Down:     bubbleskey.NewBinding(bubbleskey.WithKeys("down", "j"), bubbleskey.WithHelp("↓/j", "scroll down")),
+		PageUp:   bubbleskey.NewBinding(bubbleskey.WithKeys("pgup", "b"), bubbleskey.WithHelp("pgup", "page up")),
+		PageDown: bubbleskey.NewBinding(bubbleskey.WithKeys("pgdown", "space", "f"), bubbleskey.WithHelp("pgdown", "page down")),
+		Top:      bubbleskey.NewBinding(bubbleskey.WithKeys("home", "g"), bubbleskey.WithHelp("g", "top")),
+		Bottom:   bubbleskey.NewBinding(bubbleskey.WithKeys("end", "G"), bubbleskey.WithHelp("G", "bottom")),
+		Left:     bubbleskey.NewBinding(bubbleskey.WithKeys("left", "h"), bubbleskey.WithHelp("←/h", "scroll left")),
+		Right:    bubbleskey.NewBinding(bubbleskey.WithKeys("right", "l"), bubbleskey.WithHelp("→/l", "scroll right")),
+	}
+}
+
+// Scope and action IDs the code view bindings register under in a
+// keymap.Registry.
+const (
+	Scope          keymap.Scope = "code"
+	ActionUp                    = "code.up"
+	ActionDown                  = "code.down"
+	ActionPageUp                = "code.pageup"
+	ActionPageDown              = "code.pagedown"
+	ActionTop                   = "code.top"
+	ActionBottom                = "code.bottom"
+	ActionLeft                  = "code.left"
+	ActionRight                 = "code.right"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionUp, km.Up),
+		keymap.FromKey(ActionDown, km.Down),
+		keymap.FromKey(ActionPageUp, km.PageUp),
+		keymap.FromKey(ActionPageDown, km.PageDown),
+		keymap.FromKey(ActionTop, km.Top),
+		keymap.FromKey(ActionBottom, km.Bottom),
+		keymap.FromKey(ActionLeft, km.Left),
+		keymap.FromKey(ActionRight, km.Right),
+	}
+}
+
+const (
+	// wheelRows is how far one mouse wheel notch scrolls.
+	wheelRows = 3
+	// panCols is how far left/right pan long lines.
+	panCols = 4
+	// tabWidth is how many spaces a tab expands to.
+	tabWidth = 4
+)
+
+type Tone string
+
+const (
+	ToneNeutral Tone = "neutral"
+	ToneInfo    Tone = "info"
+	ToneSuccess Tone = "success"
+	ToneWarn    Tone = "warn"
+	ToneDanger  Tone = "danger"
+)
+
+// Marker is a one-cell glyph in the gutter, such as a breakpoint or a
+// lint finding.
+type Marker struct {
+	Glyph string
+	Tone  Tone
+}
+
+type Option func(*Model)
+
+// Source sets the initial text.
+func Source(src string) Option { return func(m *Model) { m.src = src } }
+
+// Lang sets the language explicitly.
+func Lang(l Language) Option { return func(m *Model) { m.lang = l } }
+
+// Filename picks the language from the file extension.
+func Filename(name string) Option { return func(m *Model) { m.lang = DetectLanguage(name) } }
+
+// FirstLine numbers the first line n instead of 1, for showing a snippet
+// of a larger file.
+func FirstLine(n int) Option { return func(m *Model) { m.first = max(1, n) } }
+
+// HideLineNumbers drops the number column. The marker gutter stays.
+func HideLineNumbers() Option { return func(m *Model) { m.hideNumbers = true } }
+
+// WithTheme sets the theme for this code view instance.
+func WithTheme(t theme.Theme) Option { return func(m *Model) { m.theme = t } }
+
+// Model shows source text with syntax colors. It is read-only: it scrolls
+// but has no cursor.
+type Model struct {
+	src         string
+	lang        Language
+	lines       [][]Token
+	widths      []int // cell width of each line, tabs expanded
+	markers     map[int]Marker
+	first       int
+	hideNumbers bool
+	offset      int // first visible line
+	pan         int // first visible code column
+
+	width   int
+	height  int
+	focused bool
+	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
+	theme   theme.Theme      // nil = use theme.CurrentTheme()
+}
+
+func New(opts ...Option) *Model {
+	m := &Model{keys: DefaultKeyMap(), first: 1, markers: map[int]Marker{}}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.retokenize()
+	return m
+}
+
+// SetSource replaces the text and language, keeping markers and scrolling
+// back to the top.
+func (m *Model) SetSource(src string, lang Language) {
+	m.src, m.lang = src, lang
+	m.offset, m.pan = 0, 0
+	m.retokenize()
+}
+
+// SetLanguage re-highlights the current text as lang.
+func (m *Model) SetLanguage(lang Language) {
+	m.lang = lang
+	m.retokenize()
+}
+
+func (m *Model) Source() string     { return m.src }
+func (m *Model) Language() Language { return m.lang }
+
+func (m *Model) retokenize() {
+	src := strings.ReplaceAll(m.src, "\t", strings.Repeat(" ", tabWidth))
+	m.lines = Tokenize(m.lang, src)
+	m.widths = make([]int, len(m.lines))
+	for i, toks := range m.lines {
+		for _, tok := range toks {
+			m.widths[i] += ansi.StringWidth(tok.Text)
+		}
+	}
+	m.scrollTo(m.offset)
+	m.pan = m.clampPan(m.pan)
+}
+
+// SetMarker puts mk in the gutter of line n, numbered as displayed.
+func (m *Model) SetMarker(n int, mk Marker) { m.markers[n] = mk }
+
+// ClearMarker removes the marker on line n.
+func (m *Model) ClearMarker(n int) { delete(m.markers, n) }
+
+// ClearMarkers removes every marker.
+func (m *Model) ClearMarkers() { clear(m.markers) }
+
+// LineCount is the number of lines held.
+func (m *Model) LineCount() int { return len(m.lines) }
+
+// Offset is the 0-based line shown on the first row.
+func (m *Model) Offset() int { return m.offset }
+
+// Pan is the first visible code column.
+func (m *Model) Pan() int { return m.pan }
+
+// GotoLine scrolls line n, numbered as displayed, into the top third of
+// the view.
+func (m *Model) GotoLine(n int) {
+	i := n - m.first
+	if i >= m.offset && i < m.offset+m.height {
+		return
+	}
+	m.scrollTo(i - m.height/3)
+}
+
+// ── geometry ──────────────────────────────────────────────────────────────────
+
+// gutterWidth is the marker cell, the right-aligned numbers and a space.
+func (m *Model) gutterWidth() int {
+	if m.hideNumbers {
+		return 2
+	}
+	return 1 + len(strconv.Itoa(m.first+max(0, len(m.lines)-1))) + 1
+}
+
+func (m *Model) codeWidth() int { return max(0, m.width-m.gutterWidth()) }
+
+func (m *Model) maxOffset() int { return max(0, len(m.lines)-m.height) }
+
+func (m *Model) scrollTo(offset int) { m.offset = max(0, min(offset, m.maxOffset())) }
+
+func (m *Model) clampPan(x int) int {
+	widest := 0
+	for _, w := range m.widths {
+		widest = max(widest, w)
+	}
+	return max(0, min(x, widest-m.codeWidth()))
+}
+
+// ── lifecycle ─────────────────────────────────────────────────────────────────
+
+func (m *Model) Focus()          { m.focused = true }
+func (m *Model) Blur()           { m.focused = false }
+func (m *Model) IsFocused() bool { return m.focused }
+func (m *Model) Init() tea.Cmd   { return nil }
+
+func (m *Model) SetSize(width, height int) {
+	m.width, m.height = max(0, width), max(0, height)
+	m.scrollTo(m.offset)
+	m.pan = m.clampPan(m.pan)
+}
+
+func (m *Model) GetSize() (int, int) { return m.width, m.height }
+
+// SetTheme updates the theme. Call on ThemeChangedMsg.
+func (m *Model) SetTheme(t theme.Theme) { m.theme = t }
+
+func (m *Model) activeTheme() theme.Theme {
+	if m.theme != nil {
+		return m.theme
+	}
+	return theme.CurrentTheme()
+}
+
+// SetKeymap reads the code view keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{
+		Up:       r.KeyOr(ActionUp, k.Up),
+		Down:     r.KeyOr(ActionDown, k.Down),
+		PageUp:   r.KeyOr(ActionPageUp, k.PageUp),
+		PageDown: r.KeyOr(ActionPageDown, k.PageDown),
+		Top:      r.KeyOr(ActionTop, k.Top),
+		Bottom:   r.KeyOr(ActionBottom, k.Bottom),
+		Left:     r.KeyOr(ActionLeft, k.Left),
+		Right:    r.KeyOr(ActionRight, k.Right),
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			m.scrollTo(m.offset - wheelRows)
+		case tea.MouseWheelDown:
+			m.scrollTo(m.offset + wheelRows)
+		case tea.MouseWheelLeft:
+			m.pan = m.clampPan(m.pan - panCols)
+		case tea.MouseWheelRight:
+			m.pan = m.clampPan(m.pan + panCols)
+		}
+	case tea.KeyPressMsg:
+		if m.focused {
+			m.updateKeys(msg)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) updateKeys(k tea.KeyPressMsg) {
+	keys := m.keyMap()
+	page := max(1, m.height)
+	switch {
+	case bubbleskey.Matches(k, keys.Up):
+		m.scrollTo(m.offset - 1)
+	case bubbleskey.Matches(k, keys.Down):
+		m.scrollTo(m.offset + 1)
+	case bubbleskey.Matches(k, keys.PageUp):
+		m.scrollTo(m.offset - page)
+	case bubbleskey.Matches(k, keys.PageDown):
+		m.scrollTo(m.offset + page)
+	case bubbleskey.Matches(k, keys.Top):
+		m.scrollTo(0)
+	case bubbleskey.Matches(k, keys.Bottom):
+		m.scrollTo(m.maxOffset())
+	case bubbleskey.Matches(k, keys.Left):
+		m.pan = m.clampPan(m.pan - panCols)
+	case bubbleskey.Matches(k, keys.Right):
+		m.pan = m.clampPan(m.pan + panCols)
+	}
+}
+
+// ── rendering ─────────────────────────────────────────────────────────────────
+
+func (m *Model) View() tea.View {
+	if m.width <= 0 || m.height <= 0 {
+		return tea.NewView("")
+	}
+	t := m.activeTheme()
+	bg, fg := t.BackgroundPanel(), t.Text()
+	muted := lipgloss.NewStyle().Background(bg).Foreground(t.TextMuted())
+	digits := m.gutterWidth() - 2
+
+	rows := make([]string, 0, m.height)
+	for i := m.offset; len(rows) < m.height; i++ {
+		if i >= len(m.lines) {
+			rows = append(rows, styles.Row(bg, fg, m.width, ""))
+			continue
+		}
+		n := m.first + i
+		var b strings.Builder
+		b.WriteString(m.renderMarker(t, n))
+		if !m.hideNumbers {
+			num := strconv.Itoa(n)
+			b.WriteString(muted.Render(strings.Repeat(" ", digits-len(num)) + num))
+		}
+		b.WriteString(muted.Render(" "))
+		if cw := m.codeWidth(); cw > 0 {
+			b.WriteString(ansi.Cut(m.highlight(t, i), m.pan, m.pan+cw))
+		}
+		rows = append(rows, styles.RowClip(bg, fg, m.width, styles.Reopen(b.String(), bg, fg)))
+	}
+	return tea.NewView(strings.Join(rows, "\n"))
+}
+
+func (m *Model) renderMarker(t theme.Theme, n int) string {
+	plain := lipgloss.NewStyle().Background(t.BackgroundPanel())
+	mk, ok := m.markers[n]
+	if !ok || mk.Glyph == "" {
+		return plain.Render(" ")
+	}
+	glyph := ansi.Truncate(mk.Glyph, 1, "")
+	if c := toneColor(t, mk.Tone); c != nil {
+		return plain.Foreground(c).Render(glyph)
+	}
+	return plain.Foreground(t.TextAccent()).Render(glyph)
+}
+
+// highlight paints line i's tokens in the theme's syntax colors.
+func (m *Model) highlight(t theme.Theme, i int) string {
+	var b strings.Builder
+	for _, tok := range m.lines[i] {
+		b.WriteString(lipgloss.NewStyle().
+			Background(t.BackgroundPanel()).
+			Foreground(kindColor(t, tok.Kind)).
+			Render(tok.Text))
+	}
+	return b.String()
+}
+
+func kindColor(t theme.Theme, k TokenKind) color.Color {
+	switch k {
+	case Keyword:
+		return t.SyntaxKeyword()
+	case Type:
+		return t.SyntaxType()
+	case Function:
+		return t.SyntaxFunction()
+	case Variable:
+		return t.SyntaxVariable()
+	case String:
+		return t.SyntaxString()
+	case Number:
+		return t.SyntaxNumber()
+	case Comment:
+		return t.SyntaxComment()
+	case Operator:
+		return t.SyntaxOperator()
+	case Punctuation:
+		return t.SyntaxPunctuation()
+	}
+	return t.Text()
+}
+
+func toneColor(t theme.Theme, tone Tone) color.Color {
+	switch tone {
+	case ToneInfo:
+		return t.Info()
+	case ToneSuccess:
+		return t.Success()
+	case ToneWarn:
+		return t.Warning()
+	case ToneDanger:
+		return t.Error()
+	}
+	return nil
+}
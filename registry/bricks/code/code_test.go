@@ -0,0 +1,189 @@
+package code
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+const goSample = "package main\n\nimport \"fmt\"\n\n/* block\n   comment */\nfunc main() {\n\tx := 42 // answer\n\tfmt.Println(`raw\nstring`, x)\n}\n"
+
+// kinds flattens one line of tokens into "kind:text" pairs, skipping
+// whitespace-only text.
+func kinds(toks []Token) []string {
+	var out []string
+	for _, tok := range toks {
+		if strings.TrimSpace(tok.Text) == "" {
+			continue
+		}
+		out = append(out, fmt.Sprintf("%d:%s", tok.Kind, strings.TrimSpace(tok.Text)))
+	}
+	return out
+}
+
+func requireKinds(t *testing.T, got []Token, want ...string) {
+	t.Helper()
+	if g := kinds(got); strings.Join(g, "|") != strings.Join(want, "|") {
+		t.Fatalf("tokens = %q, want %q", g, want)
+	}
+}
+
+func tok(k TokenKind, s string) string { return fmt.Sprintf("%d:%s", k, s) }
+
+func TestTokenizeGo(t *testing.T) {
+	lines := Tokenize(Go, goSample)
+	if len(lines) != 11 {
+		t.Fatalf("lines = %d, want 11", len(lines))
+	}
+	requireKinds(t, lines[2], tok(Keyword, "import"), tok(String, `"fmt"`))
+	requireKinds(t, lines[5], tok(Comment, "comment */"))
+	requireKinds(t, lines[6], tok(Keyword, "func"), tok(Function, "main"), tok(Punctuation, "()"), tok(Punctuation, "{"))
+	requireKinds(t, lines[7], tok(Text, "x"), tok(Operator, ":="), tok(Number, "42"), tok(Comment, "// answer"))
+	requireKinds(t, lines[9], tok(String, "string`"), tok(Punctuation, ","), tok(Text, "x"), tok(Punctuation, ")"))
+}
+
+func TestTokenizeOtherLanguages(t *testing.T) {
+	json := Tokenize(JSON, `{"name": "bento", "n": -1.5, "ok": true}`)[0]
+	requireKinds(t, json,
+		tok(Punctuation, "{"), tok(Variable, `"name"`), tok(Punctuation, ":"), tok(String, `"bento"`),
+		tok(Punctuation, ","), tok(Variable, `"n"`), tok(Punctuation, ":"), tok(Number, "-1.5"),
+		tok(Punctuation, ","), tok(Variable, `"ok"`), tok(Punctuation, ":"), tok(Keyword, "true"), tok(Punctuation, "}"))
+
+	yaml := Tokenize(YAML, "# config\n- name: web # svc\n  port: 8080\n  tag: &base 'v1'")
+	requireKinds(t, yaml[0], tok(Comment, "# config"))
+	requireKinds(t, yaml[1], tok(Punctuation, "-"), tok(Variable, "name"), tok(Punctuation, ":"), tok(Text, "web"), tok(Comment, "# svc"))
+	requireKinds(t, yaml[2], tok(Variable, "port"), tok(Punctuation, ":"), tok(Number, "8080"))
+	requireKinds(t, yaml[3], tok(Variable, "tag"), tok(Punctuation, ":"), tok(Type, "&base"), tok(String, "'v1'"))
+
+	sh := Tokenize(Shell, `if [ -n "$HOME" ]; then NAME=x echo $NAME | grep -c x # done`)[0]
+	requireKinds(t, sh,
+		tok(Keyword, "if"), tok(Function, "["), tok(Text, "-n"), tok(String, `"$HOME"`), tok(Text, "]"),
+		tok(Operator, ";"), tok(Keyword, "then"), tok(Variable, "NAME"), tok(Operator, "="), tok(Text, "x"),
+		tok(Function, "echo"), tok(Variable, "$NAME"), tok(Operator, "|"), tok(Function, "grep"),
+		tok(Text, "-c x"), tok(Comment, "# done"))
+
+	md := Tokenize(Markdown, "# Title\n- see [docs](https://x.dev) and `go run`\n```go\nfunc main() {}\n```\n> quote")
+	requireKinds(t, md[0], tok(Keyword, "# Title"))
+	requireKinds(t, md[1], tok(Punctuation, "-"), tok(Text, "see"), tok(Punctuation, "["), tok(Function, "docs"),
+		tok(Punctuation, "]("), tok(String, "https://x.dev"), tok(Punctuation, ")"), tok(Text, "and"), tok(String, "`go run`"))
+	requireKinds(t, md[2], tok(Punctuation, "```"), tok(Type, "go"))
+	requireKinds(t, md[3], tok(String, "func main() {}"))
+	requireKinds(t, md[4], tok(Punctuation, "```"))
+	requireKinds(t, md[5], tok(Comment, "> quote"))
+}
+
+func TestDetectLanguage(t *testing.T) {
+	for name, want := range map[string]Language{
+		"main.go": Go, "package.json": JSON, "ci.yml": YAML, "install.sh": Shell,
+		"README.md": Markdown, "notes.txt": Plain, ".zshrc": Shell,
+	} {
+		if got := DetectLanguage(name); got != want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestConformance(t *testing.T) {
+	testkit.RunConformance(t, testkit.Conformance{
+		New: func() testkit.Brick { return New(Source(goSample), Lang(Go)) },
+	})
+}
+
+func TestGutterAndMarkers(t *testing.T) {
+	m := New(Source(goSample), Lang(Go), FirstLine(9))
+	m.SetMarker(11, Marker{Glyph: "●", Tone: ToneDanger})
+	m.SetSize(30, 4)
+	lines := testkit.FrameOf(m.View()).Lines()
+	want := []string{
+		"  9 package main",
+		" 10",
+		"●11 import \"fmt\"",
+		" 12",
+	}
+	for i, w := range want {
+		if got := strings.TrimRight(lines[i], " "); got != w {
+			t.Fatalf("row %d = %q, want %q", i, got, w)
+		}
+	}
+	m.ClearMarker(11)
+	if strings.Contains(testkit.FrameOf(m.View()).Plain(), "●") {
+		t.Fatal("ClearMarker should remove the glyph")
+	}
+
+	m = New(Source("a\nb"), HideLineNumbers())
+	m.SetSize(10, 2)
+	if got := strings.TrimRight(testkit.FrameOf(m.View()).Lines()[0], " "); got != "  a" {
+		t.Fatalf("row without numbers = %q", got)
+	}
+}
+
+func TestHorizontalScroll(t *testing.T) {
+	src := "short\n" + strings.Repeat("x", 20) + "END"
+	m := New(Source(src))
+	m.Focus()
+	d := testkit.Boot(t, m, 14, 2) // 3-cell gutter, 11 cells of code
+	d.RequireNotContains("END")
+	d.Press("l", "l", "l", "l", "l", "l", "l", "l")
+	if m.Pan() != 12 {
+		t.Fatalf("pan = %d, want clamped to 12", m.Pan())
+	}
+	d.RequireContains("END")
+	if got := strings.TrimRight(d.Frame().Lines()[0], " "); got != " 1" {
+		t.Fatalf("the gutter should stay put while panning: %q", got)
+	}
+	d.Press("h", "h", "h", "h")
+	if m.Pan() != 0 {
+		t.Fatalf("pan = %d, want 0", m.Pan())
+	}
+}
+
+func TestScroll(t *testing.T) {
+	var b strings.Builder
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	m := New(Source(b.String()))
+	d := testkit.Boot(t, m, 20, 5)
+	d.Press("j", "G")
+	if m.Offset() != 0 {
+		t.Fatal("a blurred code view should ignore keys")
+	}
+	d.Send(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if m.Offset() != 3 {
+		t.Fatalf("offset = %d after a wheel notch, want 3", m.Offset())
+	}
+	m.Focus()
+	d.Press("G")
+	if m.Offset() != 25 {
+		t.Fatalf("offset = %d at the bottom, want 25", m.Offset())
+	}
+	d.RequireContains("30 line 30")
+	m.GotoLine(10)
+	if m.Offset() != 8 {
+		t.Fatalf("offset = %d after GotoLine(10), want 8", m.Offset())
+	}
+}
+
+func TestSetKeymapUsesRegistryKeys(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(Scope, keymap.Binding{Action: ActionDown, Keys: []string{"ctrl+n"}})
+	m := New(Source(strings.Repeat("x\n", 20)))
+	m.SetKeymap(r)
+	m.Focus()
+	m.SetSize(10, 5)
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m.Offset() != 0 {
+		t.Fatal("j should no longer scroll")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	if m.Offset() != 1 {
+		t.Fatal("ctrl+n should scroll")
+	}
+	if _, ok := r.Lookup(ActionRight); !ok {
+		t.Fatal("SetKeymap should register the remaining defaults")
+	}
+}
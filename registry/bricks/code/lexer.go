@@ -0,0 +1,623 @@
+package code
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Language picks the tokenizer.
+type Language string
+
+const (
+	Plain    Language = ""
+	Go       Language = "go"
+	JSON     Language = "json"
+	YAML     Language = "yaml"
+	Shell    Language = "shell"
+	Markdown Language = "markdown"
+)
+
+// DetectLanguage guesses the language from a file name, or Plain.
+func DetectLanguage(filename string) Language {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".go":
+		return Go
+	case ".json", ".jsonc":
+		return JSON
+	case ".yaml", ".yml":
+		return YAML
+	case ".sh", ".bash", ".zsh":
+		return Shell
+	case ".md", ".markdown":
+		return Markdown
+	}
+	switch filepath.Base(filename) {
+	case ".bashrc", ".zshrc", ".profile":
+		return Shell
+	}
+	return Plain
+}
+
+// TokenKind is what a token is colored as. Each kind but Text maps to one
+// of the theme's Syntax slots.
+type TokenKind int
+
+const (
+	Text TokenKind = iota
+	Keyword
+	Type
+	Function
+	Variable
+	String
+	Number
+	Comment
+	Operator
+	Punctuation
+)
+
+type Token struct {
+	Kind TokenKind
+	Text string
+}
+
+// Tokenize splits src into lines of tokens. Block comments, raw strings
+// and fenced code carry over from one line to the next.
+func Tokenize(lang Language, src string) [][]Token {
+	lines := strings.Split(strings.TrimSuffix(src, "\n"), "\n")
+	out := make([][]Token, len(lines))
+	var st lexState
+	for i, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		var l lexer
+		switch lang {
+		case Go:
+			l.goLine(line, &st)
+		case JSON:
+			l.jsonLine(line)
+		case YAML:
+			l.yamlLine(line)
+		case Shell:
+			l.shellLine(line)
+		case Markdown:
+			l.markdownLine(line, &st)
+		default:
+			l.emit(Text, line)
+		}
+		out[i] = l.tokens
+	}
+	return out
+}
+
+// lexState is what a tokenizer carries between lines.
+type lexState struct {
+	blockComment bool   // Go: inside /* */
+	rawString    bool   // Go: inside a `raw string`
+	fence        string // Markdown: the open ``` or ~~~ marker
+}
+
+// lexer collects the tokens of one line, merging neighbours of one kind.
+type lexer struct{ tokens []Token }
+
+func (l *lexer) emit(k TokenKind, s string) {
+	if s == "" {
+		return
+	}
+	if n := len(l.tokens); n > 0 && l.tokens[n-1].Kind == k {
+		l.tokens[n-1].Text += s
+		return
+	}
+	l.tokens = append(l.tokens, Token{Kind: k, Text: s})
+}
+
+// ── shared scanners ───────────────────────────────────────────────────────────
+
+func isIdentStart(r rune) bool { return r == '_' || unicode.IsLetter(r) }
+func isIdent(r rune) bool      { return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+func isDigit(b byte) bool      { return b >= '0' && b <= '9' }
+
+// scanIdent returns the end of the identifier starting at i.
+func scanIdent(s string, i int) int {
+	for i < len(s) {
+		r, n := utf8.DecodeRuneInString(s[i:])
+		if !isIdent(r) {
+			break
+		}
+		i += n
+	}
+	return i
+}
+
+// scanQuoted returns the end of the quoted string starting at i, after the
+// closing quote or at the end of the line. Backslash escapes when escapes.
+func scanQuoted(s string, i int, escapes bool) int {
+	q := s[i]
+	for i++; i < len(s); i++ {
+		switch {
+		case escapes && s[i] == '\\':
+			i++
+		case s[i] == q:
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// scanNumber returns the end of the number starting at i: digits, hex,
+// underscores, a fraction and an exponent.
+func scanNumber(s string, i int) int {
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case isDigit(c), c == '.', c == '_', c == 'x', c == 'X', c == 'o', c == 'b',
+			c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+		case (c == '+' || c == '-') && (s[i-1] == 'e' || s[i-1] == 'E'):
+		default:
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+// nextNonSpace returns the first byte after i that is not a space or tab.
+func nextNonSpace(s string, i int) byte {
+	for ; i < len(s); i++ {
+		if s[i] != ' ' && s[i] != '\t' {
+			return s[i]
+		}
+	}
+	return 0
+}
+
+func spaces(s string, i int) int {
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+func set(words ...string) map[string]bool {
+	m := make(map[string]bool, len(words))
+	for _, w := range words {
+		m[w] = true
+	}
+	return m
+}
+
+// ── Go ────────────────────────────────────────────────────────────────────────
+
+var (
+	goKeywords = set("break", "case", "chan", "const", "continue", "default", "defer", "else",
+		"fallthrough", "for", "func", "go", "goto", "if", "import", "interface", "map",
+		"package", "range", "return", "select", "struct", "switch", "type", "var",
+		"true", "false", "nil", "iota")
+	goTypes = set("any", "bool", "byte", "comparable", "complex64", "complex128", "error",
+		"float32", "float64", "int", "int8", "int16", "int32", "int64", "rune", "string",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr")
+)
+
+func (l *lexer) goLine(s string, st *lexState) {
+	for i := 0; i < len(s); {
+		switch {
+		case st.blockComment:
+			end := strings.Index(s[i:], "*/")
+			if end < 0 {
+				l.emit(Comment, s[i:])
+				return
+			}
+			l.emit(Comment, s[i:i+end+2])
+			i += end + 2
+			st.blockComment = false
+			continue
+		case st.rawString:
+			end := strings.IndexByte(s[i:], '`')
+			if end < 0 {
+				l.emit(String, s[i:])
+				return
+			}
+			l.emit(String, s[i:i+end+1])
+			i += end + 1
+			st.rawString = false
+			continue
+		}
+		c := s[i]
+		r, n := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case strings.HasPrefix(s[i:], "//"):
+			l.emit(Comment, s[i:])
+			return
+		case strings.HasPrefix(s[i:], "/*"):
+			l.emit(Comment, "/*")
+			st.blockComment = true
+			i += 2
+		case c == '`':
+			l.emit(String, "`")
+			st.rawString = true
+			i++
+		case c == '"' || c == '\'':
+			j := scanQuoted(s, i, true)
+			l.emit(String, s[i:j])
+			i = j
+		case isDigit(c) || (c == '.' && i+1 < len(s) && isDigit(s[i+1])):
+			j := scanNumber(s, i)
+			l.emit(Number, s[i:j])
+			i = j
+		case isIdentStart(r):
+			j := scanIdent(s, i)
+			word := s[i:j]
+			switch {
+			case goKeywords[word]:
+				l.emit(Keyword, word)
+			case goTypes[word]:
+				l.emit(Type, word)
+			case nextNonSpace(s, j) == '(':
+				l.emit(Function, word)
+			default:
+				l.emit(Text, word)
+			}
+			i = j
+		case c == ' ' || c == '\t':
+			j := spaces(s, i)
+			l.emit(Text, s[i:j])
+			i = j
+		case strings.IndexByte("(){}[],;.", c) >= 0:
+			l.emit(Punctuation, s[i:i+1])
+			i++
+		case strings.IndexByte("+-*/%&|^<>=!:~", c) >= 0:
+			l.emit(Operator, s[i:i+1])
+			i++
+		default:
+			l.emit(Text, s[i:i+n])
+			i += n
+		}
+	}
+}
+
+// ── JSON ──────────────────────────────────────────────────────────────────────
+
+func (l *lexer) jsonLine(s string) {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			j := scanQuoted(s, i, true)
+			if nextNonSpace(s, j) == ':' {
+				l.emit(Variable, s[i:j]) // object key
+			} else {
+				l.emit(String, s[i:j])
+			}
+			i = j
+		case isDigit(c) || (c == '-' && i+1 < len(s) && isDigit(s[i+1])):
+			j := scanNumber(s, i+1)
+			l.emit(Number, s[i:j])
+			i = j
+		case c >= 'a' && c <= 'z':
+			j := scanIdent(s, i)
+			if w := s[i:j]; w == "true" || w == "false" || w == "null" {
+				l.emit(Keyword, w)
+			} else {
+				l.emit(Text, w)
+			}
+			i = j
+		case strings.IndexByte("{}[],:", c) >= 0:
+			l.emit(Punctuation, s[i:i+1])
+			i++
+		case strings.HasPrefix(s[i:], "//"): // JSONC
+			l.emit(Comment, s[i:])
+			return
+		default:
+			_, n := utf8.DecodeRuneInString(s[i:])
+			l.emit(Text, s[i:i+n])
+			i += n
+		}
+	}
+}
+
+// ── YAML ──────────────────────────────────────────────────────────────────────
+
+var yamlKeywords = set("true", "false", "yes", "no", "on", "off", "null", "~",
+	"True", "False", "Yes", "No", "Null", "TRUE", "FALSE", "NULL")
+
+func (l *lexer) yamlLine(s string) {
+	i := spaces(s, 0)
+	l.emit(Text, s[:i])
+	rest := s[i:]
+	switch {
+	case strings.HasPrefix(rest, "#"):
+		l.emit(Comment, rest)
+		return
+	case rest == "---" || rest == "...":
+		l.emit(Punctuation, rest)
+		return
+	}
+	// List markers.
+	for strings.HasPrefix(s[i:], "- ") || s[i:] == "-" {
+		l.emit(Punctuation, "-")
+		j := spaces(s, i+1)
+		l.emit(Text, s[i+1:j])
+		i = j
+	}
+	// A key, plain or quoted, followed by ": " or a line-ending ":".
+	if key := yamlKey(s[i:]); key > 0 {
+		l.emit(Variable, s[i:i+key])
+		l.emit(Punctuation, ":")
+		i += key + 1
+	}
+	l.yamlValue(s, i)
+}
+
+// yamlKey returns the length of the mapping key at the start of s, or 0.
+func yamlKey(s string) int {
+	if s == "" {
+		return 0
+	}
+	end := 0
+	if s[0] == '"' || s[0] == '\'' {
+		end = scanQuoted(s, 0, s[0] == '"')
+	} else {
+		for end < len(s) && s[end] != ':' {
+			if s[end] == '#' || s[end] == '{' || s[end] == '[' {
+				return 0
+			}
+			end++
+		}
+	}
+	if end >= len(s) || s[end] != ':' || (end+1 < len(s) && s[end+1] != ' ') {
+		return 0
+	}
+	return end
+}
+
+func (l *lexer) yamlValue(s string, i int) {
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t':
+			j := spaces(s, i)
+			if j < len(s) && s[j] == '#' {
+				l.emit(Comment, s[i:])
+				return
+			}
+			l.emit(Text, s[i:j])
+			i = j
+		case c == '#' && i == 0:
+			l.emit(Comment, s[i:])
+			return
+		case c == '"' || c == '\'':
+			j := scanQuoted(s, i, c == '"')
+			l.emit(String, s[i:j])
+			i = j
+		case strings.IndexByte("{}[],", c) >= 0:
+			l.emit(Punctuation, s[i:i+1])
+			i++
+		case c == '|' || c == '>':
+			l.emit(Operator, s[i:i+1])
+			i++
+		default:
+			j := i
+			for j < len(s) && strings.IndexByte(" \t{}[],", s[j]) < 0 {
+				j++
+			}
+			word := s[i:j]
+			switch {
+			case yamlKeywords[word]:
+				l.emit(Keyword, word)
+			case word[0] == '&' || word[0] == '*' || word[0] == '!':
+				l.emit(Type, word) // anchors, aliases and tags
+			case isNumber(word):
+				l.emit(Number, word)
+			default:
+				l.emit(Text, word)
+			}
+			i = j
+		}
+	}
+}
+
+func isNumber(w string) bool {
+	w = strings.TrimLeft(w, "+-")
+	return w != "" && (isDigit(w[0]) || (w[0] == '.' && len(w) > 1 && isDigit(w[1]))) && scanNumber(w, 0) == len(w)
+}
+
+// ── shell ─────────────────────────────────────────────────────────────────────
+
+var (
+	shellKeywords = set("if", "then", "else", "elif", "fi", "for", "while", "until", "do",
+		"done", "case", "esac", "in", "function", "select", "return", "break", "continue")
+	shellBuiltins = set("echo", "printf", "cd", "export", "local", "readonly", "set", "unset",
+		"source", "exit", "test", "read", "shift", "trap", "eval", "exec", "alias")
+)
+
+func (l *lexer) shellLine(s string) {
+	command := true // the next word is a command name
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			l.emit(Comment, s[i:])
+			return
+		case c == '\'' || c == '"':
+			j := scanQuoted(s, i, c == '"')
+			l.emit(String, s[i:j])
+			i = j
+			command = false
+		case c == '$':
+			j := shellVar(s, i)
+			if j == i+2 && s[i+1] == '(' {
+				l.emit(Operator, "$(")
+				command = true
+			} else {
+				l.emit(Variable, s[i:j])
+				command = false
+			}
+			i = j
+		case c == ' ' || c == '\t':
+			j := spaces(s, i)
+			l.emit(Text, s[i:j])
+			i = j
+		case strings.IndexByte("|&;<>()`!", c) >= 0:
+			j := i + 1
+			for j < len(s) && strings.IndexByte("|&;<>", s[j]) >= 0 {
+				j++
+			}
+			l.emit(Operator, s[i:j])
+			i = j
+			command = c != '>' && c != '<'
+		default:
+			j := i
+			for j < len(s) && strings.IndexByte(" \t|&;<>()`'\"$", s[j]) < 0 {
+				j++
+			}
+			word := s[i:j]
+			if name, _, ok := strings.Cut(word, "="); ok && command && name != "" && scanIdent(name, 0) == len(name) {
+				l.emit(Variable, name) // assignment
+				l.emit(Operator, "=")
+				l.emit(Text, word[len(name)+1:])
+				i = j
+				continue
+			}
+			switch {
+			case shellKeywords[word]:
+				l.emit(Keyword, word)
+				command = word != "in" && word != "function"
+				i = j
+				continue
+			case isNumber(word):
+				l.emit(Number, word)
+			case command || shellBuiltins[word]:
+				l.emit(Function, word)
+			default:
+				l.emit(Text, word)
+			}
+			command = false
+			i = j
+		}
+	}
+}
+
+// shellVar returns the end of the variable reference starting at i.
+func shellVar(s string, i int) int {
+	j := i + 1
+	switch {
+	case j >= len(s):
+		return j
+	case s[j] == '{':
+		if end := strings.IndexByte(s[j:], '}'); end >= 0 {
+			return j + end + 1
+		}
+		return len(s)
+	case s[j] == '(':
+		return j + 1
+	case strings.IndexByte("?#@*!$-0123456789", s[j]) >= 0:
+		return j + 1
+	}
+	return scanIdent(s, j)
+}
+
+// ── Markdown ──────────────────────────────────────────────────────────────────
+
+func (l *lexer) markdownLine(s string, st *lexState) {
+	trimmed := strings.TrimLeft(s, " \t")
+	indent := s[:len(s)-len(trimmed)]
+	if st.fence != "" {
+		if strings.HasPrefix(trimmed, st.fence) {
+			l.emit(Punctuation, s)
+			st.fence = ""
+		} else {
+			l.emit(String, s)
+		}
+		return
+	}
+	for _, fence := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, fence) {
+			st.fence = fence
+			l.emit(Text, indent)
+			l.emit(Punctuation, fence)
+			l.emit(Type, trimmed[len(fence):]) // info string
+			return
+		}
+	}
+	switch {
+	case strings.HasPrefix(trimmed, "#"):
+		if level := len(trimmed) - len(strings.TrimLeft(trimmed, "#")); level <= 6 {
+			l.emit(Keyword, s)
+			return
+		}
+	case strings.HasPrefix(trimmed, ">"):
+		l.emit(Comment, s)
+		return
+	case isRule(trimmed):
+		l.emit(Punctuation, s)
+		return
+	}
+	l.emit(Text, indent)
+	i := len(indent)
+	if n := listMarker(trimmed); n > 0 {
+		l.emit(Punctuation, s[i:i+n])
+		i += n
+	}
+	l.markdownInline(s, i)
+}
+
+func isRule(s string) bool {
+	s = strings.ReplaceAll(s, " ", "")
+	return len(s) >= 3 && (strings.Trim(s, "-") == "" || strings.Trim(s, "*") == "" || strings.Trim(s, "_") == "")
+}
+
+// listMarker returns the length of a "- ", "* ", "+ " or "1. " marker.
+func listMarker(s string) int {
+	if len(s) >= 2 && strings.IndexByte("-*+", s[0]) >= 0 && s[1] == ' ' {
+		return 1
+	}
+	j := 0
+	for j < len(s) && isDigit(s[j]) {
+		j++
+	}
+	if j > 0 && j+1 < len(s) && (s[j] == '.' || s[j] == ')') && s[j+1] == ' ' {
+		return j + 1
+	}
+	return 0
+}
+
+// markdownInline colors code spans, emphasis and links.
+func (l *lexer) markdownInline(s string, i int) {
+	for i < len(s) {
+		c := s[i]
+		switch {
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				l.emit(String, s[i:i+end+2])
+				i += end + 2
+				continue
+			}
+		case c == '*' || c == '_':
+			mark := s[i : i+1]
+			if strings.HasPrefix(s[i:], mark+mark) {
+				mark += mark
+			}
+			if end := strings.Index(s[i+len(mark):], mark); end > 0 {
+				l.emit(Type, s[i:i+len(mark)+end+len(mark)])
+				i += len(mark) + end + len(mark)
+				continue
+			}
+		case c == '[':
+			close := strings.Index(s[i:], "](")
+			if close > 0 {
+				if end := strings.IndexByte(s[i+close:], ')'); end > 0 {
+					l.emit(Punctuation, "[")
+					l.emit(Function, s[i+1:i+close])
+					l.emit(Punctuation, "](")
+					l.emit(String, s[i+close+2:i+close+end])
+					l.emit(Punctuation, ")")
+					i += close + end + 1
+					continue
+				}
+			}
+		}
+		_, n := utf8.DecodeRuneInString(s[i:])
+		l.emit(Text, s[i:i+n])
+		i += n
+	}
+}
@@ -0,0 +1,299 @@
+// Brick: Choice
+// +-----------------------------------+
+// | message                            |
+// | > ( ) first option                 |
+// |   (x) second option                |
+// +-----------------------------------+
+// Option list dialog with single or multi select.
+package dialog
+
+import (
+	"strings"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+)
+
+type ChoiceKeyMap struct {
+	Up     bubbleskey.Binding
+	Down   bubbleskey.Binding
+	Top    bubbleskey.Binding
+	Bottom bubbleskey.Binding
+	Toggle bubbleskey.Binding // multi-select only
+	Choose bubbleskey.Binding
+	Close  bubbleskey.Binding
+}
+
+func DefaultChoiceKeyMap() ChoiceKeyMap {
+	return ChoiceKeyMap{
+		Up:     bubbleskey.NewBinding(bubbleskey.WithKeys("up", "k", "shift+tab"), bubbleskey.WithHelp("↑/k", "up")),
+		Down:   bubbleskey.NewBinding(bubbleskey.WithKeys("down", "j", "tab"), bubbleskey.WithHelp("↓/j", "down")),
+		Top:    bubbleskey.NewBinding(bubbleskey.WithKeys("home", "g"), bubbleskey.WithHelp("g", "first")),
+		Bottom: bubbleskey.NewBinding(bubbleskey.WithKeys("end", "G"), bubbleskey.WithHelp("G", "last")),
+		Toggle: bubbleskey.NewBinding(bubbleskey.WithKeys("space"), bubbleskey.WithHelp("space", "toggle")),
+		Choose: bubbleskey.NewBinding(bubbleskey.WithKeys("enter"), bubbleskey.WithHelp("enter", "choose")),
+		Close:  bubbleskey.NewBinding(bubbleskey.WithKeys("esc"), bubbleskey.WithHelp("esc", "cancel")),
+	}
+}
+
+// Scope and action IDs the Choice bindings register under in a
+// keymap.Registry.
+const (
+	ChoiceScope        keymap.Scope = "choice"
+	ActionChoiceUp                  = "choice.up"
+	ActionChoiceDown                = "choice.down"
+	ActionChoiceTop                 = "choice.top"
+	ActionChoiceBottom              = "choice.bottom"
+	ActionChoiceToggle              = "choice.toggle"
+	ActionChoiceChoose              = "choice.choose"
+	ActionChoiceClose               = "choice.close"
+)
+
+// Bindings returns km as keymap bindings.
+func (km ChoiceKeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionChoiceUp, km.Up),
+		keymap.FromKey(ActionChoiceDown, km.Down),
+		keymap.FromKey(ActionChoiceTop, km.Top),
+		keymap.FromKey(ActionChoiceBottom, km.Bottom),
+		keymap.FromKey(ActionChoiceToggle, km.Toggle),
+		keymap.FromKey(ActionChoiceChoose, km.Choose),
+		keymap.FromKey(ActionChoiceClose, km.Close),
+	}
+}
+
+// ChoiceOption is one entry in a Choice. Value is reported in ResultMsg and
+// defaults to Label.
+type ChoiceOption struct {
+	Label string
+	Value string
+}
+
+func (o ChoiceOption) value() string {
+	if o.Value != "" {
+		return o.Value
+	}
+	return o.Label
+}
+
+// Choice asks the user to pick from a list. In single-select mode enter
+// closes with the highlighted option's value; in multi-select mode space
+// toggles options and enter closes with every checked value, in list order.
+type Choice struct {
+	id      string
+	title   string
+	message string
+	options []ChoiceOption
+	multi   bool
+	checked map[int]bool
+	cursor  int
+	width   int
+	height  int
+	keys    ChoiceKeyMap
+	keymap  *keymap.Registry // nil = use keys; set by Manager
+	theme   theme.Theme      // nil = use theme.CurrentTheme(); set by Manager
+}
+
+func NewChoice(id, title string, options ...ChoiceOption) *Choice {
+	return &Choice{
+		id:      id,
+		title:   title,
+		options: append([]ChoiceOption(nil), options...),
+		checked: make(map[int]bool),
+		keys:    DefaultChoiceKeyMap(),
+	}
+}
+
+// SetKeymap reads the Choice keys from r.
+func (c *Choice) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(ChoiceScope, c.keys.Bindings()...)
+	}
+	c.keymap = r
+}
+
+func (c *Choice) keyMap() ChoiceKeyMap {
+	r, k := c.keymap, c.keys
+	return ChoiceKeyMap{
+		Up:     r.KeyOr(ActionChoiceUp, k.Up),
+		Down:   r.KeyOr(ActionChoiceDown, k.Down),
+		Top:    r.KeyOr(ActionChoiceTop, k.Top),
+		Bottom: r.KeyOr(ActionChoiceBottom, k.Bottom),
+		Toggle: r.KeyOr(ActionChoiceToggle, k.Toggle),
+		Choose: r.KeyOr(ActionChoiceChoose, k.Choose),
+		Close:  r.KeyOr(ActionChoiceClose, k.Close),
+	}
+}
+
+// SetMessage sets the question shown above the options.
+func (c *Choice) SetMessage(message string) { c.message = message }
+
+// SetMulti switches between single-select and multi-select.
+func (c *Choice) SetMulti(multi bool) { c.multi = multi }
+
+// SetSelected moves the cursor to the first matching value and, in
+// multi-select mode, checks every matching value.
+func (c *Choice) SetSelected(values ...string) {
+	want := make(map[string]bool, len(values))
+	for _, v := range values {
+		want[v] = true
+	}
+	first := true
+	c.checked = make(map[int]bool)
+	for i, o := range c.options {
+		if !want[o.value()] {
+			continue
+		}
+		if first {
+			c.cursor = i
+			first = false
+		}
+		c.checked[i] = true
+	}
+}
+
+// Selected returns the checked values in multi-select mode, or the
+// highlighted value in single-select mode.
+func (c *Choice) Selected() []string {
+	if len(c.options) == 0 {
+		return nil
+	}
+	if !c.multi {
+		return []string{c.options[c.cursor].value()}
+	}
+	out := make([]string, 0, len(c.checked))
+	for i, o := range c.options {
+		if c.checked[i] {
+			out = append(out, o.value())
+		}
+	}
+	return out
+}
+
+// ID returns the id reported in ResultMsg.
+func (c *Choice) ID() string { return c.id }
+
+func (c *Choice) Init() tea.Cmd { return nil }
+
+func (c *Choice) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+
+	keys := c.keyMap()
+	switch {
+	case bubbleskey.Matches(keyMsg, keys.Close):
+		return c, func() tea.Msg { return CloseWith(ResultMsg{ID: c.id, Canceled: true}) }
+	case bubbleskey.Matches(keyMsg, keys.Up):
+		if c.cursor > 0 {
+			c.cursor--
+		}
+	case bubbleskey.Matches(keyMsg, keys.Down):
+		if c.cursor < len(c.options)-1 {
+			c.cursor++
+		}
+	case bubbleskey.Matches(keyMsg, keys.Top):
+		c.cursor = 0
+	case bubbleskey.Matches(keyMsg, keys.Bottom):
+		c.cursor = max(0, len(c.options)-1)
+	case bubbleskey.Matches(keyMsg, keys.Toggle):
+		if c.multi && len(c.options) > 0 {
+			c.checked[c.cursor] = !c.checked[c.cursor]
+		}
+	case bubbleskey.Matches(keyMsg, keys.Choose):
+		if len(c.options) == 0 {
+			return c, nil
+		}
+		result := ResultMsg{ID: c.id}
+		if c.multi {
+			result.Value = c.Selected()
+		} else {
+			result.Value = c.options[c.cursor].value()
+		}
+		return c, func() tea.Msg { return CloseWith(result) }
+	}
+	return c, nil
+}
+
+func (c *Choice) View() tea.View {
+	t := c.activeTheme()
+	width := max(48, c.width/2)
+	inner := max(1, width-4)
+
+	head := make([]string, 0, 2)
+	if strings.TrimSpace(c.message) != "" {
+		head = append(head, c.message, "")
+	}
+	hint := "↑↓ move  enter choose  esc cancel"
+	if c.multi {
+		hint = "↑↓ move  space toggle  enter done  esc cancel"
+	}
+	foot := []string{"", dialogToneRow(t, t.TextMuted(), inner, hint)}
+
+	// Frame chrome is 4 rows; keep the whole dialog inside the screen margin.
+	visible := len(c.options)
+	if c.height > 0 {
+		visible = min(visible, max(1, c.height-4-4-len(head)-len(foot)))
+	}
+	start := 0
+	if c.cursor >= visible {
+		start = c.cursor - visible + 1
+	}
+	end := min(len(c.options), start+visible)
+
+	rows := append([]string(nil), head...)
+	if len(c.options) == 0 {
+		rows = append(rows, dialogToneRow(t, t.TextMuted(), inner, "No options"))
+	}
+	for i := start; i < end; i++ {
+		rows = append(rows, c.renderOption(t, i, inner))
+	}
+	rows = append(rows, foot...)
+
+	return tea.NewView(renderDialogFrame(c.title, strings.Join(rows, "\n"), width, len(rows)+4, t))
+}
+
+func (c *Choice) renderOption(t theme.Theme, i, width int) string {
+	marker := "  "
+	if i == c.cursor {
+		marker = "> "
+	}
+	if c.multi {
+		if c.checked[i] {
+			marker += "[x] "
+		} else {
+			marker += "[ ] "
+		}
+	}
+	line := paletteClip(marker+c.options[i].Label, width)
+	if i != c.cursor {
+		return line
+	}
+	return lipgloss.NewStyle().
+		Background(t.SelectionBG()).
+		Foreground(t.SelectionFG()).
+		Render(line)
+}
+
+func (c *Choice) SetSize(width, height int) {
+	c.width = width
+	c.height = height
+}
+
+// SetTheme updates the theme. The Manager calls it on open and on theme
+// changes.
+func (c *Choice) SetTheme(t theme.Theme) { c.theme = t }
+
+func (c *Choice) activeTheme() theme.Theme {
+	if c.theme != nil {
+		return c.theme
+	}
+	return theme.CurrentTheme()
+}
+
+func (c *Choice) Title() string { return c.title }
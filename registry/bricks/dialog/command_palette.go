@@ -1,40 +1,167 @@
 // Brick: Command Palette
 // +-----------------------------------+
+// | breadcrumbs (inside a step)       |
 // | search row                        |
 // +-----------------------------------+
 // | grouped command list              |
 // +-----------------------------------+
-// Searchable grouped action picker.
+// Searchable grouped action picker with a Recent group on top, nested
+// sub-menus and argument steps.
 package dialog
 
 import (
 	"image/color"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	bubbleskey "charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/sahilm/fuzzy"
 
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
 )
 
-// Command is a single entry in the command palette.
+// PaletteKeyMap holds the command palette's own keys. Letters are left free
+// for the search query.
+type PaletteKeyMap struct {
+	Up       bubbleskey.Binding
+	Down     bubbleskey.Binding
+	Run      bubbleskey.Binding
+	Close    bubbleskey.Binding
+	Back     bubbleskey.Binding // on an empty query
+	Complete bubbleskey.Binding // path argument steps
+}
+
+func DefaultPaletteKeyMap() PaletteKeyMap {
+	return PaletteKeyMap{
+		Up:       bubbleskey.NewBinding(bubbleskey.WithKeys("up", "ctrl+p"), bubbleskey.WithHelp("↑", "previous")),
+		Down:     bubbleskey.NewBinding(bubbleskey.WithKeys("down", "ctrl+n"), bubbleskey.WithHelp("↓", "next")),
+		Run:      bubbleskey.NewBinding(bubbleskey.WithKeys("enter"), bubbleskey.WithHelp("enter", "run")),
+		Close:    bubbleskey.NewBinding(bubbleskey.WithKeys("esc"), bubbleskey.WithHelp("esc", "close")),
+		Back:     bubbleskey.NewBinding(bubbleskey.WithKeys("backspace"), bubbleskey.WithHelp("⌫", "back")),
+		Complete: bubbleskey.NewBinding(bubbleskey.WithKeys("tab"), bubbleskey.WithHelp("tab", "complete")),
+	}
+}
+
+// Scope and action IDs the palette bindings register under in a
+// keymap.Registry.
+const (
+	PaletteScope          keymap.Scope = "palette"
+	ActionPaletteUp                    = "palette.up"
+	ActionPaletteDown                  = "palette.down"
+	ActionPaletteRun                   = "palette.run"
+	ActionPaletteClose                 = "palette.close"
+	ActionPaletteBack                  = "palette.back"
+	ActionPaletteComplete              = "palette.complete"
+)
+
+// Bindings returns km as keymap bindings.
+func (km PaletteKeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionPaletteUp, km.Up),
+		keymap.FromKey(ActionPaletteDown, km.Down),
+		keymap.FromKey(ActionPaletteRun, km.Run),
+		keymap.FromKey(ActionPaletteClose, km.Close),
+		keymap.FromKey(ActionPaletteBack, km.Back),
+		keymap.FromKey(ActionPaletteComplete, km.Complete),
+	}
+}
+
+// Command is a single entry in the command palette. A command with Children
+// opens a sub-menu; one with Args walks the user through each argument and
+// then calls Run with the collected values. Otherwise Action runs directly.
 type Command struct {
-	Label   string // e.g. "Switch theme"
-	Group   string // e.g. "System" — empty = "Commands"
-	Keybind string // e.g. "ctrl+t" — optional, shown right-aligned
-	Action  func() tea.Msg
+	ID       string   // stable key for History — empty = Label
+	Label    string   // e.g. "Switch theme"
+	Group    string   // e.g. "System" — empty = "Commands"
+	Aliases  []string // extra search terms, e.g. "colors"
+	Keybind  string   // e.g. "ctrl+t" — optional, shown right-aligned; empty = the keymap binding for ID
+	Action   func() tea.Msg
+	Children []Command          // sub-menu entries
+	Args     []Arg              // values to collect before Run
+	Run      func(Args) tea.Msg // called with collected Args — nil = Action
+}
+
+func (c Command) key() string {
+	if c.ID != "" {
+		return c.ID
+	}
+	return c.Label
+}
+
+func (c Command) group() string {
+	if c.Group == "" {
+		return "Commands"
+	}
+	return c.Group
+}
+
+// paletteMatch is a command that survived the search, with its rank.
+type paletteMatch struct {
+	Command
+	order   int    // position in the command list
+	tier    int    // 0 label, 1 alias, 2 group
+	score   int    // fuzzy score within the tier
+	matched []int  // byte offsets of matched label characters
+	section string // group heading the command is listed under
+	value   string // option value or path inside an argument step
+	dir     bool   // path entry is a folder to descend into
+}
+
+// paletteMenu is an opened sub-menu.
+type paletteMenu struct {
+	cmd Command
+}
+
+// paletteStep collects the arguments of the chosen command.
+type paletteStep struct {
+	cmd    Command
+	idx    int
+	values Args
 }
 
-// CommandPalette is a searchable, grouped command picker dialog.
+// arg is the argument being collected. Once the last one is accepted the
+// step is done and arg is the zero Arg.
+func (s *paletteStep) arg() Arg {
+	if s.idx >= len(s.cmd.Args) {
+		return Arg{}
+	}
+	return s.cmd.Args[s.idx]
+}
+
+// recentGroup heads the frecency-ranked commands shown before any query.
+const recentGroup = "Recent"
+
+// CommandPalette is a searchable, grouped command picker dialog. With an
+// empty query commands are listed by group, led by a Recent group of the
+// most frequently and recently run commands; typing ranks them by fuzzy
+// match on label, then aliases, then group, with ties going to the most
+// recently run command.
+//
+// Choosing a command with Children or Args moves into it; breadcrumbs show
+// the trail, and backspace on an empty query goes back up one step.
 type CommandPalette struct {
-	commands []Command
-	filtered []Command
-	groups   []string
-	selected int
-	search   textinput.Model
-	width    int
-	height   int
+	commands    []Command
+	filtered    []paletteMatch
+	groups      []string
+	selected    int
+	search      textinput.Model
+	history     *History
+	recentLimit int
+	menus       []paletteMenu // opened sub-menus, innermost last
+	step        *paletteStep  // argument being collected, nil while choosing
+	errText     string        // validation error for the current step
+	keys        PaletteKeyMap
+	keymap      *keymap.Registry // nil = use keys
+	theme       theme.Theme      // nil = use theme.CurrentTheme(); set by Manager
+	width       int
+	height      int
 }
 
 // NewCommandPalette creates a CommandPalette pre-loaded with commands.
@@ -46,59 +173,298 @@ func NewCommandPalette(commands []Command) *CommandPalette {
 	in.Focus()
 
 	p := &CommandPalette{
-		commands: commands,
-		selected: 0,
-		search:   in,
+		commands:    commands,
+		selected:    0,
+		search:      in,
+		recentLimit: 5,
+		keys:        DefaultPaletteKeyMap(),
 	}
 	p.refilter()
 	p.syncStyles()
 	return p
 }
 
+// SetHistory shares a History with the palette. Running a command records
+// it, its best commands fill the Recent group, and recency breaks ranking
+// ties.
+func (p *CommandPalette) SetHistory(h *History) {
+	p.history = h
+	p.refilter()
+}
+
+// SetRecentLimit sets how many commands the Recent group shows (default 5).
+// Zero hides the group.
+func (p *CommandPalette) SetRecentLimit(n int) {
+	p.recentLimit = maxv(0, n)
+	p.refilter()
+}
+
+// SetKeymap reads the palette keys from r. Commands without a Keybind show
+// the key r binds to their ID.
+func (p *CommandPalette) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(PaletteScope, p.keys.Bindings()...)
+	}
+	p.keymap = r
+}
+
+func (p *CommandPalette) keyMap() PaletteKeyMap {
+	r, k := p.keymap, p.keys
+	return PaletteKeyMap{
+		Up:       r.KeyOr(ActionPaletteUp, k.Up),
+		Down:     r.KeyOr(ActionPaletteDown, k.Down),
+		Run:      r.KeyOr(ActionPaletteRun, k.Run),
+		Close:    r.KeyOr(ActionPaletteClose, k.Close),
+		Back:     r.KeyOr(ActionPaletteBack, k.Back),
+		Complete: r.KeyOr(ActionPaletteComplete, k.Complete),
+	}
+}
+
+// keybind is the key shown next to c: its own Keybind, else the keymap's.
+func (p *CommandPalette) keybind(c Command) string {
+	if c.Keybind != "" || p.step != nil {
+		return c.Keybind
+	}
+	if b, ok := p.keymap.Lookup(c.key()); ok && len(b.Keys) > 0 {
+		return b.Label()
+	}
+	return ""
+}
+
 func (p *CommandPalette) Init() tea.Cmd { return p.search.Focus() }
 
 func (p *CommandPalette) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.MouseClickMsg:
+		return p, p.click(msg)
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			p.selected = maxv(0, p.selected-1)
+		case tea.MouseWheelDown:
+			p.selected = maxv(0, minv(len(p.filtered)-1, p.selected+1))
+		}
+		return p, nil
+	}
 	keyMsg, ok := msg.(tea.KeyMsg)
 	if !ok {
 		return p, nil
 	}
 
-	switch keyMsg.String() {
-	case "esc":
+	keys := p.keyMap()
+	switch {
+	case bubbleskey.Matches(keyMsg, keys.Close):
 		return p, func() tea.Msg { return Close() }
-	case "up", "k":
+	case bubbleskey.Matches(keyMsg, keys.Up):
 		if p.selected > 0 {
 			p.selected--
 		}
 		return p, nil
-	case "down", "j":
+	case bubbleskey.Matches(keyMsg, keys.Down):
 		if p.selected < len(p.filtered)-1 {
 			p.selected++
 		}
 		return p, nil
-	case "enter":
-		if len(p.filtered) == 0 {
+	case bubbleskey.Matches(keyMsg, keys.Back):
+		if p.search.Value() == "" && p.back() {
+			return p, nil
+		}
+	case bubbleskey.Matches(keyMsg, keys.Complete):
+		if p.step != nil && p.step.arg().Kind == ArgPath && len(p.filtered) > 0 {
+			p.setQuery(p.filtered[p.selected].value)
 			return p, nil
 		}
-		cmd := p.filtered[p.selected]
-		if cmd.Action == nil {
-			return p, func() tea.Msg { return Close() }
+	case bubbleskey.Matches(keyMsg, keys.Run):
+		if p.step != nil {
+			return p, p.chooseArg()
 		}
-		action := cmd.Action
-		return p, tea.Sequence(
-			func() tea.Msg { return Close() },
-			func() tea.Msg { return action() },
-		)
+		return p, p.choose()
 	}
 
+	before := p.search.Value()
 	updated, cmd := p.search.Update(keyMsg)
 	p.search = updated
+	if p.search.Value() != before {
+		p.selected = 0
+		p.errText = ""
+	}
 	p.refilter()
 	return p, cmd
 }
 
+// choose opens, starts or runs the selected command.
+func (p *CommandPalette) choose() tea.Cmd {
+	if len(p.filtered) == 0 {
+		return nil
+	}
+	c := p.filtered[p.selected].Command
+	switch {
+	case len(c.Children) > 0:
+		p.menus = append(p.menus, paletteMenu{cmd: c})
+		p.setQuery("")
+		return nil
+	case len(c.Args) > 0:
+		p.step = &paletteStep{cmd: c, values: Args{}}
+		p.enterArg()
+		return nil
+	}
+	return p.run(c, nil)
+}
+
+// chooseArg accepts the current argument and moves to the next one, or runs
+// the command once every argument is collected.
+func (p *CommandPalette) chooseArg() tea.Cmd {
+	a := p.step.arg()
+	value := p.search.Value()
+	switch a.Kind {
+	case ArgEnum:
+		if len(p.filtered) == 0 {
+			return nil
+		}
+		value = p.filtered[p.selected].value
+	case ArgPath:
+		if len(p.filtered) > 0 {
+			sel := p.filtered[p.selected]
+			if sel.dir {
+				p.setQuery(sel.value)
+				return nil
+			}
+			value = sel.value
+		}
+	}
+	if a.Required && strings.TrimSpace(value) == "" {
+		p.errText = a.label() + " is required"
+		return nil
+	}
+	if a.Validate != nil {
+		if err := a.Validate(value); err != nil {
+			p.errText = err.Error()
+			return nil
+		}
+	}
+
+	p.step.values[a.Key] = value
+	p.step.idx++
+	if p.step.idx < len(p.step.cmd.Args) {
+		p.enterArg()
+		return nil
+	}
+	return p.run(p.step.cmd, p.step.values)
+}
+
+// enterArg prepares the search row for the current argument, restoring the
+// value it had if the user came back to it.
+func (p *CommandPalette) enterArg() {
+	a := p.step.arg()
+	prev, seen := p.step.values[a.Key]
+	if !seen {
+		prev = a.Default
+	}
+	query := prev
+	switch a.Kind {
+	case ArgEnum:
+		query = ""
+	case ArgPath:
+		if !seen && query == "" && a.Dir != "" {
+			query = strings.TrimSuffix(a.Dir, string(filepath.Separator)) + string(filepath.Separator)
+		}
+	}
+	p.setQuery(query)
+	if a.Kind == ArgEnum {
+		for i, m := range p.filtered {
+			if m.value == prev {
+				p.selected = i
+			}
+		}
+	}
+}
+
+// back leaves the current argument or sub-menu. It reports false at the root.
+func (p *CommandPalette) back() bool {
+	switch {
+	case p.step != nil && p.step.idx > 0:
+		p.step.idx--
+		p.enterArg()
+	case p.step != nil:
+		p.step = nil
+		p.setQuery("")
+	case len(p.menus) > 0:
+		p.menus = p.menus[:len(p.menus)-1]
+		p.setQuery("")
+	default:
+		return false
+	}
+	return true
+}
+
+// setQuery replaces the search text and placeholder for the current step.
+func (p *CommandPalette) setQuery(q string) {
+	p.search.Placeholder = "Search"
+	if p.step != nil {
+		p.search.Placeholder = p.step.arg().placeholder()
+	}
+	p.search.SetValue(q)
+	p.search.CursorEnd()
+	p.selected = 0
+	p.errText = ""
+	p.refilter()
+}
+
+// run records the command and the menus leading to it, then performs it.
+// The palette goes back to the root: it is drawn again before the command's
+// message arrives, and stays open under a dialog the command opens. The
+// history is saved by the returned command, off the update loop.
+func (p *CommandPalette) run(c Command, args Args) tea.Cmd {
+	for _, m := range p.menus {
+		p.history.Record(m.cmd.key())
+	}
+	p.history.Record(c.key())
+	p.menus, p.step = nil, nil
+	p.setQuery("")
+
+	save := p.history.Save
+	action := c.Action
+	if c.Run != nil {
+		run := c.Run
+		action = func() tea.Msg { return run(args) }
+	}
+	return func() tea.Msg {
+		_ = save() // best-effort; a failed write only loses recents
+		if action == nil {
+			return Close()
+		}
+		msg := action()
+		if _, ok := msg.(OpenMsg); ok {
+			// Stack the new dialog on the palette so closing it returns here.
+			return msg
+		}
+		return CloseWith(msg)
+	}
+}
+
+// current is the command list being browsed: the innermost sub-menu or the
+// root.
+func (p *CommandPalette) current() []Command {
+	if n := len(p.menus); n > 0 {
+		return p.menus[n-1].cmd.Children
+	}
+	return p.commands
+}
+
+// crumbs is the trail shown above the search row inside a step.
+func (p *CommandPalette) crumbs() []string {
+	out := make([]string, 0, len(p.menus)+2)
+	for _, m := range p.menus {
+		out = append(out, m.cmd.Label)
+	}
+	if p.step != nil && p.step.idx < len(p.step.cmd.Args) {
+		out = append(out, p.step.cmd.Label, p.step.arg().label())
+	}
+	return out
+}
+
 func (p *CommandPalette) View() tea.View {
-	t := theme.CurrentTheme()
+	t := p.activeTheme()
 	contentWidth := maxv(24, p.width)
 
 	dbg := t.DialogBG()
@@ -115,48 +481,37 @@ func (p *CommandPalette) View() tea.View {
 
 	rows := make([]string, 0, 16)
 
+	// Breadcrumbs row, only inside a sub-menu or argument step
+	if crumbs := p.crumbs(); len(crumbs) > 0 {
+		trail := paletteClip(" "+strings.Join(crumbs, " › "), contentWidth)
+		rows = append(rows, baseRow(muted, trail))
+	}
+
 	// Search input row
 	inputContent := paletteClip(p.search.View(), maxv(1, contentWidth-2))
 	rows = append(rows, baseRow(dfg, " "+inputContent))
-	rows = append(rows, baseRow(muted, ""))
-
-	if len(p.filtered) == 0 {
-		rows = append(rows, baseRow(muted, "  No matching commands"))
+	if p.errText != "" {
+		rows = append(rows, baseRow(t.Error(), paletteClip("  "+p.errText, contentWidth)))
 	} else {
-		maxVisible := maxv(1, p.height-4)
-
-		type entry struct {
-			isGroup bool
-			label   string
-			keybind string
-			idx     int
-		}
-		entries := make([]entry, 0, len(p.filtered)+len(p.groups))
-		for _, g := range p.groups {
-			entries = append(entries, entry{isGroup: true, label: g, idx: -1})
-			for i, c := range p.filtered {
-				if c.Group == g {
-					entries = append(entries, entry{label: c.Label, keybind: c.Keybind, idx: i})
-				}
-			}
-		}
+		rows = append(rows, baseRow(muted, ""))
+	}
 
-		selectedEntry := 0
-		for i, e := range entries {
-			if !e.isGroup && e.idx == p.selected {
-				selectedEntry = i
-				break
+	if len(p.filtered) == 0 {
+		empty := "  No matching commands"
+		if p.step != nil {
+			switch p.step.arg().Kind {
+			case ArgText:
+				empty = ""
+			case ArgEnum:
+				empty = "  No matching options"
+			case ArgPath:
+				empty = "  No matching files — enter uses the typed path"
 			}
 		}
-
-		start := 0
-		if selectedEntry >= maxVisible {
-			start = selectedEntry - maxVisible + 1
-		}
-		end := minv(len(entries), start+maxVisible)
-
-		for i := start; i < end; i++ {
-			e := entries[i]
+		rows = append(rows, baseRow(muted, paletteClip(empty, contentWidth)))
+	} else {
+		entries, _ := p.listRows()
+		for _, e := range entries {
 			if e.isGroup {
 				groupStyle := lipgloss.NewStyle().
 					Background(dbg).
@@ -166,20 +521,102 @@ func (p *CommandPalette) View() tea.View {
 				rows = append(rows, groupStyle.Render(" "+e.label))
 				continue
 			}
+			c := p.filtered[e.idx]
 			selected := e.idx == p.selected
-			rows = append(rows, renderPaletteCommandRow(t, e.label, e.keybind, contentWidth, selected))
+			keybind := p.keybind(c.Command)
+			if keybind == "" && len(c.Children) > 0 {
+				keybind = "›"
+			}
+			rows = append(rows, renderPaletteCommandRow(t, c.Label, c.matched, keybind, contentWidth, selected))
 		}
 	}
 
 	// Navigation hint
+	hint := "  ↑↓ navigate  enter run  esc close"
+	switch {
+	case p.step != nil && p.step.arg().Kind == ArgText:
+		hint = "  enter accept  ⌫ back  esc close"
+	case p.step != nil && p.step.arg().Kind == ArgPath:
+		hint = "  ↑↓ choose  tab complete  enter select  ⌫ back"
+	case p.step != nil:
+		hint = "  ↑↓ choose  enter select  ⌫ back  esc close"
+	case len(p.menus) > 0:
+		hint = "  ↑↓ navigate  enter run  ⌫ back  esc close"
+	}
 	rows = append(rows,
 		baseRow(muted, ""),
-		baseRow(muted, "  ↑↓ navigate  enter run  esc close"),
+		baseRow(muted, paletteClip(hint, contentWidth)),
 	)
 
 	return tea.NewView(strings.Join(rows, "\n"))
 }
 
+// paletteEntry is one row of the list: a group heading, or the match at idx.
+type paletteEntry struct {
+	isGroup bool
+	label   string
+	idx     int
+}
+
+// listRows returns the list rows that fit, scrolled to keep the selection
+// in view, and the row of the palette the first one is drawn on.
+func (p *CommandPalette) listRows() ([]paletteEntry, int) {
+	top, chrome := 2, 4 // search and error rows above; blank and hint below
+	if len(p.crumbs()) > 0 {
+		top, chrome = top+1, chrome+1
+	}
+	maxVisible := maxv(1, p.height-chrome)
+
+	entries := make([]paletteEntry, 0, len(p.filtered)+len(p.groups))
+	if len(p.groups) == 0 {
+		// Ranked results are listed flat so the order is the ranking.
+		for i := range p.filtered {
+			entries = append(entries, paletteEntry{idx: i})
+		}
+	}
+	for _, g := range p.groups {
+		entries = append(entries, paletteEntry{isGroup: true, label: g, idx: -1})
+		for i, c := range p.filtered {
+			if c.section == g {
+				entries = append(entries, paletteEntry{idx: i})
+			}
+		}
+	}
+
+	selectedEntry := 0
+	for i, e := range entries {
+		if !e.isGroup && e.idx == p.selected {
+			selectedEntry = i
+			break
+		}
+	}
+
+	start := 0
+	if selectedEntry >= maxVisible {
+		start = selectedEntry - maxVisible + 1
+	}
+	end := minv(len(entries), start+maxVisible)
+	return entries[start:end], top
+}
+
+// click selects the command or option on the clicked row and chooses it,
+// as enter would.
+func (p *CommandPalette) click(msg tea.MouseClickMsg) tea.Cmd {
+	if msg.Button != tea.MouseLeft || len(p.filtered) == 0 || msg.X < 0 || msg.X >= maxv(24, p.width) {
+		return nil
+	}
+	entries, top := p.listRows()
+	i := msg.Y - top
+	if i < 0 || i >= len(entries) || entries[i].isGroup {
+		return nil
+	}
+	p.selected = entries[i].idx
+	if p.step != nil {
+		return p.chooseArg()
+	}
+	return p.choose()
+}
+
 func (p *CommandPalette) SetSize(width, height int) {
 	p.width = maxv(1, width)
 	p.height = maxv(1, height)
@@ -189,8 +626,22 @@ func (p *CommandPalette) SetSize(width, height int) {
 func (p *CommandPalette) GetSize() (int, int) { return p.width, p.height }
 func (p *CommandPalette) Title() string       { return "Commands" }
 
+// SetTheme updates the theme. The Manager calls it on open and on theme
+// changes.
+func (p *CommandPalette) SetTheme(t theme.Theme) {
+	p.theme = t
+	p.syncStyles()
+}
+
+func (p *CommandPalette) activeTheme() theme.Theme {
+	if p.theme != nil {
+		return p.theme
+	}
+	return theme.CurrentTheme()
+}
+
 func (p *CommandPalette) syncStyles() {
-	t := theme.CurrentTheme()
+	t := p.activeTheme()
 	s := textinput.DefaultStyles(true)
 	s.Focused.Prompt = lipgloss.NewStyle().Foreground(t.TextMuted())
 	s.Focused.Text = lipgloss.NewStyle().Foreground(t.DialogFG())
@@ -202,67 +653,167 @@ func (p *CommandPalette) syncStyles() {
 }
 
 func (p *CommandPalette) refilter() {
-	query := strings.ToLower(strings.TrimSpace(p.search.Value()))
-
-	next := make([]Command, 0, len(p.commands))
-	for _, c := range p.commands {
-		if query == "" ||
-			strings.Contains(strings.ToLower(c.Label), query) ||
-			strings.Contains(strings.ToLower(c.Group), query) {
-			next = append(next, c)
+	if p.step != nil {
+		p.refilterArg()
+		return
+	}
+	query := strings.TrimSpace(p.search.Value())
+
+	recent := make(map[string]int)
+	if query == "" && len(p.menus) == 0 {
+		for i, k := range p.history.Recent(p.recentLimit) {
+			recent[k] = i
 		}
 	}
-	p.filtered = next
 
-	seen := make(map[string]bool)
-	groups := make([]string, 0, 4)
-	for _, c := range p.filtered {
-		g := c.Group
-		if g == "" {
-			g = "Commands"
+	commands := p.current()
+	next := make([]paletteMatch, 0, len(commands))
+	for i, c := range commands {
+		if query == "" {
+			m := paletteMatch{Command: c, order: i, section: c.group()}
+			if r, ok := recent[c.key()]; ok {
+				// Each recent key is listed once, under Recent instead of its group.
+				delete(recent, c.key())
+				m.section, m.order = recentGroup, r-len(commands)
+			}
+			next = append(next, m)
+			continue
+		}
+		if m, ok := matchCommand(query, c); ok {
+			m.order = i
+			next = append(next, m)
 		}
-		if !seen[g] {
-			seen[g] = true
-			groups = append(groups, g)
+	}
+
+	p.groups = nil
+	if query == "" {
+		// Recent first, then groups in first-appearance order. Keep the list
+		// in display order so up/down walk the rows as drawn.
+		sort.SliceStable(next, func(a, b int) bool { return next[a].order < next[b].order })
+		rank := make(map[string]int)
+		for _, c := range next {
+			g := c.section
+			if _, ok := rank[g]; !ok {
+				rank[g] = len(p.groups)
+				p.groups = append(p.groups, g)
+			}
 		}
+		sort.SliceStable(next, func(a, b int) bool {
+			return rank[next[a].section] < rank[next[b].section]
+		})
+	} else {
+		sort.SliceStable(next, func(a, b int) bool {
+			x, y := next[a], next[b]
+			if x.tier != y.tier {
+				return x.tier < y.tier
+			}
+			if x.score != y.score {
+				return x.score > y.score
+			}
+			tx, ty := p.history.LastUsed(x.key()), p.history.LastUsed(y.key())
+			if !tx.Equal(ty) {
+				return tx.After(ty)
+			}
+			return x.order < y.order
+		})
 	}
-	p.groups = groups
+	p.filtered = next
 
 	if p.selected >= len(p.filtered) {
 		p.selected = maxv(0, len(p.filtered)-1)
 	}
 }
 
-func renderPaletteCommandRow(t theme.Theme, label, keybind string, width int, selected bool) string {
-	var itemBG, itemFG, keybindFG color.Color
+// refilterArg lists the choices for the current argument step, flat.
+func (p *CommandPalette) refilterArg() {
+	a := p.step.arg()
+	p.groups = nil
+	switch a.Kind {
+	case ArgEnum:
+		p.filtered = enumMatches(strings.TrimSpace(p.search.Value()), a.Options)
+	case ArgPath:
+		p.filtered = pathMatches(p.search.Value())
+	default:
+		p.filtered = nil
+	}
+	if p.selected >= len(p.filtered) {
+		p.selected = maxv(0, len(p.filtered)-1)
+	}
+}
+
+// matchCommand fuzzy-matches query against the label, then the aliases, then
+// the group, and reports the best field that matched.
+func matchCommand(query string, c Command) (paletteMatch, bool) {
+	if ms := fuzzy.Find(query, []string{c.Label}); len(ms) > 0 {
+		return paletteMatch{Command: c, tier: 0, score: ms[0].Score, matched: ms[0].MatchedIndexes}, true
+	}
+	if ms := fuzzy.Find(query, c.Aliases); len(ms) > 0 {
+		return paletteMatch{Command: c, tier: 1, score: ms[0].Score}, true
+	}
+	if ms := fuzzy.Find(query, []string{c.group()}); len(ms) > 0 {
+		return paletteMatch{Command: c, tier: 2, score: ms[0].Score}, true
+	}
+	return paletteMatch{}, false
+}
+
+// renderPaletteCommandRow draws one command. matched holds byte offsets of
+// label characters to highlight.
+func renderPaletteCommandRow(t theme.Theme, label string, matched []int, keybind string, width int, selected bool) string {
+	var itemBG, itemFG, keybindFG, matchFG color.Color
 	if selected {
 		itemBG = t.SelectionBG()
 		itemFG = t.SelectionFG()
 		keybindFG = t.SelectionFG()
+		matchFG = t.SelectionFG()
 	} else {
 		itemBG = t.DialogBG()
 		itemFG = t.DialogFG()
 		keybindFG = t.TextMuted()
+		matchFG = t.TextAccent()
 	}
 
-	if keybind == "" {
-		return lipgloss.NewStyle().Background(itemBG).Foreground(itemFG).Width(width).Render(" " + label)
+	labelMaxW := maxv(1, width-1)
+	if keybind != "" {
+		labelMaxW = maxv(1, width-lipgloss.Width(keybind)-3) // 1 lead + 2 sep
 	}
-
-	keybindW := lipgloss.Width(keybind)
-	labelMaxW := maxv(1, width-keybindW-3) // 1 lead + 2 sep
 	labelClipped := label
 	if lipgloss.Width(label) > labelMaxW {
-		labelClipped = paletteClip(label, labelMaxW)
+		labelClipped = ansi.Truncate(label, labelMaxW, "")
 	}
-
 	actualLabelW := lipgloss.Width(labelClipped)
-	gap := maxv(1, width-1-actualLabelW-2-keybindW)
-	line := " " + labelClipped + strings.Repeat(" ", gap) + "  " +
-		lipgloss.NewStyle().Foreground(keybindFG).Render(keybind)
+	labelView := highlightMatches(labelClipped, matched, lipgloss.NewStyle().
+		Background(itemBG).Foreground(matchFG).Bold(true).Underline(true))
+
+	line := " " + labelView
+	if keybind != "" {
+		gap := maxv(1, width-1-actualLabelW-2-lipgloss.Width(keybind))
+		line += strings.Repeat(" ", gap) + "  " +
+			lipgloss.NewStyle().Background(itemBG).Foreground(keybindFG).Render(keybind)
+	}
+	line = styles.Reopen(line, itemBG, itemFG)
 	return lipgloss.NewStyle().Background(itemBG).Foreground(itemFG).Width(width).Render(line)
 }
 
+// highlightMatches styles the runes of s that start at the given byte offsets.
+func highlightMatches(s string, offsets []int, style lipgloss.Style) string {
+	if len(offsets) == 0 {
+		return s
+	}
+	hit := make(map[int]bool, len(offsets))
+	for _, o := range offsets {
+		hit[o] = true
+	}
+	var b strings.Builder
+	for i, r := range s {
+		if hit[i] {
+			b.WriteString(style.Render(string(r)))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
 func paletteClip(s string, width int) string {
 	if width <= 0 {
 		return ""
@@ -0,0 +1,441 @@
+package dialog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func paletteLabels(p *CommandPalette) []string {
+	out := make([]string, len(p.filtered))
+	for i, m := range p.filtered {
+		out[i] = m.Label
+	}
+	return out
+}
+
+func TestPaletteTypesJAndK(t *testing.T) {
+	p := NewCommandPalette([]Command{{Label: "Jump to kitchen"}, {Label: "Other"}})
+	typeInto(p, "jk")
+	if got := p.search.Value(); got != "jk" {
+		t.Fatalf("search = %q, want j and k typed into the query", got)
+	}
+	if labels := paletteLabels(p); len(labels) != 1 || labels[0] != "Jump to kitchen" {
+		t.Fatalf("filtered = %v", labels)
+	}
+}
+
+func TestPaletteRanksLabelThenAliasThenGroup(t *testing.T) {
+	p := NewCommandPalette([]Command{
+		{Label: "Open settings", Group: "Theme"},
+		{Label: "Colors", Aliases: []string{"theme"}},
+		{Label: "Toggle theme"},
+		{Label: "Quit", Group: "App"},
+	})
+	typeInto(p, "theme")
+	want := "Toggle theme,Colors,Open settings"
+	if got := strings.Join(paletteLabels(p), ","); got != want {
+		t.Fatalf("ranking = %s, want %s", got, want)
+	}
+	if p.selected != 0 {
+		t.Fatal("typing should select the best match")
+	}
+}
+
+func TestPaletteFuzzyMatchesScatteredLetters(t *testing.T) {
+	p := NewCommandPalette([]Command{
+		{Label: "Toggle compact table"},
+		{Label: "Pulse progress"},
+	})
+	typeInto(p, "tct")
+	if labels := paletteLabels(p); len(labels) != 1 || labels[0] != "Toggle compact table" {
+		t.Fatalf("filtered = %v", labels)
+	}
+}
+
+func TestPaletteBreaksTiesByRecency(t *testing.T) {
+	h := NewHistory()
+	clock := time.Unix(0, 0)
+	h.now = func() time.Time { clock = clock.Add(time.Minute); return clock }
+	h.Record("Switch to dusk")
+	h.Record("Switch to nord")
+
+	p := NewCommandPalette([]Command{
+		{Label: "Switch to dusk"},
+		{Label: "Switch to nord"},
+		{Label: "Switch to rose"},
+	})
+	p.SetHistory(h)
+	typeInto(p, "switch")
+	want := "Switch to nord,Switch to dusk,Switch to rose"
+	if got := strings.Join(paletteLabels(p), ","); got != want {
+		t.Fatalf("ranking = %s, want %s", got, want)
+	}
+}
+
+func TestPaletteRecordsRunCommands(t *testing.T) {
+	h := NewHistory()
+	p := NewCommandPalette([]Command{{ID: "app.quit", Label: "Quit", Action: func() tea.Msg { return nil }}})
+	p.SetHistory(h)
+	feed(p, press("enter"))
+	if h.LastUsed("app.quit").IsZero() {
+		t.Fatal("running a command should record it by ID")
+	}
+}
+
+func TestPaletteSavesHistoryOutsideUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "palette_history.json")
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := NewCommandPalette([]Command{{Label: "Quit"}})
+	p.SetHistory(h)
+	_, cmd := p.Update(press("enter"))
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Update should not write the history, stat err = %v", err)
+	}
+	cmd()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("the run command should save the history: %v", err)
+	}
+}
+
+func TestPaletteHighlightsMatches(t *testing.T) {
+	p := NewCommandPalette([]Command{{Label: "Pulse progress"}, {Label: "Quit"}})
+	p.SetSize(40, 10)
+	typeInto(p, "pp")
+	frame := testkit.FrameOf(p.View())
+
+	row := -1
+	for y, line := range frame.Lines() {
+		if strings.Contains(line, "Pulse progress") {
+			row = y
+		}
+	}
+	if row < 0 {
+		t.Fatalf("match not rendered:\n%s", frame.Plain())
+	}
+	x := strings.Index(frame.Lines()[row], "Pulse")
+	underlined := func(x int) bool { return frame.Cell(x, row).Style.Underline != uv.UnderlineNone }
+	if !underlined(x) || underlined(x+1) || !underlined(x+len("Pulse ")) {
+		t.Fatalf("expected P and p highlighted in %q", frame.Lines()[row])
+	}
+	if frame.Width() != 40 {
+		t.Fatalf("row width %d, want 40", frame.Width())
+	}
+}
+
+func TestPaletteListsEmptyGroupCommands(t *testing.T) {
+	p := NewCommandPalette([]Command{{Label: "Ungrouped"}, {Label: "Grouped", Group: "G"}})
+	p.SetSize(40, 10)
+	if plain := testkit.FrameOf(p.View()).Plain(); !strings.Contains(plain, "Ungrouped") {
+		t.Fatalf("commands without a group should be listed under Commands:\n%s", plain)
+	}
+}
+
+func TestPaletteShowsRecentGroupFirst(t *testing.T) {
+	h := NewHistory()
+	fakeClock(h)
+	h.Record("Deploy")
+	h.Record("Deploy")
+	h.Record("Logs")
+
+	p := NewCommandPalette([]Command{
+		{Label: "Quit", Group: "App"},
+		{Label: "Logs", Group: "Ops"},
+		{Label: "Deploy", Group: "Ops"},
+	})
+	p.SetHistory(h)
+	p.SetSize(40, 20)
+
+	if got := strings.Join(paletteLabels(p), ","); got != "Deploy,Logs,Quit" {
+		t.Fatalf("order = %s, want recents first", got)
+	}
+	plain := testkit.FrameOf(p.View()).Plain()
+	if strings.Index(plain, "Recent") > strings.Index(plain, "App") || strings.Contains(plain, "Ops") {
+		t.Fatalf("Recent should lead and empty groups should be dropped:\n%s", plain)
+	}
+	if strings.Count(plain, "Deploy") != 1 {
+		t.Fatalf("recent commands should not be listed twice:\n%s", plain)
+	}
+
+	p.SetRecentLimit(0)
+	if plain := testkit.FrameOf(p.View()).Plain(); strings.Contains(plain, "Recent") {
+		t.Fatalf("limit 0 should hide the Recent group:\n%s", plain)
+	}
+}
+
+type deployMsg struct{ Args Args }
+
+// runResult unwraps the action message a palette closes with.
+func runResult(t *testing.T, msg tea.Msg) tea.Msg {
+	t.Helper()
+	c, ok := msg.(CloseMsg)
+	if !ok {
+		t.Fatalf("got %T, want CloseMsg", msg)
+	}
+	return c.Result
+}
+
+func deployPalette() *CommandPalette {
+	return NewCommandPalette([]Command{
+		{Label: "Quit"},
+		{Label: "Deploy", Args: []Arg{
+			{Key: "env", Label: "Environment", Kind: ArgEnum, Options: []ChoiceOption{
+				{Label: "Staging", Value: "stg"}, {Label: "Production", Value: "prod"},
+			}},
+			{Key: "note", Label: "Note", Required: true},
+		}, Run: func(a Args) tea.Msg { return deployMsg{a} }},
+	})
+}
+
+func TestPaletteCollectsArgs(t *testing.T) {
+	p := deployPalette()
+	p.SetSize(50, 12)
+	typeInto(p, "deploy")
+	feed(p, press("enter"))
+
+	plain := testkit.FrameOf(p.View()).Plain()
+	if !strings.Contains(plain, "Deploy › Environment") || !strings.Contains(plain, "Production") {
+		t.Fatalf("expected the Environment step:\n%s", plain)
+	}
+
+	typeInto(p, "prod")
+	feed(p, press("enter"))
+	if msg := feed(p, press("enter")); msg != nil {
+		t.Fatalf("empty required note ran the command: %v", msg)
+	}
+	if plain := testkit.FrameOf(p.View()).Plain(); !strings.Contains(plain, "Note is required") {
+		t.Fatalf("expected a required error:\n%s", plain)
+	}
+
+	typeInto(p, "hotfix")
+	got, ok := runResult(t, feed(p, press("enter"))).(deployMsg)
+	if !ok || got.Args.Get("env") != "prod" || got.Args.Get("note") != "hotfix" {
+		t.Fatalf("Run got %+v", got)
+	}
+}
+
+func TestPaletteDrawsRightAfterTheLastArg(t *testing.T) {
+	p := deployPalette()
+	p.SetSize(50, 12)
+	typeInto(p, "deploy")
+	feed(p, press("enter"))
+	feed(p, press("enter"))
+	typeInto(p, "hotfix")
+	_, cmd := p.Update(press("enter"))
+
+	// The screen is drawn before the command's message comes back.
+	plain := testkit.FrameOf(p.View()).Plain()
+	if strings.Contains(plain, "›") || !strings.Contains(plain, "Quit") {
+		t.Fatalf("the palette should be back at the root:\n%s", plain)
+	}
+	if got, ok := runResult(t, cmd()).(deployMsg); !ok || got.Args.Get("note") != "hotfix" {
+		t.Fatalf("Run got %+v", got)
+	}
+}
+
+func TestPaletteStaysUsableUnderAnOpenedDialog(t *testing.T) {
+	p := NewCommandPalette([]Command{{Label: "Rename", Args: []Arg{{Key: "name"}},
+		Run: func(a Args) tea.Msg { return Open(NewPrompt("confirm", a.Get("name"))) }}})
+	p.SetSize(50, 12)
+	feed(p, press("enter"))
+	typeInto(p, "x")
+	if _, ok := feed(p, press("enter")).(OpenMsg); !ok {
+		t.Fatal("a command that opens a dialog should stack it on the palette")
+	}
+	// Closing the new dialog reveals the palette again.
+	if plain := testkit.FrameOf(p.View()).Plain(); !strings.Contains(plain, "Rename") {
+		t.Fatalf("expected the command list:\n%s", plain)
+	}
+}
+
+func TestPaletteBackspaceGoesUp(t *testing.T) {
+	p := deployPalette()
+	p.SetSize(50, 12)
+	typeInto(p, "deploy")
+	feed(p, press("enter"))
+	feed(p, press("down"))
+	feed(p, press("enter"))
+	if p.step == nil || p.step.idx != 1 {
+		t.Fatal("expected the Note step")
+	}
+
+	typeInto(p, "x")
+	feed(p, press("backspace"))
+	if p.step.idx != 1 || p.search.Value() != "" {
+		t.Fatal("backspace should edit the query before going up")
+	}
+	feed(p, press("backspace"))
+	if p.step.idx != 0 || p.filtered[p.selected].value != "prod" {
+		t.Fatal("going back should restore the previous choice")
+	}
+	feed(p, press("backspace"))
+	if p.step != nil || len(p.filtered) != 2 {
+		t.Fatal("backspace on the first argument should return to the commands")
+	}
+	if plain := testkit.FrameOf(p.View()).Plain(); strings.Contains(plain, "›") {
+		t.Fatalf("breadcrumbs should be gone at the root:\n%s", plain)
+	}
+}
+
+func TestPaletteSubMenus(t *testing.T) {
+	var ran []string
+	leaf := func(name string) Command {
+		return Command{Label: name, Action: func() tea.Msg { ran = append(ran, name); return nil }}
+	}
+	h := NewHistory()
+	p := NewCommandPalette([]Command{
+		{Label: "Git", Children: []Command{
+			leaf("Pull"),
+			{Label: "Branch", Children: []Command{leaf("Create"), leaf("Delete")}},
+		}},
+	})
+	p.SetHistory(h)
+	p.SetSize(50, 12)
+
+	feed(p, press("enter"))
+	typeInto(p, "branch")
+	feed(p, press("enter"))
+	if plain := testkit.FrameOf(p.View()).Plain(); !strings.Contains(plain, "Git › Branch") || !strings.Contains(plain, "Delete") {
+		t.Fatalf("expected the nested menu:\n%s", plain)
+	}
+
+	feed(p, press("backspace"))
+	if plain := testkit.FrameOf(p.View()).Plain(); !strings.Contains(plain, "Pull") || strings.Contains(plain, "Git › Branch") {
+		t.Fatalf("backspace should return to Git:\n%s", plain)
+	}
+
+	typeInto(p, "branch")
+	feed(p, press("enter"))
+	feed(p, press("down"))
+	runResult(t, feed(p, press("enter")))
+	if len(ran) != 1 || ran[0] != "Delete" {
+		t.Fatalf("ran %v", ran)
+	}
+	if h.LastUsed("Git").IsZero() || h.LastUsed("Delete").IsZero() {
+		t.Fatal("running a nested command should record its menus too")
+	}
+}
+
+func TestPalettePathArg(t *testing.T) {
+	root := t.TempDir()
+	sep := string(filepath.Separator)
+	if err := os.Mkdir(filepath.Join(root, "logs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"logs/app.log", "main.go", "go.mod"} {
+		if err := os.WriteFile(filepath.Join(root, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	open := func() *CommandPalette {
+		p := NewCommandPalette([]Command{{Label: "Open", Args: []Arg{
+			{Key: "path", Label: "File", Kind: ArgPath, Dir: root},
+		}, Run: func(a Args) tea.Msg { return a.Get("path") }}})
+		p.SetSize(60, 12)
+		feed(p, press("enter"))
+		return p
+	}
+
+	p := open()
+	if p.search.Value() != root+sep || paletteLabels(p)[1] != "logs"+sep {
+		t.Fatalf("expected %s listed folders first, got %q %v", root, p.search.Value(), paletteLabels(p))
+	}
+	typeInto(p, "mgo")
+	if got := runResult(t, feed(p, press("enter"))); got != filepath.Join(root, "main.go") {
+		t.Fatalf("picked %v", got)
+	}
+
+	p = open()
+	typeInto(p, "logs")
+	feed(p, press("enter"))
+	if p.search.Value() != filepath.Join(root, "logs")+sep {
+		t.Fatalf("enter on a folder should descend, query %q", p.search.Value())
+	}
+	if got := runResult(t, feed(p, press("enter"))); got != filepath.Join(root, "logs") {
+		t.Fatalf("\".\" should pick the folder, got %v", got)
+	}
+}
+
+func TestPaletteKeymap(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(keymap.Global, keymap.Binding{Action: "app.quit", Keys: []string{"q"}, Help: "quit"})
+	r.Register(PaletteScope, keymap.Binding{Action: ActionPaletteDown, Keys: []string{"tab"}})
+
+	p := NewCommandPalette([]Command{{Label: "Reload"}, {ID: "app.quit", Label: "Quit"}})
+	p.SetKeymap(r)
+	p.SetSize(40, 10)
+
+	lines := testkit.FrameOf(p.View()).Lines()
+	var quit string
+	for _, l := range lines {
+		if strings.Contains(l, "Quit") {
+			quit = l
+		}
+	}
+	if !strings.HasSuffix(strings.TrimRight(ansi.Strip(quit), " "), "q") {
+		t.Fatalf("Quit should show its keymap binding, got %q", ansi.Strip(quit))
+	}
+
+	feed(p, press("tab"))
+	if p.selected != 1 {
+		t.Fatal("the registry's palette.down key should move the selection")
+	}
+	feed(p, tea.KeyPressMsg{Code: tea.KeyDown})
+	if p.selected != 1 {
+		t.Fatal("down was remapped away")
+	}
+}
+
+func TestPaletteClickRunsRow(t *testing.T) {
+	type pulsed struct{}
+	p := NewCommandPalette([]Command{
+		{Label: "Reload", Group: "App"},
+		{Label: "Pulse", Group: "View", Action: func() tea.Msg { return pulsed{} }},
+	})
+	m := New()
+	m.SetSize(80, 24)
+	m.Update(Open(Custom{DialogTitle: "Commands", Content: p, Width: 40, Height: 14}))
+
+	// Find the row on screen, as the app draws the dialog centered.
+	lines := testkit.FrameOf(m.View()).Lines()
+	x0, y0 := (80-ansi.StringWidth(lines[0]))/2, (24-len(lines))/2
+	click := tea.MouseClickMsg{Button: tea.MouseLeft}
+	for y, l := range lines {
+		if i := strings.Index(l, "Pulse"); i >= 0 {
+			click.X, click.Y = x0+ansi.StringWidth(l[:i]), y0+y
+		}
+	}
+	if click.Y == 0 {
+		t.Fatalf("Pulse row missing:\n%s", strings.Join(lines, "\n"))
+	}
+
+	_, cmd := m.Update(click)
+	if cmd == nil {
+		t.Fatal("clicking a command row should run it")
+	}
+	if res, ok := cmd().(CloseMsg); !ok || res.Result != (pulsed{}) {
+		t.Fatalf("click emitted %#v", cmd())
+	}
+
+	p.selected = 0
+	click.Y-- // the View group heading
+	if _, cmd := m.Update(click); cmd != nil || p.selected != 0 {
+		t.Fatal("clicking a group heading should do nothing")
+	}
+	m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if p.selected != 1 {
+		t.Fatal("the wheel should move the selection")
+	}
+}
@@ -13,9 +13,14 @@ import (
 	"image/color"
 	"strings"
 
+	bubbleskey "charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/cloudboy-jh/bentotui/focus"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
 )
 
 // Dialog is implemented by any modal that the Manager can host.
@@ -25,27 +30,88 @@ type Dialog interface {
 	Title() string
 }
 
-// OpenMsg signals the Manager to open a dialog.
+// OpenMsg signals the Manager to open a dialog. The dialog is pushed on top of
+// any dialog that is already open.
 type OpenMsg struct{ Dialog Dialog }
 
-// CloseMsg signals the Manager to close the active dialog.
-type CloseMsg struct{}
+// CloseMsg signals the Manager to close the active dialog and return to the
+// one beneath it. A non-nil Result is emitted after the pop, so it reaches the
+// revealed dialog (or the app, when the stack is empty).
+type CloseMsg struct{ Result tea.Msg }
+
+// CloseAllMsg signals the Manager to close every open dialog.
+type CloseAllMsg struct{}
 
 func Open(dialog Dialog) tea.Msg { return OpenMsg{Dialog: dialog} }
 func Close() tea.Msg             { return CloseMsg{} }
+func CloseAll() tea.Msg          { return CloseAllMsg{} }
+
+// CloseWith closes the active dialog and delivers result to whatever is
+// underneath it.
+func CloseWith(result tea.Msg) tea.Msg { return CloseMsg{Result: result} }
+
+// ResultMsg is what Prompt and Choice close with. ID is the id the dialog was
+// created with, so one handler can tell several questions apart. Value holds
+// a string for Prompt and single-select Choice, and a []string for
+// multi-select Choice. Canceled is set when the user pressed esc.
+type ResultMsg struct {
+	ID       string
+	Value    any
+	Canceled bool
+}
+
+// AsString returns Value as a string, if it is one.
+func (r ResultMsg) AsString() (string, bool) {
+	s, ok := r.Value.(string)
+	return s, ok
+}
+
+// AsStrings returns Value as a []string, if it is one.
+func (r ResultMsg) AsStrings() ([]string, bool) {
+	s, ok := r.Value.([]string)
+	return s, ok
+}
 
-// Manager hosts zero or one active Dialog.
+// Manager hosts a stack of dialogs. Only the top one is rendered and receives
+// input; the ones beneath keep their state until they are revealed again.
 type Manager struct {
-	active Dialog
+	stack  []Dialog
 	width  int
 	height int
-	theme  theme.Theme // nil = use theme.CurrentTheme()
+	keymap *keymap.Registry // nil = dialogs keep their own keys
+	theme  theme.Theme      // nil = use theme.CurrentTheme()
+	origin [2]int           // top-left cell of the active dialog as last drawn
+	placed bool             // origin is current; cleared when the stack or size changes
 }
 
 func New() *Manager { return &Manager{} }
 
-// SetTheme updates the theme. Call on ThemeChangedMsg.
-func (m *Manager) SetTheme(t theme.Theme) { m.theme = t }
+// SetKeymap registers the default keys of every dialog in this package, so
+// a keymap file can remap them before any dialog opens, and hands r to each
+// dialog as it is opened and to the ones already open.
+func (m *Manager) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(ConfirmScope, DefaultConfirmKeyMap().Bindings()...)
+		r.Register(PromptScope, DefaultPromptKeyMap().Bindings()...)
+		r.Register(ChoiceScope, DefaultChoiceKeyMap().Bindings()...)
+		r.Register(FormScope, DefaultFormKeyMap().Bindings()...)
+		r.Register(ThemePickerScope, DefaultThemePickerKeyMap().Bindings()...)
+		r.Register(PaletteScope, DefaultPaletteKeyMap().Bindings()...)
+	}
+	m.keymap = r
+	for i, d := range m.stack {
+		m.stack[i] = keymapDialog(d, r)
+	}
+}
+
+// SetTheme updates the theme of the Manager and every stacked dialog. Call on
+// ThemeChangedMsg.
+func (m *Manager) SetTheme(t theme.Theme) {
+	m.theme = t
+	for i, d := range m.stack {
+		m.stack[i] = themeDialog(d, t)
+	}
+}
 
 func (m *Manager) activeTheme() theme.Theme {
 	if m.theme != nil {
@@ -59,100 +125,358 @@ func (m *Manager) Init() tea.Cmd { return nil }
 func (m *Manager) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch v := msg.(type) {
 	case OpenMsg:
-		m.active = v.Dialog
-		if m.active != nil {
-			m.active = resizeDialog(m.active, m.width, m.height)
+		if v.Dialog != nil {
+			d := themeDialog(resizeDialog(v.Dialog, m.width, m.height), m.theme)
+			m.stack = append(m.stack, keymapDialog(d, m.keymap))
+			m.placed = false
 		}
 		return m, nil
 	case CloseMsg:
-		m.active = nil
+		m.pop()
+		if v.Result == nil {
+			return m, nil
+		}
+		return m, func() tea.Msg { return v.Result }
+	case CloseAllMsg:
+		m.stack, m.placed = nil, false
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.SetSize(v.Width, v.Height)
 		return m, nil
 	}
 
-	if m.active != nil {
-		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			switch keyMsg.String() {
-			case "esc":
-				switch m.active.(type) {
-				case Confirm, *Confirm:
-					m.active = nil
-					return m, nil
-				}
-			case "enter":
-				switch active := m.active.(type) {
-				case Confirm:
-					m.active = nil
-					if active.OnConfirm != nil {
-						return m, func() tea.Msg { return active.OnConfirm() }
-					}
-					return m, nil
-				case *Confirm:
-					m.active = nil
-					if active != nil && active.OnConfirm != nil {
-						return m, func() tea.Msg { return active.OnConfirm() }
-					}
-					return m, nil
-				}
-			}
-		}
-	}
-
-	if m.active == nil {
+	active := m.Active()
+	if active == nil {
 		return m, nil
 	}
-	updated, cmd := m.active.Update(msg)
+	if mouse, ok := msg.(tea.MouseMsg); ok {
+		msg = m.local(active, mouse)
+	}
+
+	updated, cmd := active.Update(msg)
 	if next, ok := updated.(Dialog); ok {
-		m.active = next
+		m.stack[len(m.stack)-1] = next
 	}
 	return m, cmd
 }
 
 func (m *Manager) View() tea.View {
-	if m.active == nil {
+	active := m.Active()
+	if active == nil {
 		return tea.NewView("")
 	}
-	return tea.NewView(viewString(m.active.View()))
+	view := viewString(active.View())
+	m.place(view)
+	return tea.NewView(view)
+}
+
+// place records where View's caller draws view: centered, the way
+// surface.DrawCenter does.
+func (m *Manager) place(view string) {
+	m.origin = [2]int{
+		max(0, (m.width-lipgloss.Width(view))/2),
+		max(0, (m.height-lipgloss.Height(view))/2),
+	}
+	m.placed = true
+}
+
+// local moves a screen mouse event into d's frame, at the origin of the
+// last View. d is only rendered when the stack changed since.
+func (m *Manager) local(d Dialog, msg tea.MouseMsg) tea.MouseMsg {
+	if !m.placed {
+		m.place(viewString(d.View()))
+	}
+	return focus.Translate(msg, -m.origin[0], -m.origin[1])
 }
 
 func (m *Manager) SetSize(width, height int) {
 	m.width = width
 	m.height = height
-	if m.active != nil {
-		m.active = resizeDialog(m.active, width, height)
+	m.placed = false
+	for i, d := range m.stack {
+		m.stack[i] = resizeDialog(d, width, height)
+	}
+}
+
+func (m *Manager) IsOpen() bool { return len(m.stack) > 0 }
+
+// Depth reports how many dialogs are open.
+func (m *Manager) Depth() int { return len(m.stack) }
+
+// Active returns the top dialog, or nil when none is open.
+func (m *Manager) Active() Dialog {
+	if len(m.stack) == 0 {
+		return nil
 	}
+	return m.stack[len(m.stack)-1]
 }
 
-func (m *Manager) IsOpen() bool { return m.active != nil }
+func (m *Manager) pop() {
+	if len(m.stack) == 0 {
+		return
+	}
+	m.placed = false
+	m.stack[len(m.stack)-1] = nil
+	m.stack = m.stack[:len(m.stack)-1]
+}
 
 // ── Confirm ───────────────────────────────────────────────────────────────────
 
+type ConfirmKeyMap struct {
+	Switch       bubbleskey.Binding
+	FocusCancel  bubbleskey.Binding // ignored while RequireText is being typed
+	FocusConfirm bubbleskey.Binding // ignored while RequireText is being typed
+	Press        bubbleskey.Binding
+	Close        bubbleskey.Binding
+}
+
+func DefaultConfirmKeyMap() ConfirmKeyMap {
+	return ConfirmKeyMap{
+		Switch:       bubbleskey.NewBinding(bubbleskey.WithKeys("left", "right", "tab", "shift+tab"), bubbleskey.WithHelp("←→", "choose")),
+		FocusCancel:  bubbleskey.NewBinding(bubbleskey.WithKeys("h"), bubbleskey.WithHelp("h", "cancel button")),
+		FocusConfirm: bubbleskey.NewBinding(bubbleskey.WithKeys("l"), bubbleskey.WithHelp("l", "confirm button")),
+		Press:        bubbleskey.NewBinding(bubbleskey.WithKeys("enter"), bubbleskey.WithHelp("enter", "select")),
+		Close:        bubbleskey.NewBinding(bubbleskey.WithKeys("esc"), bubbleskey.WithHelp("esc", "cancel")),
+	}
+}
+
+// Scope and action IDs the Confirm bindings register under in a
+// keymap.Registry.
+const (
+	ConfirmScope              keymap.Scope = "confirm"
+	ActionConfirmSwitch                    = "confirm.switch"
+	ActionConfirmFocusCancel               = "confirm.focuscancel"
+	ActionConfirmFocusConfirm              = "confirm.focusconfirm"
+	ActionConfirmPress                     = "confirm.press"
+	ActionConfirmClose                     = "confirm.close"
+)
+
+// Bindings returns km as keymap bindings.
+func (km ConfirmKeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionConfirmSwitch, km.Switch),
+		keymap.FromKey(ActionConfirmFocusCancel, km.FocusCancel),
+		keymap.FromKey(ActionConfirmFocusConfirm, km.FocusConfirm),
+		keymap.FromKey(ActionConfirmPress, km.Press),
+		keymap.FromKey(ActionConfirmClose, km.Close),
+	}
+}
+
+// Confirm asks a yes/no question with two buttons. Left/right (or tab) move
+// between them, enter presses the focused one and esc cancels. Destructive
+// styles the confirm button with Error colors and focuses Cancel first.
+// RequireText keeps the confirm button disabled until that exact text is
+// typed, for "type the name to delete" checks.
 type Confirm struct {
-	DialogTitle string
-	Message     string
-	OnConfirm   func() tea.Msg
-	width       int
-	height      int
+	DialogTitle  string
+	Message      string
+	ConfirmLabel string // default "Confirm"
+	CancelLabel  string // default "Cancel"
+	Destructive  bool
+	RequireText  string
+	OnConfirm    func() tea.Msg
+	OnCancel     func() tea.Msg
+	width        int
+	height       int
+	focus        confirmFocus
+	input        *textinput.Model
+	keymap       *keymap.Registry // nil = DefaultConfirmKeyMap(); set by Manager
+	theme        theme.Theme      // nil = use theme.CurrentTheme(); set by Manager
 }
 
-func (c Confirm) Init() tea.Cmd                           { return nil }
-func (c Confirm) Update(msg tea.Msg) (tea.Model, tea.Cmd) { return c, nil }
+type confirmFocus int
+
+const (
+	focusDefault confirmFocus = iota
+	focusConfirm
+	focusCancel
+)
+
+func (c Confirm) Init() tea.Cmd { return nil }
+
+func (c Confirm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return c, nil
+	}
+	c = c.prepared()
+
+	keys := c.keyMap()
+	switch {
+	case bubbleskey.Matches(keyMsg, keys.Close):
+		return c, closeWith(c.OnCancel)
+	case bubbleskey.Matches(keyMsg, keys.Press):
+		if !c.confirmFocused() {
+			return c, closeWith(c.OnCancel)
+		}
+		if !c.unlocked() {
+			return c, nil
+		}
+		return c, closeWith(c.OnConfirm)
+	case bubbleskey.Matches(keyMsg, keys.Switch):
+		if c.confirmFocused() {
+			c.focus = focusCancel
+		} else {
+			c.focus = focusConfirm
+		}
+		return c, nil
+	case c.input == nil && bubbleskey.Matches(keyMsg, keys.FocusCancel):
+		c.focus = focusCancel
+		return c, nil
+	case c.input == nil && bubbleskey.Matches(keyMsg, keys.FocusConfirm):
+		c.focus = focusConfirm
+		return c, nil
+	}
+
+	if c.input == nil {
+		return c, nil
+	}
+	in, cmd := c.input.Update(keyMsg)
+	c.input = &in
+	return c, cmd
+}
 
 func (c Confirm) View() tea.View {
+	c = c.prepared()
+	t := c.activeTheme()
+	width := max(48, c.width/2)
+	inner := max(1, width-4)
+
 	text := c.Message
 	if strings.TrimSpace(text) == "" {
 		text = "Confirm?"
 	}
-	content := strings.Join([]string{text, "", "Enter confirm  Esc cancel"}, "\n")
-	view := renderDialogFrame(c.DialogTitle, content, max(48, c.width/2), 0, theme.CurrentTheme())
+	rows := strings.Split(text, "\n")
+	if c.input != nil {
+		c.syncInputStyles(t)
+		rows = append(rows, "",
+			dialogToneRow(t, t.TextMuted(), inner, "Type "+c.RequireText+" to confirm"),
+			paletteClip(c.input.View(), inner),
+		)
+	}
+	rows = append(rows, "", c.renderButtons(t, inner), "",
+		dialogToneRow(t, t.TextMuted(), inner, "←→ choose  enter select  esc cancel"))
+
+	view := renderDialogFrame(c.DialogTitle, strings.Join(rows, "\n"), width, len(rows)+4, t)
 	return tea.NewView(view)
 }
 
 func (c Confirm) SetSize(width, height int) { c.width = width; c.height = height }
 func (c Confirm) Title() string             { return c.DialogTitle }
 
+// SetKeymap reads the Confirm keys from r. The Manager calls it on open.
+func (c *Confirm) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(ConfirmScope, DefaultConfirmKeyMap().Bindings()...)
+	}
+	c.keymap = r
+}
+
+func (c Confirm) keyMap() ConfirmKeyMap {
+	r, k := c.keymap, DefaultConfirmKeyMap()
+	return ConfirmKeyMap{
+		Switch:       r.KeyOr(ActionConfirmSwitch, k.Switch),
+		FocusCancel:  r.KeyOr(ActionConfirmFocusCancel, k.FocusCancel),
+		FocusConfirm: r.KeyOr(ActionConfirmFocusConfirm, k.FocusConfirm),
+		Press:        r.KeyOr(ActionConfirmPress, k.Press),
+		Close:        r.KeyOr(ActionConfirmClose, k.Close),
+	}
+}
+
+func (c Confirm) activeTheme() theme.Theme {
+	if c.theme != nil {
+		return c.theme
+	}
+	return theme.CurrentTheme()
+}
+
+// prepared creates the confirmation input on first use.
+func (c Confirm) prepared() Confirm {
+	if c.RequireText == "" || c.input != nil {
+		return c
+	}
+	in := textinput.New()
+	in.Prompt = "> "
+	in.ShowSuggestions = false
+	in.Focus()
+	c.input = &in
+	return c
+}
+
+func (c Confirm) confirmFocused() bool {
+	switch c.focus {
+	case focusConfirm:
+		return true
+	case focusCancel:
+		return false
+	default:
+		return !c.Destructive
+	}
+}
+
+// unlocked reports whether the confirm button can be pressed.
+func (c Confirm) unlocked() bool {
+	return c.input == nil || c.input.Value() == c.RequireText
+}
+
+func (c Confirm) renderButtons(t theme.Theme, width int) string {
+	confirmLabel := c.ConfirmLabel
+	if confirmLabel == "" {
+		confirmLabel = "Confirm"
+	}
+	cancelLabel := c.CancelLabel
+	if cancelLabel == "" {
+		cancelLabel = "Cancel"
+	}
+
+	bg, fg := t.DialogBG(), t.DialogFG()
+	idle := lipgloss.NewStyle().Padding(0, 1).Background(t.BackgroundInteractive())
+	confirmFocused := c.confirmFocused()
+
+	cancelStyle := idle.Foreground(fg)
+	if !confirmFocused {
+		cancelStyle = cancelStyle.Bold(true).Background(t.SelectionBG()).Foreground(t.SelectionFG())
+	}
+
+	tone := t.TextAccent()
+	if c.Destructive {
+		tone = t.Error()
+	}
+	confirmStyle := idle.Foreground(tone)
+	switch {
+	case !c.unlocked():
+		confirmStyle = idle.Foreground(t.TextMuted()).Bold(confirmFocused)
+	case confirmFocused:
+		confirmStyle = confirmStyle.Bold(true).Background(tone).Foreground(t.TextInverse())
+	}
+
+	cancel := cancelStyle.Render(cancelLabel)
+	confirm := confirmStyle.Render(confirmLabel)
+	row := cancel + "  " + confirm
+	pad := max(0, width-lipgloss.Width(row))
+	return styles.Reopen(strings.Repeat(" ", pad)+row, bg, fg)
+}
+
+func (c Confirm) syncInputStyles(t theme.Theme) {
+	s := textinput.DefaultStyles(true)
+	s.Focused.Prompt = lipgloss.NewStyle().Background(t.DialogBG()).Foreground(t.TextAccent())
+	s.Focused.Text = lipgloss.NewStyle().Background(t.DialogBG()).Foreground(t.DialogFG())
+	s.Focused.Placeholder = lipgloss.NewStyle().Background(t.DialogBG()).Foreground(t.InputPlaceholder())
+	s.Blurred = s.Focused
+	s.Cursor.Color = t.InputCursor()
+	c.input.SetStyles(s)
+}
+
+// closeWith closes the active dialog and delivers fn's message, if any.
+func closeWith(fn func() tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		if fn == nil {
+			return Close()
+		}
+		return CloseWith(fn())
+	}
+}
+
 // ── Custom ────────────────────────────────────────────────────────────────────
 
 type Custom struct {
@@ -160,6 +484,7 @@ type Custom struct {
 	Content     tea.Model
 	Width       int
 	Height      int
+	theme       theme.Theme // nil = use theme.CurrentTheme(); set by Manager
 }
 
 func (c Custom) Init() tea.Cmd {
@@ -173,6 +498,10 @@ func (c Custom) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if c.Content == nil {
 		return c, nil
 	}
+	if mouse, ok := msg.(tea.MouseMsg); ok {
+		// Content starts below the header, inside the frame's padding.
+		msg = focus.Translate(mouse, -frameInsetX, -frameInsetY)
+	}
 	updated, cmd := c.Content.Update(msg)
 	c.Content = updated
 	return c, cmd
@@ -183,7 +512,11 @@ func (c Custom) View() tea.View {
 	if c.Content != nil {
 		body = viewString(c.Content.View())
 	}
-	view := renderDialogFrame(c.DialogTitle, body, max(50, c.Width), max(12, c.Height), theme.CurrentTheme())
+	t := c.theme
+	if t == nil {
+		t = theme.CurrentTheme()
+	}
+	view := renderDialogFrame(c.DialogTitle, body, max(50, c.Width), max(12, c.Height), t)
 	return tea.NewView(view)
 }
 
@@ -216,6 +549,73 @@ func resizeDialog(d Dialog, width, height int) Dialog {
 	}
 }
 
+// themeDialog hands the Manager theme to a dialog. A nil theme leaves the
+// dialog on theme.CurrentTheme(). Custom passes it on to its content.
+func themeDialog(d Dialog, t theme.Theme) Dialog {
+	switch v := d.(type) {
+	case Confirm:
+		v.theme = t
+		return v
+	case *Confirm:
+		if v != nil {
+			v.theme = t
+		}
+		return v
+	case Custom:
+		v.theme = t
+		themeContent(v.Content, t)
+		return v
+	case *Custom:
+		if v != nil {
+			v.theme = t
+			themeContent(v.Content, t)
+		}
+		return v
+	default:
+		themeContent(d, t)
+		return d
+	}
+}
+
+// keymapDialog hands the Manager keymap to a dialog. A nil registry leaves
+// the dialog's own keys alone. Custom passes it on to its content.
+func keymapDialog(d Dialog, r *keymap.Registry) Dialog {
+	if r == nil {
+		return d
+	}
+	switch v := d.(type) {
+	case Confirm:
+		v.SetKeymap(r)
+		return v
+	case Custom:
+		keymapContent(v.Content, r)
+		return v
+	case *Custom:
+		if v != nil {
+			keymapContent(v.Content, r)
+		}
+		return v
+	default:
+		keymapContent(d, r)
+		return d
+	}
+}
+
+func keymapContent(m tea.Model, r *keymap.Registry) {
+	if s, ok := m.(interface{ SetKeymap(*keymap.Registry) }); ok {
+		s.SetKeymap(r)
+	}
+}
+
+func themeContent(m tea.Model, t theme.Theme) {
+	if t == nil {
+		return
+	}
+	if s, ok := m.(interface{ SetTheme(theme.Theme) }); ok {
+		s.SetTheme(t)
+	}
+}
+
 func applyCustomSize(c *Custom, width, height int) {
 	if c == nil {
 		return
@@ -239,6 +639,13 @@ func applyCustomSize(c *Custom, width, height int) {
 
 // ── rendering ─────────────────────────────────────────────────────────────────
 
+// Where renderDialogFrame draws the content: after a blank row, the header
+// and another blank row, inside two columns of padding.
+const (
+	frameInsetX = 2
+	frameInsetY = 3
+)
+
 func renderDialogFrame(title, content string, width, height int, t theme.Theme) string {
 	bg := t.DialogBG()
 	fg := t.DialogFG()
@@ -262,7 +669,7 @@ func renderDialogFrame(title, content string, width, height int, t theme.Theme)
 		return base.
 			Foreground(rowFG).
 			PaddingLeft(2).PaddingRight(2).
-			Width(innerWidth).
+			Width(width).
 			Render(rowContent)
 	}
 	blankRow := base.Width(width).Render("")
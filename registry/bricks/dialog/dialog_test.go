@@ -0,0 +1,496 @@
+package dialog
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+	"github.com/cloudboy-jh/bentotui/theme"
+)
+
+// counter is a dialog whose state survives being covered by another dialog.
+type counter struct {
+	name  string
+	n     int
+	got   []tea.Msg
+	views int
+}
+
+func (c *counter) Init() tea.Cmd    { return nil }
+func (c *counter) View() tea.View   { c.views++; return tea.NewView(c.name) }
+func (c *counter) SetSize(int, int) {}
+func (c *counter) Title() string    { return c.name }
+func (c *counter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if k, ok := msg.(tea.KeyPressMsg); ok && k.String() == "+" {
+		c.n++
+		return c, nil
+	}
+	c.got = append(c.got, msg)
+	return c, nil
+}
+
+type doneMsg struct{ value string }
+
+func press(s string) tea.KeyPressMsg {
+	switch s {
+	case "enter":
+		return tea.KeyPressMsg{Code: tea.KeyEnter}
+	case "esc":
+		return tea.KeyPressMsg{Code: tea.KeyEscape}
+	case "tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab}
+	case "backspace":
+		return tea.KeyPressMsg{Code: tea.KeyBackspace}
+	}
+	return tea.KeyPressMsg{Code: rune(s[0]), Text: s}
+}
+
+// send applies msg and then feeds every message its commands produce back
+// into the manager, like the tea runtime would.
+func send(m *Manager, msg tea.Msg) []tea.Msg {
+	var out []tea.Msg
+	queue := []tea.Msg{msg}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		_, cmd := m.Update(next)
+		if cmd == nil {
+			continue
+		}
+		if produced := cmd(); produced != nil {
+			out = append(out, produced)
+			queue = append(queue, produced)
+		}
+	}
+	return out
+}
+
+func TestManagerStacksDialogs(t *testing.T) {
+	m := New()
+	m.SetSize(80, 24)
+	parent := &counter{name: "parent"}
+	child := &counter{name: "child"}
+
+	send(m, Open(parent))
+	send(m, press("+"))
+	send(m, Open(child))
+	if m.Depth() != 2 || m.Active() != Dialog(child) {
+		t.Fatalf("depth %d, active %v; want child on top of parent", m.Depth(), m.Active())
+	}
+	if got := viewString(m.View()); got != "child" {
+		t.Fatalf("View() = %q, want the top dialog", got)
+	}
+
+	send(m, press("+"))
+	send(m, Close())
+	if m.Active() != Dialog(parent) {
+		t.Fatalf("Close should reveal the parent, got %v", m.Active())
+	}
+	if parent.n != 1 || child.n != 1 {
+		t.Fatalf("keys leaked between dialogs: parent %d, child %d", parent.n, child.n)
+	}
+
+	send(m, Close())
+	if m.IsOpen() {
+		t.Fatal("manager should be empty after closing both dialogs")
+	}
+	send(m, Close())
+	if m.IsOpen() || m.Depth() != 0 {
+		t.Fatal("Close on an empty stack should be a no-op")
+	}
+}
+
+func TestManagerCloseWithDeliversResultToParent(t *testing.T) {
+	m := New()
+	parent := &counter{name: "parent"}
+	send(m, Open(parent))
+	send(m, Open(&counter{name: "child"}))
+
+	send(m, CloseWith(doneMsg{value: "yes"}))
+	if m.Active() != Dialog(parent) {
+		t.Fatalf("expected parent on top, got %v", m.Active())
+	}
+	if len(parent.got) != 1 || parent.got[0] != (doneMsg{value: "yes"}) {
+		t.Fatalf("parent received %v, want the child's result", parent.got)
+	}
+}
+
+func TestManagerCloseAll(t *testing.T) {
+	m := New()
+	send(m, Open(&counter{name: "a"}))
+	send(m, Open(&counter{name: "b"}))
+	send(m, Open(nil))
+	if m.Depth() != 2 {
+		t.Fatalf("Open(nil) should be ignored, depth %d", m.Depth())
+	}
+	send(m, CloseAll())
+	if m.IsOpen() {
+		t.Fatal("CloseAll should empty the stack")
+	}
+}
+
+func TestConfirmPopsBackToPalette(t *testing.T) {
+	m := New()
+	m.SetSize(80, 24)
+	palette := NewCommandPalette([]Command{{
+		Label: "Delete",
+		Action: func() tea.Msg {
+			return Open(Confirm{
+				DialogTitle: "Delete?",
+				OnConfirm:   func() tea.Msg { return doneMsg{value: "deleted"} },
+			})
+		},
+	}})
+	send(m, Open(Custom{DialogTitle: "Commands", Content: palette}))
+
+	send(m, press("enter"))
+	if m.Depth() != 2 {
+		t.Fatalf("palette action should stack the confirm, depth %d", m.Depth())
+	}
+	send(m, press("esc"))
+	if m.Depth() != 1 {
+		t.Fatalf("esc should close only the confirm, depth %d", m.Depth())
+	}
+
+	send(m, press("enter"))
+	out := send(m, press("enter"))
+	if m.Depth() != 1 {
+		t.Fatalf("confirming should return to the palette, depth %d", m.Depth())
+	}
+	if len(out) == 0 || out[len(out)-1] != (doneMsg{value: "deleted"}) {
+		t.Fatalf("confirm emitted %v, want its OnConfirm result last", out)
+	}
+}
+
+func TestPaletteClosesBeforePlainActions(t *testing.T) {
+	m := New()
+	palette := NewCommandPalette([]Command{{
+		Label:  "Run",
+		Action: func() tea.Msg { return doneMsg{value: "ran"} },
+	}})
+	send(m, Open(Custom{DialogTitle: "Commands", Content: palette}))
+
+	out := send(m, press("enter"))
+	if m.IsOpen() {
+		t.Fatal("palette should close when its action is not a dialog")
+	}
+	if len(out) != 2 || out[1] != (doneMsg{value: "ran"}) {
+		t.Fatalf("got %v, want CloseMsg followed by the action result", out)
+	}
+}
+
+// typeText drops the commands typing produces; they are cursor blink ticks.
+func typeText(m *Manager, s string) {
+	for _, r := range s {
+		m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+func lastResult(t *testing.T, out []tea.Msg) ResultMsg {
+	t.Helper()
+	for i := len(out) - 1; i >= 0; i-- {
+		if r, ok := out[i].(ResultMsg); ok {
+			return r
+		}
+	}
+	t.Fatalf("no ResultMsg in %v", out)
+	return ResultMsg{}
+}
+
+func TestPromptDefaultAndValidation(t *testing.T) {
+	m := New()
+	m.SetSize(80, 24)
+	p := NewPrompt("rename", "Rename")
+	p.SetDefault("draft")
+	p.SetValidate(func(s string) error {
+		if len(s) < 6 {
+			return fmt.Errorf("too short")
+		}
+		return nil
+	})
+	send(m, Open(p))
+
+	send(m, press("enter"))
+	if !m.IsOpen() {
+		t.Fatal("invalid input should keep the prompt open")
+	}
+	if !strings.Contains(ansi.Strip(viewString(m.View())), "too short") {
+		t.Fatal("validation error should be rendered")
+	}
+
+	typeText(m, "-v2")
+	r := lastResult(t, send(m, press("enter")))
+	if m.IsOpen() {
+		t.Fatal("valid input should close the prompt")
+	}
+	if v, ok := r.AsString(); r.ID != "rename" || !ok || v != "draft-v2" {
+		t.Fatalf("got %+v, want rename=draft-v2", r)
+	}
+}
+
+func TestPromptCancel(t *testing.T) {
+	m := New()
+	send(m, Open(NewPrompt("q", "Question")))
+	r := lastResult(t, send(m, press("esc")))
+	if m.IsOpen() || !r.Canceled || r.ID != "q" {
+		t.Fatalf("esc should close with a canceled result, got %+v", r)
+	}
+}
+
+func TestChoiceSingle(t *testing.T) {
+	m := New()
+	c := NewChoice("env", "Environment",
+		ChoiceOption{Label: "Development", Value: "dev"},
+		ChoiceOption{Label: "Production", Value: "prod"},
+	)
+	send(m, Open(c))
+	send(m, press("j"))
+	r := lastResult(t, send(m, press("enter")))
+	if v, ok := r.AsString(); !ok || v != "prod" {
+		t.Fatalf("got %+v, want prod", r)
+	}
+}
+
+func TestChoiceMulti(t *testing.T) {
+	m := New()
+	m.SetSize(80, 24)
+	c := NewChoice("tags", "Tags",
+		ChoiceOption{Label: "alpha"},
+		ChoiceOption{Label: "beta"},
+		ChoiceOption{Label: "gamma"},
+	)
+	c.SetMulti(true)
+	c.SetSelected("gamma")
+	send(m, Open(c))
+
+	send(m, press("home"))
+	send(m, tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	if !strings.Contains(ansi.Strip(viewString(m.View())), "[x] alpha") {
+		t.Fatal("space should check the highlighted option")
+	}
+	r := lastResult(t, send(m, press("enter")))
+	v, ok := r.AsStrings()
+	if !ok || strings.Join(v, ",") != "alpha,gamma" {
+		t.Fatalf("got %+v, want [alpha gamma] in list order", r)
+	}
+}
+
+func TestChoiceScrollsInShortScreens(t *testing.T) {
+	opts := make([]ChoiceOption, 30)
+	for i := range opts {
+		opts[i] = ChoiceOption{Label: fmt.Sprintf("option %02d", i)}
+	}
+	c := NewChoice("many", "Many", opts...)
+	c.SetSize(80, 20)
+	c.SetSelected("option 29")
+	view := viewString(c.View())
+	if h := lipgloss.Height(view); h > 16 {
+		t.Fatalf("dialog is %d rows, want it to fit a 20-row screen", h)
+	}
+	if !strings.Contains(ansi.Strip(view), "option 29") {
+		t.Fatal("the cursor row should stay visible")
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+func TestConfirmButtons(t *testing.T) {
+	m := New()
+	m.SetSize(100, 30)
+	send(m, Open(Confirm{
+		DialogTitle:  "Publish",
+		ConfirmLabel: "Publish",
+		CancelLabel:  "Not yet",
+		OnConfirm:    func() tea.Msg { return doneMsg{value: "published"} },
+		OnCancel:     func() tea.Msg { return doneMsg{value: "kept"} },
+	}))
+	plain := ansi.Strip(viewString(m.View()))
+	if !strings.Contains(plain, "Not yet") || !strings.Contains(plain, "Publish") {
+		t.Fatalf("custom labels missing:\n%s", plain)
+	}
+
+	// Confirm is focused first; moving left lands on Cancel.
+	send(m, tea.KeyPressMsg{Code: tea.KeyLeft})
+	out := send(m, press("enter"))
+	if m.IsOpen() || out[len(out)-1] != (doneMsg{value: "kept"}) {
+		t.Fatalf("enter on Cancel should run OnCancel, got %v", out)
+	}
+
+	send(m, Open(Confirm{OnCancel: func() tea.Msg { return doneMsg{value: "esc"} }}))
+	out = send(m, press("esc"))
+	if m.IsOpen() || out[len(out)-1] != (doneMsg{value: "esc"}) {
+		t.Fatalf("esc should run OnCancel, got %v", out)
+	}
+}
+
+func TestConfirmDestructiveFocusesCancel(t *testing.T) {
+	m := New()
+	confirmed := false
+	send(m, Open(Confirm{
+		Destructive: true,
+		OnConfirm:   func() tea.Msg { confirmed = true; return nil },
+	}))
+	send(m, press("enter"))
+	if confirmed || m.IsOpen() {
+		t.Fatal("enter on a destructive confirm should cancel by default")
+	}
+
+	send(m, Open(Confirm{
+		Destructive: true,
+		OnConfirm:   func() tea.Msg { confirmed = true; return nil },
+	}))
+	send(m, press("tab"))
+	send(m, press("enter"))
+	if !confirmed {
+		t.Fatal("tab then enter should confirm")
+	}
+}
+
+func TestConfirmDestructiveUsesErrorColor(t *testing.T) {
+	th := theme.Preset(theme.DefaultName)
+	c := themeDialog(Confirm{Destructive: true, ConfirmLabel: "Delete"}, th)
+	c = resizeDialog(c, 100, 30)
+	c2, _ := c.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	frame := testkit.FrameOf(c2.View())
+	for y := 0; y < frame.Height(); y++ {
+		line := frame.Lines()[y]
+		x := strings.Index(line, "Delete")
+		if x < 0 {
+			continue
+		}
+		x = ansi.StringWidth(line[:x])
+		if bg := frame.Cell(x, y).Style.Bg; !sameColor(bg, th.Error()) {
+			t.Fatalf("focused destructive button bg = %v, want Error %v", bg, th.Error())
+		}
+		return
+	}
+	t.Fatal("Delete button not rendered")
+}
+
+func TestConfirmRequireText(t *testing.T) {
+	m := New()
+	m.SetSize(100, 30)
+	confirmed := false
+	send(m, Open(Confirm{
+		RequireText: "prod-db",
+		OnConfirm:   func() tea.Msg { confirmed = true; return nil },
+	}))
+	if !strings.Contains(ansi.Strip(viewString(m.View())), "Type prod-db to confirm") {
+		t.Fatal("type-to-confirm hint missing")
+	}
+
+	typeText(m, "prod-d")
+	send(m, press("enter"))
+	if confirmed || !m.IsOpen() {
+		t.Fatal("confirm should stay locked until the text matches")
+	}
+	typeText(m, "b")
+	send(m, press("enter"))
+	if !confirmed || m.IsOpen() {
+		t.Fatal("matching text should unlock confirm")
+	}
+}
+
+func TestManagerThemesDialogs(t *testing.T) {
+	names := theme.Names()
+	if len(names) < 2 {
+		t.Skip("need two presets")
+	}
+	other := theme.Preset(names[1])
+	m := New()
+	m.SetSize(100, 30)
+	m.SetTheme(other)
+	send(m, Open(Confirm{Message: "hi"}))
+	if bg := testkit.FrameOf(m.View()).Cell(0, 0).Style.Bg; !sameColor(bg, other.DialogBG()) {
+		t.Fatalf("confirm bg = %v, want the Manager theme %v", bg, other.DialogBG())
+	}
+
+	p := NewPrompt("p", "Prompt")
+	send(m, Open(p))
+	if p.activeTheme() != other {
+		t.Fatal("Manager should hand its theme to dialogs with SetTheme")
+	}
+
+	send(m, Open(NewCommandPalette([]Command{{Label: "Quit"}})))
+	if bg := testkit.FrameOf(m.View()).Cell(0, 0).Style.Bg; !sameColor(bg, other.DialogBG()) {
+		t.Fatalf("palette bg = %v, want the Manager theme %v", bg, other.DialogBG())
+	}
+}
+
+func TestManagerKeymapRemapsDialogs(t *testing.T) {
+	r := keymap.NewRegistry()
+	m := New()
+	m.SetKeymap(r)
+	err := r.Load(strings.NewReader(`
+choice.down   = ctrl+n
+confirm.close = q
+form.toggle   = x
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewChoice("env", "Environment", ChoiceOption{Label: "dev"}, ChoiceOption{Label: "prod"})
+	send(m, Open(c))
+	send(m, press("j"))
+	send(m, tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	if v, _ := lastResult(t, send(m, press("enter"))).AsString(); v != "prod" {
+		t.Fatalf("got %q: j was remapped away, ctrl+n should move down once", v)
+	}
+
+	send(m, Open(Confirm{OnCancel: func() tea.Msg { return doneMsg{value: "q"} }}))
+	send(m, press("esc"))
+	if !m.IsOpen() {
+		t.Fatal("esc was remapped away from confirm.close")
+	}
+	if out := send(m, press("q")); m.IsOpen() || out[len(out)-1] != (doneMsg{value: "q"}) {
+		t.Fatalf("q should cancel, got %v", out)
+	}
+
+	f := NewForm("f", Field{Key: "public", Label: "Public", Kind: FieldCheckbox})
+	send(m, Open(f.Dialog("Form")))
+	send(m, press("x"))
+	if !f.Values().Bool("public") {
+		t.Fatal("Custom should pass the keymap on to its form")
+	}
+	if _, ok := r.Lookup(ActionThemePickerApply); !ok {
+		t.Fatal("SetKeymap should register every dialog's defaults")
+	}
+}
+
+func TestManagerMouseUsesTheLastDrawnOrigin(t *testing.T) {
+	m := New()
+	m.SetSize(21, 11)
+	c := &counter{name: "hello"}
+	send(m, Open(c))
+	m.View()
+	for range 5 {
+		send(m, tea.MouseMotionMsg{X: 10, Y: 6})
+	}
+	if c.views != 1 {
+		t.Fatalf("dialog rendered %d times, want only the View call", c.views)
+	}
+	if got := c.got[len(c.got)-1]; got != (tea.MouseMotionMsg{X: 2, Y: 1}) {
+		t.Fatalf("last event = %#v, want motion at 2,1 inside the dialog", got)
+	}
+
+	send(m, Open(&counter{name: "wide dialog"}))
+	send(m, CloseMsg{})
+	send(m, tea.MouseMotionMsg{X: 10, Y: 6})
+	if c.views != 2 {
+		t.Fatalf("a stack change should measure the revealed dialog again, views = %d", c.views)
+	}
+}
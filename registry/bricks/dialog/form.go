@@ -0,0 +1,641 @@
+// Brick: Form
+// +-----------------------------------+
+// | Name *                             |
+// | › my-service_                      |
+// |   name is required                 |
+// | Region                             |
+// |   ‹ us-east-1 ›                    |
+// |   [x] Public                       |
+// |            [ Submit ]              |
+// +-----------------------------------+
+// Labeled text, number, select and checkbox fields with focus order,
+// validation and a typed submission. Renders inline or inside a Custom dialog.
+package dialog
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+// FormKeyMap holds the form's keys. PrevOption and NextOption only apply on
+// select fields and Toggle on select and checkbox fields, so text fields
+// still receive those letters.
+type FormKeyMap struct {
+	NextField  bubbleskey.Binding
+	PrevField  bubbleskey.Binding
+	PrevOption bubbleskey.Binding
+	NextOption bubbleskey.Binding
+	Toggle     bubbleskey.Binding // checks a checkbox, steps a select
+	Submit     bubbleskey.Binding
+	Close      bubbleskey.Binding
+}
+
+func DefaultFormKeyMap() FormKeyMap {
+	return FormKeyMap{
+		NextField:  bubbleskey.NewBinding(bubbleskey.WithKeys("tab", "down"), bubbleskey.WithHelp("tab", "next field")),
+		PrevField:  bubbleskey.NewBinding(bubbleskey.WithKeys("shift+tab", "up"), bubbleskey.WithHelp("shift+tab", "previous field")),
+		PrevOption: bubbleskey.NewBinding(bubbleskey.WithKeys("left", "h"), bubbleskey.WithHelp("←/h", "previous option")),
+		NextOption: bubbleskey.NewBinding(bubbleskey.WithKeys("right", "l"), bubbleskey.WithHelp("→/l", "next option")),
+		Toggle:     bubbleskey.NewBinding(bubbleskey.WithKeys("space"), bubbleskey.WithHelp("space", "toggle")),
+		Submit:     bubbleskey.NewBinding(bubbleskey.WithKeys("enter"), bubbleskey.WithHelp("enter", "submit")),
+		Close:      bubbleskey.NewBinding(bubbleskey.WithKeys("esc"), bubbleskey.WithHelp("esc", "cancel")),
+	}
+}
+
+// Scope and action IDs the form bindings register under in a
+// keymap.Registry.
+const (
+	FormScope            keymap.Scope = "form"
+	ActionFormNextField               = "form.nextfield"
+	ActionFormPrevField               = "form.prevfield"
+	ActionFormPrevOption              = "form.prevoption"
+	ActionFormNextOption              = "form.nextoption"
+	ActionFormToggle                  = "form.toggle"
+	ActionFormSubmit                  = "form.submit"
+	ActionFormClose                   = "form.close"
+)
+
+// Bindings returns km as keymap bindings.
+func (km FormKeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionFormNextField, km.NextField),
+		keymap.FromKey(ActionFormPrevField, km.PrevField),
+		keymap.FromKey(ActionFormPrevOption, km.PrevOption),
+		keymap.FromKey(ActionFormNextOption, km.NextOption),
+		keymap.FromKey(ActionFormToggle, km.Toggle),
+		keymap.FromKey(ActionFormSubmit, km.Submit),
+		keymap.FromKey(ActionFormClose, km.Close),
+	}
+}
+
+type FieldKind int
+
+const (
+	FieldText FieldKind = iota
+	FieldNumber
+	FieldSelect
+	FieldCheckbox
+)
+
+// Field describes one form row. Validate receives the typed value: a string
+// for FieldText and FieldSelect, a float64 for FieldNumber and a bool for
+// FieldCheckbox.
+type Field struct {
+	Key         string
+	Label       string
+	Kind        FieldKind
+	Placeholder string   // text and number
+	Default     string   // text and number initial text, or the initial select option
+	Checked     bool     // checkbox initial state
+	Options     []string // select
+	Required    bool     // non-empty text/number, or a checked checkbox
+	Validate    func(value any) error
+}
+
+// FieldError attributes a whole-form validation error to one field, so it is
+// shown inline under that field instead of at the bottom of the form.
+type FieldError struct {
+	Key string
+	Err error
+}
+
+func (e FieldError) Error() string { return e.Err.Error() }
+func (e FieldError) Unwrap() error { return e.Err }
+
+// FormValues is a form submission keyed by Field.Key.
+type FormValues map[string]any
+
+// Text returns a text or select value.
+func (v FormValues) Text(key string) string {
+	s, _ := v[key].(string)
+	return s
+}
+
+// Number returns a number value.
+func (v FormValues) Number(key string) float64 {
+	n, _ := v[key].(float64)
+	return n
+}
+
+// Int returns a number value truncated to an int.
+func (v FormValues) Int(key string) int { return int(v.Number(key)) }
+
+// Bool returns a checkbox value.
+func (v FormValues) Bool(key string) bool {
+	b, _ := v[key].(bool)
+	return b
+}
+
+// AsForm returns Value as FormValues, if the result came from a Form.
+func (r ResultMsg) AsForm() (FormValues, bool) {
+	v, ok := r.Value.(FormValues)
+	return v, ok
+}
+
+// Form lays out fields top to bottom with a submit button. Tab/shift+tab (or
+// down/up) move focus, left/right change a select, space toggles a checkbox,
+// and enter submits. Each field is validated when focus leaves it and again
+// on submit, followed by the whole-form check. A valid submit emits a
+// ResultMsg with FormValues; esc emits a canceled ResultMsg.
+//
+// A Form renders inline like any brick. Dialog wraps it for dialog.Manager,
+// in which case the result also closes the dialog.
+type Form struct {
+	id       string
+	fields   []Field
+	inputs   []textinput.Model // only used by text and number fields
+	choices  []int
+	checked  []bool
+	errs     []string
+	formErr  string
+	validate func(FormValues) error
+	submit   string
+	cursor   int // index into fields; len(fields) is the submit button
+	focused  bool
+	hosted   bool
+	width    int
+	height   int
+	keys     FormKeyMap
+	keymap   *keymap.Registry // nil = use keys
+	theme    theme.Theme      // nil = use theme.CurrentTheme()
+}
+
+func NewForm(id string, fields ...Field) *Form {
+	f := &Form{
+		id:      id,
+		fields:  append([]Field(nil), fields...),
+		inputs:  make([]textinput.Model, len(fields)),
+		choices: make([]int, len(fields)),
+		checked: make([]bool, len(fields)),
+		errs:    make([]string, len(fields)),
+		submit:  "Submit",
+		keys:    DefaultFormKeyMap(),
+	}
+	for i, field := range f.fields {
+		switch field.Kind {
+		case FieldText, FieldNumber:
+			in := textinput.New()
+			in.Prompt = ""
+			in.Placeholder = field.Placeholder
+			in.ShowSuggestions = false
+			in.SetValue(field.Default)
+			in.CursorEnd()
+			f.inputs[i] = in
+		case FieldSelect:
+			for j, opt := range field.Options {
+				if opt == field.Default {
+					f.choices[i] = j
+				}
+			}
+		case FieldCheckbox:
+			f.checked[i] = field.Checked
+		}
+	}
+	return f
+}
+
+// Dialog wraps the form in a Custom frame for dialog.Manager and focuses it.
+func (f *Form) Dialog(title string) Custom {
+	f.hosted = true
+	f.Focus()
+	return Custom{
+		DialogTitle: title,
+		Content:     f,
+		Height:      f.contentHeight() + 4,
+	}
+}
+
+// SetValidate installs the whole-form check that runs after every field is
+// valid. Return a FieldError to report against a specific field.
+func (f *Form) SetValidate(fn func(FormValues) error) { f.validate = fn }
+
+// SetSubmitLabel changes the submit button text.
+func (f *Form) SetSubmitLabel(label string) { f.submit = label }
+
+// SetKeymap reads the form keys from r.
+func (f *Form) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(FormScope, f.keys.Bindings()...)
+	}
+	f.keymap = r
+}
+
+func (f *Form) keyMap() FormKeyMap {
+	r, k := f.keymap, f.keys
+	return FormKeyMap{
+		NextField:  r.KeyOr(ActionFormNextField, k.NextField),
+		PrevField:  r.KeyOr(ActionFormPrevField, k.PrevField),
+		PrevOption: r.KeyOr(ActionFormPrevOption, k.PrevOption),
+		NextOption: r.KeyOr(ActionFormNextOption, k.NextOption),
+		Toggle:     r.KeyOr(ActionFormToggle, k.Toggle),
+		Submit:     r.KeyOr(ActionFormSubmit, k.Submit),
+		Close:      r.KeyOr(ActionFormClose, k.Close),
+	}
+}
+
+// SetTheme updates the theme. Call on ThemeChangedMsg.
+func (f *Form) SetTheme(t theme.Theme) { f.theme = t }
+
+func (f *Form) activeTheme() theme.Theme {
+	if f.theme != nil {
+		return f.theme
+	}
+	return theme.CurrentTheme()
+}
+
+func (f *Form) Focus() {
+	f.focused = true
+	f.syncFocus()
+}
+
+func (f *Form) Blur() {
+	f.focused = false
+	f.syncFocus()
+}
+
+func (f *Form) IsFocused() bool { return f.focused }
+
+func (f *Form) SetSize(width, height int) {
+	f.width = max(0, width)
+	f.height = max(0, height)
+	for i := range f.inputs {
+		f.inputs[i].SetWidth(max(1, f.width-3))
+	}
+}
+
+func (f *Form) GetSize() (int, int) { return f.width, f.height }
+func (f *Form) Init() tea.Cmd       { return nil }
+
+// Values returns the current field values, whether or not they are valid.
+// Unparseable numbers are reported as 0.
+func (f *Form) Values() FormValues {
+	out := make(FormValues, len(f.fields))
+	for i, field := range f.fields {
+		out[field.Key] = f.value(i)
+	}
+	return out
+}
+
+// Errors returns the inline error for every field that has one, keyed by
+// Field.Key, plus the whole-form error under the empty key.
+func (f *Form) Errors() map[string]string {
+	out := make(map[string]string)
+	for i, err := range f.errs {
+		if err != "" {
+			out[f.fields[i].Key] = err
+		}
+	}
+	if f.formErr != "" {
+		out[""] = f.formErr
+	}
+	return out
+}
+
+func (f *Form) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if !f.focused {
+		return f, nil
+	}
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return f, nil
+	}
+
+	keys := f.keyMap()
+	switch {
+	case bubbleskey.Matches(keyMsg, keys.Close):
+		return f, f.emit(ResultMsg{ID: f.id, Canceled: true})
+	case bubbleskey.Matches(keyMsg, keys.NextField):
+		f.move(1)
+		return f, nil
+	case bubbleskey.Matches(keyMsg, keys.PrevField):
+		f.move(-1)
+		return f, nil
+	case bubbleskey.Matches(keyMsg, keys.Submit):
+		return f, f.trySubmit()
+	}
+
+	if f.cursor >= len(f.fields) {
+		return f, nil
+	}
+	field := f.fields[f.cursor]
+	switch field.Kind {
+	case FieldSelect:
+		n := len(field.Options)
+		if n == 0 {
+			return f, nil
+		}
+		switch {
+		case bubbleskey.Matches(keyMsg, keys.PrevOption):
+			f.choices[f.cursor] = (f.choices[f.cursor] + n - 1) % n
+		case bubbleskey.Matches(keyMsg, keys.NextOption, keys.Toggle):
+			f.choices[f.cursor] = (f.choices[f.cursor] + 1) % n
+		default:
+			return f, nil
+		}
+		f.errs[f.cursor] = ""
+		return f, nil
+	case FieldCheckbox:
+		if bubbleskey.Matches(keyMsg, keys.Toggle) {
+			f.checked[f.cursor] = !f.checked[f.cursor]
+			f.errs[f.cursor] = ""
+		}
+		return f, nil
+	}
+
+	updated, cmd := f.inputs[f.cursor].Update(keyMsg)
+	f.inputs[f.cursor] = updated
+	f.errs[f.cursor] = ""
+	f.formErr = ""
+	return f, cmd
+}
+
+func (f *Form) View() tea.View {
+	t := f.activeTheme()
+	width := f.width
+	if width <= 0 {
+		width = 40
+	}
+	bg, fg := t.CardBody(), t.Text()
+	if f.hosted {
+		bg, fg = t.DialogBG(), t.DialogFG()
+	}
+	focusBG := t.BackgroundInteractive()
+
+	type row struct {
+		owner int
+		text  string
+	}
+	rows := make([]row, 0, len(f.fields)*3+4)
+	add := func(owner int, text string) { rows = append(rows, row{owner, text}) }
+
+	for i, field := range f.fields {
+		active := f.focused && i == f.cursor
+		marker := "  "
+		if active {
+			marker = "› "
+		}
+		if i > 0 {
+			add(i, formRow(bg, fg, width, ""))
+		}
+		if field.Kind != FieldCheckbox {
+			label := field.Label
+			if field.Required {
+				label += " *"
+			}
+			labelFG := t.TextMuted()
+			if active {
+				labelFG = t.TextAccent()
+			}
+			add(i, formRow(bg, labelFG, width, label))
+		}
+
+		rowBG := bg
+		if active {
+			rowBG = focusBG
+		}
+		switch field.Kind {
+		case FieldText, FieldNumber:
+			f.syncInputStyles(i, t, rowBG, fg)
+			add(i, formRow(rowBG, fg, width, marker+f.inputs[i].View()))
+		case FieldSelect:
+			add(i, formRow(rowBG, fg, width, marker+"‹ "+f.choice(i)+" ›"))
+		case FieldCheckbox:
+			mark := "[ ]"
+			if f.checked[i] {
+				mark = "[x]"
+			}
+			label := field.Label
+			if field.Required {
+				label += " *"
+			}
+			add(i, formRow(rowBG, fg, width, marker+mark+" "+label))
+		}
+		if f.errs[i] != "" {
+			add(i, formRow(bg, t.Error(), width, "  "+f.errs[i]))
+		}
+	}
+
+	end := len(f.fields)
+	add(end, formRow(bg, fg, width, ""))
+	if f.formErr != "" {
+		add(end, formRow(bg, t.Error(), width, f.formErr))
+	}
+	add(end, f.renderButton(t, bg, fg, width))
+	if f.hosted {
+		add(end, formRow(bg, fg, width, ""))
+		add(end, formRow(bg, t.TextMuted(), width, "tab next  enter submit  esc cancel"))
+	}
+
+	start, stop := 0, len(rows)
+	if f.height > 0 && len(rows) > f.height {
+		// Keep every row of the focused field inside the window.
+		first, last := -1, 0
+		for i, r := range rows {
+			if r.owner == f.cursor {
+				if first < 0 {
+					first = i
+				}
+				last = i
+			}
+		}
+		start = min(max(0, last-f.height+1), max(0, first))
+		start = min(start, len(rows)-f.height)
+		stop = start + f.height
+	}
+	out := make([]string, 0, max(f.height, stop-start))
+	for _, r := range rows[start:stop] {
+		out = append(out, r.text)
+	}
+	for len(out) < f.height {
+		out = append(out, formRow(bg, fg, width, ""))
+	}
+	return tea.NewView(strings.Join(out, "\n"))
+}
+
+func (f *Form) renderButton(t theme.Theme, bg, fg color.Color, width int) string {
+	label := "[ " + f.submit + " ]"
+	btnBG, btnFG := t.BackgroundInteractive(), fg
+	if f.focused && f.cursor == len(f.fields) {
+		btnBG, btnFG = t.SelectionBG(), t.SelectionFG()
+	}
+	pad := max(0, (width-lipgloss.Width(label))/2)
+	btn := lipgloss.NewStyle().Background(btnBG).Foreground(btnFG).Bold(true).Render(label)
+	return formRow(bg, fg, width, strings.Repeat(" ", pad)+btn)
+}
+
+func (f *Form) syncInputStyles(i int, t theme.Theme, bg, fg color.Color) {
+	s := textinput.DefaultStyles(true)
+	s.Focused.Prompt = lipgloss.NewStyle().Background(bg).Foreground(t.TextMuted())
+	s.Focused.Text = lipgloss.NewStyle().Background(bg).Foreground(fg)
+	s.Focused.Placeholder = lipgloss.NewStyle().Background(bg).Foreground(t.InputPlaceholder())
+	s.Blurred = s.Focused
+	s.Cursor.Color = t.InputCursor()
+	f.inputs[i].SetStyles(s)
+}
+
+// contentHeight is the number of rows the form needs without scrolling.
+func (f *Form) contentHeight() int {
+	h := 2 // spacer + button
+	for i, field := range f.fields {
+		if i > 0 {
+			h++
+		}
+		h++
+		if field.Kind != FieldCheckbox {
+			h++
+		}
+	}
+	if f.hosted {
+		h += 2
+	}
+	return h
+}
+
+func (f *Form) move(step int) {
+	if f.cursor < len(f.fields) {
+		f.checkField(f.cursor)
+	}
+	f.cursor = (f.cursor + step + len(f.fields) + 1) % (len(f.fields) + 1)
+	f.syncFocus()
+}
+
+func (f *Form) syncFocus() {
+	for i, field := range f.fields {
+		if field.Kind != FieldText && field.Kind != FieldNumber {
+			continue
+		}
+		if f.focused && i == f.cursor {
+			f.inputs[i].Focus()
+		} else {
+			f.inputs[i].Blur()
+		}
+	}
+}
+
+func (f *Form) trySubmit() tea.Cmd {
+	firstBad := -1
+	for i := range f.fields {
+		if !f.checkField(i) && firstBad < 0 {
+			firstBad = i
+		}
+	}
+	f.formErr = ""
+	values := f.Values()
+	if firstBad < 0 && f.validate != nil {
+		if err := f.validate(values); err != nil {
+			var fieldErr FieldError
+			if errors.As(err, &fieldErr) && f.indexOf(fieldErr.Key) >= 0 {
+				firstBad = f.indexOf(fieldErr.Key)
+				f.errs[firstBad] = fieldErr.Error()
+			} else {
+				f.formErr = err.Error()
+				return nil
+			}
+		}
+	}
+	if firstBad >= 0 {
+		f.cursor = firstBad
+		f.syncFocus()
+		return nil
+	}
+	return f.emit(ResultMsg{ID: f.id, Value: values})
+}
+
+// checkField runs the built-in and per-field checks for field i and records
+// the error inline. It reports whether the field is valid.
+func (f *Form) checkField(i int) bool {
+	field := f.fields[i]
+	f.errs[i] = ""
+	name := field.Label
+	if name == "" {
+		name = field.Key
+	}
+	switch field.Kind {
+	case FieldText:
+		if field.Required && strings.TrimSpace(f.inputs[i].Value()) == "" {
+			f.errs[i] = name + " is required"
+		}
+	case FieldNumber:
+		raw := strings.TrimSpace(f.inputs[i].Value())
+		if raw == "" {
+			if field.Required {
+				f.errs[i] = name + " is required"
+			}
+			break
+		}
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			f.errs[i] = fmt.Sprintf("%s must be a number", name)
+		}
+	case FieldSelect:
+		if field.Required && len(field.Options) == 0 {
+			f.errs[i] = name + " has no options"
+		}
+	case FieldCheckbox:
+		if field.Required && !f.checked[i] {
+			f.errs[i] = name + " must be checked"
+		}
+	}
+	if f.errs[i] == "" && field.Validate != nil {
+		if err := field.Validate(f.value(i)); err != nil {
+			f.errs[i] = err.Error()
+		}
+	}
+	return f.errs[i] == ""
+}
+
+func (f *Form) value(i int) any {
+	switch f.fields[i].Kind {
+	case FieldNumber:
+		n, _ := strconv.ParseFloat(strings.TrimSpace(f.inputs[i].Value()), 64)
+		return n
+	case FieldSelect:
+		return f.choice(i)
+	case FieldCheckbox:
+		return f.checked[i]
+	default:
+		return f.inputs[i].Value()
+	}
+}
+
+func (f *Form) choice(i int) string {
+	opts := f.fields[i].Options
+	if len(opts) == 0 {
+		return ""
+	}
+	return opts[clamp(f.choices[i], 0, len(opts)-1)]
+}
+
+func (f *Form) indexOf(key string) int {
+	for i, field := range f.fields {
+		if field.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// emit delivers a result inline, or closes the hosting dialog with it.
+func (f *Form) emit(result ResultMsg) tea.Cmd {
+	if f.hosted {
+		return func() tea.Msg { return CloseWith(result) }
+	}
+	return func() tea.Msg { return result }
+}
+
+// formRow paints one exact-width row and keeps its colors across the resets
+// inside styled spans such as the text input and the submit button.
+func formRow(bg, fg color.Color, width int, content string) string {
+	return styles.RowClip(bg, fg, width, styles.Reopen(content, bg, fg))
+}
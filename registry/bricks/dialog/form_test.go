@@ -0,0 +1,184 @@
+package dialog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func serviceForm() *Form {
+	return NewForm("service",
+		Field{Key: "name", Label: "Name", Required: true},
+		Field{Key: "replicas", Label: "Replicas", Kind: FieldNumber, Default: "2"},
+		Field{Key: "region", Label: "Region", Kind: FieldSelect, Options: []string{"us-east", "eu-west"}},
+		Field{Key: "public", Label: "Public", Kind: FieldCheckbox},
+	)
+}
+
+// feed sends msg to a model and runs the returned command once.
+func feed(m tea.Model, msg tea.Msg) tea.Msg {
+	_, cmd := m.Update(msg)
+	if cmd == nil {
+		return nil
+	}
+	return cmd()
+}
+
+func typeInto(m tea.Model, s string) {
+	for _, r := range s {
+		m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+func TestFormIgnoresKeysWhenBlurred(t *testing.T) {
+	f := serviceForm()
+	typeInto(f, "web")
+	if got := f.Values().Text("name"); got != "" {
+		t.Fatalf("blurred form accepted input %q", got)
+	}
+}
+
+func TestFormSubmitsTypedValues(t *testing.T) {
+	f := serviceForm()
+	f.SetSize(40, 0)
+	f.Focus()
+
+	typeInto(f, "web")
+	feed(f, press("tab"))
+	typeInto(f, "1")
+	feed(f, press("tab"))
+	feed(f, tea.KeyPressMsg{Code: tea.KeyRight})
+	feed(f, press("tab"))
+	feed(f, tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+
+	msg := feed(f, press("enter"))
+	r, ok := msg.(ResultMsg)
+	if !ok {
+		t.Fatalf("enter produced %T, want ResultMsg", msg)
+	}
+	v, ok := r.AsForm()
+	if !ok || r.ID != "service" {
+		t.Fatalf("got %+v, want service FormValues", r)
+	}
+	if v.Text("name") != "web" || v.Int("replicas") != 21 || v.Text("region") != "eu-west" || !v.Bool("public") {
+		t.Fatalf("unexpected values %v", v)
+	}
+}
+
+func TestFormFieldValidation(t *testing.T) {
+	f := serviceForm()
+	f.SetSize(40, 0)
+	f.Focus()
+
+	// Leaving a required field empty flags it as soon as focus moves on.
+	feed(f, press("tab"))
+	if f.Errors()["name"] == "" {
+		t.Fatal("required field should be flagged on blur")
+	}
+
+	typeInto(f, "x")
+	if msg := feed(f, press("enter")); msg != nil {
+		t.Fatalf("invalid form submitted %v", msg)
+	}
+	errs := f.Errors()
+	if errs["replicas"] == "" || errs["name"] == "" {
+		t.Fatalf("expected name and replicas errors, got %v", errs)
+	}
+	plain := ansi.Strip(viewString(f.View()))
+	if !strings.Contains(plain, "Replicas must be a number") {
+		t.Fatalf("number error not rendered inline:\n%s", plain)
+	}
+	if !strings.Contains(plain, "› ") || f.cursor != 0 {
+		t.Fatal("submit should move focus to the first invalid field")
+	}
+
+	typeInto(f, "web")
+	if f.Errors()["name"] != "" {
+		t.Fatal("editing a field should clear its error")
+	}
+}
+
+func TestFormWholeFormValidation(t *testing.T) {
+	f := NewForm("pw",
+		Field{Key: "password", Label: "Password"},
+		Field{Key: "confirm", Label: "Confirm"},
+	)
+	f.Focus()
+	f.SetValidate(func(v FormValues) error {
+		if v.Text("password") == "" {
+			return errors.New("choose a password")
+		}
+		if v.Text("password") != v.Text("confirm") {
+			return FieldError{Key: "confirm", Err: errors.New("passwords differ")}
+		}
+		return nil
+	})
+
+	if msg := feed(f, press("enter")); msg != nil {
+		t.Fatalf("submitted %v", msg)
+	}
+	if got := f.Errors()[""]; got != "choose a password" {
+		t.Fatalf("form error = %q", got)
+	}
+
+	typeInto(f, "a")
+	feed(f, press("enter"))
+	if f.Errors()["confirm"] != "passwords differ" || f.cursor != 1 {
+		t.Fatalf("FieldError should land on confirm, got %v (cursor %d)", f.Errors(), f.cursor)
+	}
+	typeInto(f, "a")
+	if _, ok := feed(f, press("enter")).(ResultMsg); !ok {
+		t.Fatal("matching passwords should submit")
+	}
+}
+
+func TestFormHostedInManager(t *testing.T) {
+	m := New()
+	m.SetSize(100, 30)
+	f := NewForm("rename", Field{Key: "name", Label: "Name", Default: "old"})
+	send(m, Open(f.Dialog("Rename")))
+	if !strings.Contains(ansi.Strip(viewString(m.View())), "Rename") {
+		t.Fatal("hosted form should render inside the dialog frame")
+	}
+
+	typeInto(m, "er")
+	r := lastResult(t, send(m, press("enter")))
+	if m.IsOpen() {
+		t.Fatal("submitting a hosted form should close its dialog")
+	}
+	if v, _ := r.AsForm(); v.Text("name") != "older" {
+		t.Fatalf("got %+v", r)
+	}
+
+	send(m, Open(NewForm("x").Dialog("X")))
+	if r := lastResult(t, send(m, press("esc"))); !r.Canceled || m.IsOpen() {
+		t.Fatalf("esc should cancel and close, got %+v", r)
+	}
+}
+
+func TestFormScrollsToFocus(t *testing.T) {
+	f := serviceForm()
+	f.SetSize(30, 4)
+	f.Focus()
+	for range 3 {
+		feed(f, press("tab"))
+	}
+	frame := testkit.FrameOf(f.View())
+	if frame.Height() != 4 {
+		t.Fatalf("form is %d rows, want 4", frame.Height())
+	}
+	if !strings.Contains(frame.Plain(), "[ ] Public") {
+		t.Fatalf("focused checkbox scrolled out of view:\n%s", frame.Plain())
+	}
+}
+
+func TestFormConformance(t *testing.T) {
+	testkit.RunConformance(t, testkit.Conformance{
+		New:     func() testkit.Brick { return serviceForm() },
+		MinSize: [2]int{12, 1},
+	})
+}
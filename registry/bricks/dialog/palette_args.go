@@ -0,0 +1,127 @@
+package dialog
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+)
+
+// ArgKind selects how the palette collects a command argument.
+type ArgKind int
+
+const (
+	ArgText ArgKind = iota // free text typed into the search row
+	ArgEnum                // one of Options, fuzzy-filtered as you type
+	ArgPath                // a file or folder, browsed from Dir
+)
+
+// Arg declares one value a Command needs before it runs. The palette asks
+// for each Arg in order after the command is chosen.
+type Arg struct {
+	Key         string         // key in Args passed to Command.Run
+	Label       string         // step name, shown as placeholder and breadcrumb
+	Kind        ArgKind        // default ArgText
+	Options     []ChoiceOption // ArgEnum choices
+	Default     string         // prefilled text, preselected option or start path
+	Dir         string         // ArgPath starting folder — empty = current folder
+	Required    bool           // reject an empty value
+	Validate    func(string) error
+	Placeholder string // overrides Label as the search placeholder
+}
+
+func (a Arg) label() string {
+	if a.Label != "" {
+		return a.Label
+	}
+	return a.Key
+}
+
+func (a Arg) placeholder() string {
+	if a.Placeholder != "" {
+		return a.Placeholder
+	}
+	return a.label()
+}
+
+// Args holds the values collected for a multi-step command, keyed by Arg.Key.
+type Args map[string]string
+
+// Get returns the value collected for key, or "".
+func (a Args) Get(key string) string { return a[key] }
+
+// enumMatches filters the options of an ArgEnum step by query.
+func enumMatches(query string, options []ChoiceOption) []paletteMatch {
+	out := make([]paletteMatch, 0, len(options))
+	for i, o := range options {
+		m := paletteMatch{Command: Command{Label: o.Label}, value: o.value(), order: i}
+		if query != "" {
+			ms := fuzzy.Find(query, []string{o.Label})
+			if len(ms) == 0 {
+				continue
+			}
+			m.score, m.matched = ms[0].Score, ms[0].MatchedIndexes
+		}
+		out = append(out, m)
+	}
+	sort.SliceStable(out, func(a, b int) bool { return out[a].score > out[b].score })
+	return out
+}
+
+// pathMatches lists the folder named by query up to its last separator,
+// fuzzy-filtered by the rest. Folders come first and end in a separator;
+// a query that is exactly a folder also offers "." to pick that folder.
+func pathMatches(query string) []paletteMatch {
+	dir, base := filepath.Split(query)
+	entries, err := os.ReadDir(cmp.Or(dir, "."))
+	if err != nil {
+		return nil
+	}
+
+	out := make([]paletteMatch, 0, len(entries)+1)
+	if dir != "" && base == "" {
+		out = append(out, paletteMatch{
+			Command: Command{Label: ".", Keybind: "this folder"},
+			value:   filepath.Clean(dir),
+		})
+	}
+	names := make([]string, 0, len(entries))
+	isDir := make([]bool, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".") {
+			continue
+		}
+		names = append(names, name)
+		isDir = append(isDir, e.IsDir())
+	}
+
+	matches := make([]paletteMatch, 0, len(names))
+	for i, name := range names {
+		m := paletteMatch{Command: Command{Label: name}, value: dir + name, order: i}
+		if isDir[i] {
+			m.Label += string(filepath.Separator)
+			m.value += string(filepath.Separator)
+			m.dir = true
+		}
+		if base != "" {
+			ms := fuzzy.Find(base, []string{name})
+			if len(ms) == 0 {
+				continue
+			}
+			m.score, m.matched = ms[0].Score, ms[0].MatchedIndexes
+		}
+		matches = append(matches, m)
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		x, y := matches[a], matches[b]
+		if x.dir != y.dir {
+			return x.dir
+		}
+		return x.score > y.score
+	})
+	return append(out, matches...)
+}
@@ -0,0 +1,230 @@
+package dialog
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// historyHalfLife is how long it takes a run to count half as much.
+	historyHalfLife = 72 * time.Hour
+	// historyMaxEntries caps the state file; the lowest-ranked commands are
+	// forgotten first.
+	historyMaxEntries = 100
+	historyVersion    = 1
+)
+
+// History remembers which palette commands were run, how often and when.
+// Palettes are usually rebuilt every time they open, so keep one History on
+// the app model and hand it to each palette with SetHistory.
+//
+// A History from NewHistory lives in memory. One from OpenHistory or
+// LoadHistory can also be written to a small JSON state file with Save. The
+// palette saves it once per run, from the command it returns, so the file is
+// never written inside Update.
+type History struct {
+	mu      sync.Mutex
+	entries map[string]historyEntry
+	path    string
+	now     func() time.Time
+}
+
+type historyEntry struct {
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+}
+
+type historyFile struct {
+	Version  int                     `json:"version"`
+	Commands map[string]historyEntry `json:"commands"`
+}
+
+func NewHistory() *History {
+	return &History{entries: make(map[string]historyEntry), now: time.Now}
+}
+
+// HistoryPath returns the per-app state file used by OpenHistory:
+// $XDG_STATE_HOME/<app>/palette_history.json, falling back to
+// ~/.local/state/<app>/palette_history.json.
+func HistoryPath(app string) (string, error) {
+	if app == "" {
+		return "", errors.New("dialog: history needs an app name")
+	}
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, app, "palette_history.json"), nil
+}
+
+// OpenHistory loads the command history persisted for app, or starts an
+// empty one if the app has none yet.
+func OpenHistory(app string) (*History, error) {
+	path, err := HistoryPath(app)
+	if err != nil {
+		return nil, err
+	}
+	return LoadHistory(path)
+}
+
+// LoadHistory loads a History persisted at path. A missing file is not an
+// error; it is created on the first Save.
+func LoadHistory(path string) (*History, error) {
+	h := NewHistory()
+	h.path = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f historyFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	for k, e := range f.Commands {
+		if k != "" && e.Count > 0 {
+			h.entries[k] = e
+		}
+	}
+	return h, nil
+}
+
+// Record marks the command with key as run now. It only touches memory; call
+// Save to persist it.
+func (h *History) Record(key string) {
+	if h == nil || key == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e := h.entries[key]
+	e.Count++
+	e.Last = h.now()
+	h.entries[key] = e
+	h.prune()
+}
+
+// LastUsed returns when the command with key was last run, or the zero time.
+func (h *History) LastUsed(key string) time.Time {
+	if h == nil {
+		return time.Time{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.entries[key].Last
+}
+
+// Recent returns up to n command keys ranked by frecency: how often they were
+// run, with older runs counting less.
+func (h *History) Recent(n int) []string {
+	if h == nil || n <= 0 {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	keys := h.ranked()
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
+
+// Clear forgets every command and, for a persisted History, removes the
+// state file.
+func (h *History) Clear() error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = make(map[string]historyEntry)
+	if h.path == "" {
+		return nil
+	}
+	if err := os.Remove(h.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Save writes a persisted History to its state file. It is a no-op for an
+// in-memory History.
+func (h *History) Save() error {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.saveLocked()
+}
+
+func (h *History) saveLocked() error {
+	if h.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(historyFile{Version: historyVersion, Commands: h.entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return err
+	}
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
+
+// ranked returns every key, best frecency first, ties to the latest run.
+func (h *History) ranked() []string {
+	now := h.now()
+	keys := make([]string, 0, len(h.entries))
+	score := make(map[string]float64, len(h.entries))
+	for k, e := range h.entries {
+		keys = append(keys, k)
+		score[k] = frecency(e, now)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		x, y := keys[a], keys[b]
+		if score[x] != score[y] {
+			return score[x] > score[y]
+		}
+		if lx, ly := h.entries[x].Last, h.entries[y].Last; !lx.Equal(ly) {
+			return lx.After(ly)
+		}
+		return x < y
+	})
+	return keys
+}
+
+func (h *History) prune() {
+	if len(h.entries) <= historyMaxEntries {
+		return
+	}
+	for _, k := range h.ranked()[historyMaxEntries:] {
+		delete(h.entries, k)
+	}
+}
+
+// frecency weighs the run count by age, halving every historyHalfLife.
+func frecency(e historyEntry, now time.Time) float64 {
+	age := now.Sub(e.Last)
+	if age < 0 {
+		age = 0
+	}
+	return float64(e.Count) * math.Pow(0.5, float64(age)/float64(historyHalfLife))
+}
@@ -0,0 +1,98 @@
+package dialog
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// fakeClock makes h read the returned time, which the test can move.
+func fakeClock(h *History) *time.Time {
+	clock := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	h.now = func() time.Time { return clock }
+	return &clock
+}
+
+func TestHistoryRanksByFrecency(t *testing.T) {
+	h := NewHistory()
+	clock := fakeClock(h)
+	for range 4 {
+		h.Record("deploy")
+	}
+	h.Record("logs")
+	*clock = clock.Add(time.Hour)
+	h.Record("quit")
+
+	if got := h.Recent(3); !slices.Equal(got, []string{"deploy", "quit", "logs"}) {
+		t.Fatalf("Recent = %v", got)
+	}
+
+	// A month later the old burst has decayed below a fresh run.
+	*clock = clock.Add(30 * 24 * time.Hour)
+	h.Record("logs")
+	if got := h.Recent(1); !slices.Equal(got, []string{"logs"}) {
+		t.Fatalf("Recent = %v, want logs after decay", got)
+	}
+}
+
+func TestHistoryPersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app", "palette_history.json")
+	h, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.Record("deploy")
+	h.Record("deploy")
+	h.Record("logs")
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Record should not write the state file, stat err = %v", err)
+	}
+	if err := h.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	again, err := LoadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := again.Recent(5); !slices.Equal(got, []string{"deploy", "logs"}) {
+		t.Fatalf("reloaded Recent = %v", got)
+	}
+
+	if err := again.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Recent(5)) != 0 {
+		t.Fatal("Clear should forget every command")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("Clear should remove the state file, stat err = %v", err)
+	}
+}
+
+func TestHistoryPathIsPerApp(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", dir)
+	path, err := HistoryPath("myapp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "myapp", "palette_history.json"); path != want {
+		t.Fatalf("path = %q, want %q", path, want)
+	}
+	if _, err := HistoryPath(""); err == nil {
+		t.Fatal("empty app name should be rejected")
+	}
+}
+
+func TestHistoryRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "palette_history.json")
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadHistory(path); err == nil {
+		t.Fatal("corrupt state file should be reported")
+	}
+}
@@ -0,0 +1,208 @@
+// Brick: Prompt
+// +-----------------------------------+
+// | message                            |
+// | > value_                           |
+// | validation error                   |
+// +-----------------------------------+
+// Single-line question dialog with a default and validation.
+package dialog
+
+import (
+	"image/color"
+	"strings"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+)
+
+// PromptKeyMap holds the Prompt's own keys; everything else edits the input.
+type PromptKeyMap struct {
+	Submit bubbleskey.Binding
+	Close  bubbleskey.Binding
+}
+
+func DefaultPromptKeyMap() PromptKeyMap {
+	return PromptKeyMap{
+		Submit: bubbleskey.NewBinding(bubbleskey.WithKeys("enter"), bubbleskey.WithHelp("enter", "submit")),
+		Close:  bubbleskey.NewBinding(bubbleskey.WithKeys("esc"), bubbleskey.WithHelp("esc", "cancel")),
+	}
+}
+
+// Scope and action IDs the Prompt bindings register under in a
+// keymap.Registry.
+const (
+	PromptScope        keymap.Scope = "prompt"
+	ActionPromptSubmit              = "prompt.submit"
+	ActionPromptClose               = "prompt.close"
+)
+
+// Bindings returns km as keymap bindings.
+func (km PromptKeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionPromptSubmit, km.Submit),
+		keymap.FromKey(ActionPromptClose, km.Close),
+	}
+}
+
+// Prompt asks for a single line of text. Enter validates and closes with a
+// ResultMsg carrying the string; Esc closes with a canceled ResultMsg.
+type Prompt struct {
+	id       string
+	title    string
+	message  string
+	validate func(string) error
+	err      string
+	input    textinput.Model
+	width    int
+	height   int
+	keys     PromptKeyMap
+	keymap   *keymap.Registry // nil = use keys; set by Manager
+	theme    theme.Theme      // nil = use theme.CurrentTheme(); set by Manager
+}
+
+func NewPrompt(id, title string) *Prompt {
+	in := textinput.New()
+	in.Prompt = "> "
+	in.ShowSuggestions = false
+	in.Focus()
+
+	p := &Prompt{id: id, title: title, input: in, keys: DefaultPromptKeyMap()}
+	p.syncStyles()
+	return p
+}
+
+// SetMessage sets the question shown above the input.
+func (p *Prompt) SetMessage(message string) { p.message = message }
+
+// SetPlaceholder sets the text shown while the input is empty.
+func (p *Prompt) SetPlaceholder(placeholder string) { p.input.Placeholder = placeholder }
+
+// SetDefault pre-fills the input with value.
+func (p *Prompt) SetDefault(value string) {
+	p.input.SetValue(value)
+	p.input.CursorEnd()
+}
+
+// SetValidate installs a check that runs on enter. A non-nil error is shown
+// under the input and keeps the prompt open.
+func (p *Prompt) SetValidate(fn func(string) error) { p.validate = fn }
+
+// SetKeymap reads the Prompt keys from r.
+func (p *Prompt) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(PromptScope, p.keys.Bindings()...)
+	}
+	p.keymap = r
+}
+
+func (p *Prompt) keyMap() PromptKeyMap {
+	r, k := p.keymap, p.keys
+	return PromptKeyMap{Submit: r.KeyOr(ActionPromptSubmit, k.Submit), Close: r.KeyOr(ActionPromptClose, k.Close)}
+}
+
+// Value returns the current input.
+func (p *Prompt) Value() string { return p.input.Value() }
+
+// ID returns the id reported in ResultMsg.
+func (p *Prompt) ID() string { return p.id }
+
+func (p *Prompt) Init() tea.Cmd { return p.input.Focus() }
+
+func (p *Prompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+
+	keys := p.keyMap()
+	switch {
+	case bubbleskey.Matches(keyMsg, keys.Close):
+		return p, func() tea.Msg { return CloseWith(ResultMsg{ID: p.id, Canceled: true}) }
+	case bubbleskey.Matches(keyMsg, keys.Submit):
+		value := p.input.Value()
+		if p.validate != nil {
+			if err := p.validate(value); err != nil {
+				p.err = err.Error()
+				return p, nil
+			}
+		}
+		p.err = ""
+		return p, func() tea.Msg { return CloseWith(ResultMsg{ID: p.id, Value: value}) }
+	}
+
+	updated, cmd := p.input.Update(keyMsg)
+	p.input = updated
+	p.err = ""
+	return p, cmd
+}
+
+func (p *Prompt) View() tea.View {
+	t := p.activeTheme()
+	p.syncStyles()
+	width := p.frameWidth()
+	inner := max(1, width-4)
+
+	rows := make([]string, 0, 6)
+	if strings.TrimSpace(p.message) != "" {
+		rows = append(rows, p.message, "")
+	}
+	rows = append(rows, paletteClip(p.input.View(), inner))
+	if p.err != "" {
+		rows = append(rows, dialogToneRow(t, t.Error(), inner, p.err))
+	} else {
+		rows = append(rows, "")
+	}
+	rows = append(rows, "", dialogToneRow(t, t.TextMuted(), inner, "enter submit  esc cancel"))
+
+	height := len(rows) + 4
+	return tea.NewView(renderDialogFrame(p.title, strings.Join(rows, "\n"), width, height, t))
+}
+
+func (p *Prompt) SetSize(width, height int) {
+	p.width = width
+	p.height = height
+	inner := max(1, p.frameWidth()-4)
+	p.input.SetWidth(max(1, inner-lipgloss.Width(p.input.Prompt)-1))
+}
+
+func (p *Prompt) frameWidth() int { return max(48, p.width/2) }
+
+// SetTheme updates the theme. The Manager calls it on open and on theme
+// changes.
+func (p *Prompt) SetTheme(t theme.Theme) { p.theme = t }
+
+func (p *Prompt) activeTheme() theme.Theme {
+	if p.theme != nil {
+		return p.theme
+	}
+	return theme.CurrentTheme()
+}
+
+func (p *Prompt) Title() string { return p.title }
+
+func (p *Prompt) syncStyles() {
+	t := p.activeTheme()
+	s := textinput.DefaultStyles(true)
+	s.Focused.Prompt = lipgloss.NewStyle().Background(t.DialogBG()).Foreground(t.TextAccent())
+	s.Focused.Text = lipgloss.NewStyle().Background(t.DialogBG()).Foreground(t.DialogFG())
+	s.Focused.Placeholder = lipgloss.NewStyle().Background(t.DialogBG()).Foreground(t.InputPlaceholder())
+	s.Blurred = s.Focused
+	s.Cursor.Color = t.InputCursor()
+	p.input.SetStyles(s)
+}
+
+// dialogToneRow renders one body row in a non-default foreground. The dialog
+// background is set explicitly so the frame's row fill does not break after
+// the inner reset.
+func dialogToneRow(t theme.Theme, fg color.Color, width int, content string) string {
+	return lipgloss.NewStyle().
+		Background(t.DialogBG()).
+		Foreground(fg).
+		MaxWidth(width).
+		Render(content)
+}
@@ -10,13 +10,53 @@ package dialog
 import (
 	"strings"
 
+	bubbleskey "charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 )
 
+// ThemePickerKeyMap holds the picker's own keys. Letters are left free for
+// the search query.
+type ThemePickerKeyMap struct {
+	Up    bubbleskey.Binding
+	Down  bubbleskey.Binding
+	Apply bubbleskey.Binding
+	Close bubbleskey.Binding // reverts the preview
+}
+
+func DefaultThemePickerKeyMap() ThemePickerKeyMap {
+	return ThemePickerKeyMap{
+		Up:    bubbleskey.NewBinding(bubbleskey.WithKeys("up", "ctrl+p"), bubbleskey.WithHelp("↑", "previous")),
+		Down:  bubbleskey.NewBinding(bubbleskey.WithKeys("down", "ctrl+n"), bubbleskey.WithHelp("↓", "next")),
+		Apply: bubbleskey.NewBinding(bubbleskey.WithKeys("enter"), bubbleskey.WithHelp("enter", "apply")),
+		Close: bubbleskey.NewBinding(bubbleskey.WithKeys("esc"), bubbleskey.WithHelp("esc", "cancel")),
+	}
+}
+
+// Scope and action IDs the theme picker bindings register under in a
+// keymap.Registry.
+const (
+	ThemePickerScope       keymap.Scope = "themepicker"
+	ActionThemePickerUp                 = "themepicker.up"
+	ActionThemePickerDown               = "themepicker.down"
+	ActionThemePickerApply              = "themepicker.apply"
+	ActionThemePickerClose              = "themepicker.close"
+)
+
+// Bindings returns km as keymap bindings.
+func (km ThemePickerKeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionThemePickerUp, km.Up),
+		keymap.FromKey(ActionThemePickerDown, km.Down),
+		keymap.FromKey(ActionThemePickerApply, km.Apply),
+		keymap.FromKey(ActionThemePickerClose, km.Close),
+	}
+}
+
 // ThemePicker is a searchable theme selection dialog.
 // Previews themes live as the user navigates; reverts on ESC.
 type ThemePicker struct {
@@ -28,6 +68,8 @@ type ThemePicker struct {
 	themeName string // current preview/selection
 	baseTheme string // theme at open time — reverted on ESC
 	search    textinput.Model
+	keys      ThemePickerKeyMap
+	keymap    *keymap.Registry // nil = use keys; set by Manager
 }
 
 func NewThemePicker() *ThemePicker {
@@ -45,12 +87,31 @@ func NewThemePicker() *ThemePicker {
 		themeName: cur,
 		baseTheme: cur,
 		search:    in,
+		keys:      DefaultThemePickerKeyMap(),
 	}
 	p.alignSelectionToCurrent()
 	p.syncStyles()
 	return p
 }
 
+// SetKeymap reads the picker keys from r.
+func (p *ThemePicker) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(ThemePickerScope, p.keys.Bindings()...)
+	}
+	p.keymap = r
+}
+
+func (p *ThemePicker) keyMap() ThemePickerKeyMap {
+	r, k := p.keymap, p.keys
+	return ThemePickerKeyMap{
+		Up:    r.KeyOr(ActionThemePickerUp, k.Up),
+		Down:  r.KeyOr(ActionThemePickerDown, k.Down),
+		Apply: r.KeyOr(ActionThemePickerApply, k.Apply),
+		Close: r.KeyOr(ActionThemePickerClose, k.Close),
+	}
+}
+
 func (p *ThemePicker) Init() tea.Cmd { return p.search.Focus() }
 
 func (p *ThemePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -65,8 +126,9 @@ func (p *ThemePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return p, nil
 	}
 
-	switch keyMsg.String() {
-	case "esc":
+	keys := p.keyMap()
+	switch {
+	case bubbleskey.Matches(keyMsg, keys.Close):
 		t, err := theme.PreviewTheme(p.baseTheme)
 		if err != nil {
 			return p, func() tea.Msg { return Close() }
@@ -78,19 +140,19 @@ func (p *ThemePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			func() tea.Msg { return theme.ThemeChangedMsg{Name: p.baseTheme, Theme: t} },
 			func() tea.Msg { return Close() },
 		)
-	case "up", "k":
+	case bubbleskey.Matches(keyMsg, keys.Up):
 		if p.selected > 0 {
 			p.selected--
 			return p, p.previewSelectedCmd()
 		}
 		return p, nil
-	case "down", "j":
+	case bubbleskey.Matches(keyMsg, keys.Down):
 		if p.selected < len(p.filtered)-1 {
 			p.selected++
 			return p, p.previewSelectedCmd()
 		}
 		return p, nil
-	case "enter":
+	case bubbleskey.Matches(keyMsg, keys.Apply):
 		if len(p.filtered) == 0 {
 			return p, nil
 		}
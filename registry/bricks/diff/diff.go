@@ -0,0 +1,648 @@
+// Brick: Diff
+// +-----------------------------------+
+// | main.go                     +2 −1 |
+// | @@ -1,4 +1,5 @@ func main()       |
+// |  1  1   package main              |
+// |  2    - fmt.Println("hi")         |
+// |     2 + fmt.Println("hello")      |
+// +-----------------------------------+
+// Unified diff viewer: unified or side-by-side, line numbers, intraline
+// highlights, hunk navigation and folded context.
+// Copy this file into your project: bento add diff
+package diff
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+type KeyMap struct {
+	Up            bubbleskey.Binding
+	Down          bubbleskey.Binding
+	PageUp        bubbleskey.Binding
+	PageDown      bubbleskey.Binding
+	Top           bubbleskey.Binding
+	Bottom        bubbleskey.Binding
+	NextHunk      bubbleskey.Binding
+	PrevHunk      bubbleskey.Binding
+	ToggleSplit   bubbleskey.Binding
+	ToggleContext bubbleskey.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:            bubbleskey.NewBinding(bubbleskey.WithKeys("up", "k"), bubbleskey.WithHelp("↑/k", "scroll up")),
+		Down:          bubbleskey.NewBinding(bubbleskey.WithKeys("down", "j"), bubbleskey.WithHelp("↓/j", "scroll down")),
+		PageUp:        bubbleskey.NewBinding(bubbleskey.WithKeys("pgup", "b"), bubbleskey.WithHelp("pgup", "page up")),
+		PageDown:      bubbleskey.NewBinding(bubbleskey.WithKeys("pgdown", "space"), bubbleskey.WithHelp("pgdown", "page down")),
+		Top:           bubbleskey.NewBinding(bubbleskey.WithKeys("home", "g"), bubbleskey.WithHelp("g", "top")),
+		Bottom:        bubbleskey.NewBinding(bubbleskey.WithKeys("end", "G"), bubbleskey.WithHelp("G", "bottom")),
+		NextHunk:      bubbleskey.NewBinding(bubbleskey.WithKeys("n", "]"), bubbleskey.WithHelp("n", "next hunk")),
+		PrevHunk:      bubbleskey.NewBinding(bubbleskey.WithKeys("N", "["), bubbleskey.WithHelp("N", "previous hunk")),
+		ToggleSplit:   bubbleskey.NewBinding(bubbleskey.WithKeys("s"), bubbleskey.WithHelp("s", "side by side")),
+		ToggleContext: bubbleskey.NewBinding(bubbleskey.WithKeys("c"), bubbleskey.WithHelp("c", "expand context")),
+	}
+}
+
+// Scope and action IDs the diff bindings register under in a
+// keymap.Registry.
+const (
+	Scope               keymap.Scope = "diff"
+	ActionUp                         = "diff.up"
+	ActionDown                       = "diff.down"
+	ActionPageUp                     = "diff.pageup"
+	ActionPageDown                   = "diff.pagedown"
+	ActionTop                        = "diff.top"
+	ActionBottom                     = "diff.bottom"
+	ActionNextHunk                   = "diff.nexthunk"
+	ActionPrevHunk                   = "diff.prevhunk"
+	ActionToggleSplit                = "diff.split"
+	ActionToggleContext              = "diff.context"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionUp, km.Up),
+		keymap.FromKey(ActionDown, km.Down),
+		keymap.FromKey(ActionPageUp, km.PageUp),
+		keymap.FromKey(ActionPageDown, km.PageDown),
+		keymap.FromKey(ActionTop, km.Top),
+		keymap.FromKey(ActionBottom, km.Bottom),
+		keymap.FromKey(ActionNextHunk, km.NextHunk),
+		keymap.FromKey(ActionPrevHunk, km.PrevHunk),
+		keymap.FromKey(ActionToggleSplit, km.ToggleSplit),
+		keymap.FromKey(ActionToggleContext, km.ToggleContext),
+	}
+}
+
+const (
+	// wheelRows is how far one mouse wheel notch scrolls.
+	wheelRows = 3
+	// defaultContext is how many unchanged lines stay visible around each
+	// change while context is folded.
+	defaultContext = 3
+	tabWidth       = 4
+)
+
+type Option func(*Model)
+
+// Files sets the parsed diff to show.
+func Files(files ...File) Option { return func(m *Model) { m.SetFiles(files...) } }
+
+// Split starts in side-by-side mode.
+func Split() Option { return func(m *Model) { m.split = true } }
+
+// ContextLines keeps n unchanged lines around each change and folds longer runs
+// into one row (default 3).
+func ContextLines(n int) Option { return func(m *Model) { m.context = max(0, n) } }
+
+// WithTheme sets the theme for this diff instance.
+func WithTheme(t theme.Theme) Option { return func(m *Model) { m.theme = t } }
+
+type rowKind int
+
+const (
+	rowFile rowKind = iota
+	rowHunk
+	rowLine
+	rowFold
+)
+
+// row is one screen row. In unified mode a line row uses left only; in
+// side-by-side mode left is the old side and right the new, either nil
+// where the other side has no counterpart.
+type row struct {
+	kind        rowKind
+	file, hunk  int
+	left, right *Line
+	folded      int // lines hidden behind a fold row
+}
+
+// span is an intraline highlight, in runes of Line.Text.
+type span struct{ from, to int }
+
+// Model shows a parsed unified diff and scrolls through it.
+type Model struct {
+	files    []File
+	split    bool
+	context  int
+	expanded bool // show folded context in full
+	offset   int
+
+	rows      []row
+	intraline map[*Line]span
+	numWidth  int
+
+	width   int
+	height  int
+	focused bool
+	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
+	theme   theme.Theme      // nil = use theme.CurrentTheme()
+}
+
+func New(opts ...Option) *Model {
+	m := &Model{keys: DefaultKeyMap(), context: defaultContext}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.layout()
+	return m
+}
+
+// SetDiff parses unified diff text and shows it. On error the current diff
+// is kept.
+func (m *Model) SetDiff(text string) error {
+	files, err := Parse(text)
+	if err != nil {
+		return err
+	}
+	m.SetFiles(files...)
+	return nil
+}
+
+// SetFiles shows files and scrolls to the top.
+func (m *Model) SetFiles(files ...File) {
+	m.files = append([]File(nil), files...)
+	m.offset = 0
+	m.intraline = pairIntraline(m.files)
+	m.numWidth = 1
+	for _, f := range m.files {
+		for _, h := range f.Hunks {
+			m.numWidth = max(m.numWidth, len(strconv.Itoa(h.OldStart+h.OldLines)), len(strconv.Itoa(h.NewStart+h.NewLines)))
+		}
+	}
+	m.layout()
+}
+
+func (m *Model) Files() []File { return m.files }
+
+// SetSplit switches between side-by-side (true) and unified, keeping the
+// current hunk in view.
+func (m *Model) SetSplit(v bool) { m.relayout(func() { m.split = v }) }
+
+func (m *Model) IsSplit() bool { return m.split }
+
+// SetContextExpanded shows folded context in full (true) or folds it again.
+func (m *Model) SetContextExpanded(v bool) { m.relayout(func() { m.expanded = v }) }
+
+func (m *Model) IsContextExpanded() bool { return m.expanded }
+
+// relayout applies change and rebuilds the rows, keeping the top row's
+// hunk where it was.
+func (m *Model) relayout(change func()) {
+	var anchor row
+	if m.offset < len(m.rows) {
+		anchor = m.rows[m.offset]
+	}
+	change()
+	m.layout()
+	for i, r := range m.rows {
+		if r.file == anchor.file && r.hunk == anchor.hunk && r.kind == anchor.kind {
+			m.scrollTo(i)
+			return
+		}
+	}
+	m.scrollTo(m.offset)
+}
+
+// Offset is the first visible row.
+func (m *Model) Offset() int { return m.offset }
+
+// Hunk returns the file and hunk index of the hunk at the top of the view,
+// or -1, -1 above the first hunk.
+func (m *Model) Hunk() (file, hunk int) {
+	for i := min(m.offset, len(m.rows)-1); i >= 0; i-- {
+		if r := m.rows[i]; r.kind != rowFile {
+			return r.file, r.hunk
+		}
+	}
+	return -1, -1
+}
+
+// NextHunk scrolls the next hunk header to the top.
+func (m *Model) NextHunk() {
+	for i := m.offset + 1; i < len(m.rows); i++ {
+		if m.rows[i].kind == rowHunk {
+			m.scrollTo(i)
+			return
+		}
+	}
+}
+
+// PrevHunk scrolls the previous hunk header to the top.
+func (m *Model) PrevHunk() {
+	for i := min(m.offset, len(m.rows)) - 1; i >= 0; i-- {
+		if m.rows[i].kind == rowHunk {
+			m.scrollTo(i)
+			return
+		}
+	}
+}
+
+func (m *Model) scrollTo(i int) {
+	m.offset = max(0, min(i, len(m.rows)-m.height))
+}
+
+// layout flattens the files into screen rows.
+func (m *Model) layout() {
+	m.rows = m.rows[:0]
+	for fi, f := range m.files {
+		m.rows = append(m.rows, row{kind: rowFile, file: fi, hunk: -1})
+		for hi := range f.Hunks {
+			h := &m.files[fi].Hunks[hi]
+			m.rows = append(m.rows, row{kind: rowHunk, file: fi, hunk: hi})
+			base := row{kind: rowLine, file: fi, hunk: hi}
+			for _, part := range m.fold(h.Lines) {
+				if part.folded > 0 {
+					r := base
+					r.kind, r.folded = rowFold, part.folded
+					m.rows = append(m.rows, r)
+					continue
+				}
+				if !m.split {
+					for i := range part.lines {
+						r := base
+						r.left = &part.lines[i]
+						m.rows = append(m.rows, r)
+					}
+					continue
+				}
+				for _, pr := range pairLines(part.lines) {
+					r := base
+					r.left, r.right = pr[0], pr[1]
+					m.rows = append(m.rows, r)
+				}
+			}
+		}
+	}
+}
+
+// foldPart is a run of shown lines, or a fold standing in for hidden ones.
+type foldPart struct {
+	lines  []Line
+	folded int
+}
+
+// fold hides unchanged runs longer than twice the context, keeping context
+// lines next to each change. Runs at the hunk edges keep only the side
+// facing the change.
+func (m *Model) fold(lines []Line) []foldPart {
+	if m.expanded {
+		return []foldPart{{lines: lines}}
+	}
+	var parts []foldPart
+	for i := 0; i < len(lines); {
+		start := i
+		for i < len(lines) && lines[i].Kind == Context {
+			i++
+		}
+		if run := i - start; run > 0 {
+			keepHead, keepTail := m.context, m.context
+			if start == 0 {
+				keepHead = 0
+			}
+			if i == len(lines) {
+				keepTail = 0
+			}
+			if hidden := run - keepHead - keepTail; hidden > 1 {
+				parts = append(parts,
+					foldPart{lines: lines[start : start+keepHead]},
+					foldPart{folded: hidden},
+					foldPart{lines: lines[i-keepTail : i]})
+			} else {
+				parts = append(parts, foldPart{lines: lines[start:i]})
+			}
+		}
+		changes := i
+		for i < len(lines) && lines[i].Kind != Context {
+			i++
+		}
+		if i > changes {
+			parts = append(parts, foldPart{lines: lines[changes:i]})
+		}
+	}
+	return parts
+}
+
+// pairLines lines up the two sides of a side-by-side view: context with
+// itself, and each block of removals with the additions that follow it.
+func pairLines(lines []Line) [][2]*Line {
+	var out [][2]*Line
+	for i := 0; i < len(lines); {
+		if lines[i].Kind == Context {
+			out = append(out, [2]*Line{&lines[i], &lines[i]})
+			i++
+			continue
+		}
+		var del, add []*Line
+		for ; i < len(lines) && lines[i].Kind == Removed; i++ {
+			del = append(del, &lines[i])
+		}
+		for ; i < len(lines) && lines[i].Kind == Added; i++ {
+			add = append(add, &lines[i])
+		}
+		for j := range max(len(del), len(add)) {
+			var p [2]*Line
+			if j < len(del) {
+				p[0] = del[j]
+			}
+			if j < len(add) {
+				p[1] = add[j]
+			}
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// pairIntraline finds what changed inside each replaced line: a removal
+// and the addition at the same position in the block that follows it are
+// compared, and the middle between their common prefix and suffix is
+// highlighted. Lines with nothing in common get no highlight.
+func pairIntraline(files []File) map[*Line]span {
+	out := make(map[*Line]span)
+	for fi := range files {
+		for hi := range files[fi].Hunks {
+			for _, p := range pairLines(files[fi].Hunks[hi].Lines) {
+				if p[0] == nil || p[1] == nil || p[0] == p[1] {
+					continue
+				}
+				a, b := []rune(p[0].Text), []rune(p[1].Text)
+				pre := 0
+				for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+					pre++
+				}
+				suf := 0
+				for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+					suf++
+				}
+				// Widen to whole words, so "hi" → "hello" marks the word
+				// rather than "i" → "ello".
+				for pre > 0 && isWord(a[pre-1]) && (midWord(a, pre) || midWord(b, pre)) {
+					pre--
+				}
+				for suf > 0 && isWord(a[len(a)-suf]) && (midWord(a, len(a)-suf) || midWord(b, len(b)-suf)) {
+					suf--
+				}
+				if pre+suf == 0 {
+					continue
+				}
+				out[p[0]] = span{pre, len(a) - suf}
+				out[p[1]] = span{pre, len(b) - suf}
+			}
+		}
+	}
+	return out
+}
+
+func isWord(r rune) bool { return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) }
+
+// midWord reports whether position i of s falls inside a word.
+func midWord(s []rune, i int) bool {
+	return i > 0 && i < len(s) && isWord(s[i-1]) && isWord(s[i])
+}
+
+func (m *Model) Focus()          { m.focused = true }
+func (m *Model) Blur()           { m.focused = false }
+func (m *Model) IsFocused() bool { return m.focused }
+func (m *Model) Init() tea.Cmd   { return nil }
+
+func (m *Model) SetSize(width, height int) {
+	m.width, m.height = max(0, width), max(0, height)
+	m.scrollTo(m.offset)
+}
+
+func (m *Model) GetSize() (int, int) { return m.width, m.height }
+
+// SetTheme updates the theme. Call on ThemeChangedMsg.
+func (m *Model) SetTheme(t theme.Theme) { m.theme = t }
+
+func (m *Model) activeTheme() theme.Theme {
+	if m.theme != nil {
+		return m.theme
+	}
+	return theme.CurrentTheme()
+}
+
+// SetKeymap reads the diff keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{
+		Up:            r.KeyOr(ActionUp, k.Up),
+		Down:          r.KeyOr(ActionDown, k.Down),
+		PageUp:        r.KeyOr(ActionPageUp, k.PageUp),
+		PageDown:      r.KeyOr(ActionPageDown, k.PageDown),
+		Top:           r.KeyOr(ActionTop, k.Top),
+		Bottom:        r.KeyOr(ActionBottom, k.Bottom),
+		NextHunk:      r.KeyOr(ActionNextHunk, k.NextHunk),
+		PrevHunk:      r.KeyOr(ActionPrevHunk, k.PrevHunk),
+		ToggleSplit:   r.KeyOr(ActionToggleSplit, k.ToggleSplit),
+		ToggleContext: r.KeyOr(ActionToggleContext, k.ToggleContext),
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			m.scrollTo(m.offset - wheelRows)
+		case tea.MouseWheelDown:
+			m.scrollTo(m.offset + wheelRows)
+		}
+	case tea.KeyPressMsg:
+		if !m.focused {
+			return m, nil
+		}
+		keys := m.keyMap()
+		page := max(1, m.height)
+		switch {
+		case bubbleskey.Matches(msg, keys.Up):
+			m.scrollTo(m.offset - 1)
+		case bubbleskey.Matches(msg, keys.Down):
+			m.scrollTo(m.offset + 1)
+		case bubbleskey.Matches(msg, keys.PageUp):
+			m.scrollTo(m.offset - page)
+		case bubbleskey.Matches(msg, keys.PageDown):
+			m.scrollTo(m.offset + page)
+		case bubbleskey.Matches(msg, keys.Top):
+			m.scrollTo(0)
+		case bubbleskey.Matches(msg, keys.Bottom):
+			m.scrollTo(len(m.rows))
+		case bubbleskey.Matches(msg, keys.NextHunk):
+			m.NextHunk()
+		case bubbleskey.Matches(msg, keys.PrevHunk):
+			m.PrevHunk()
+		case bubbleskey.Matches(msg, keys.ToggleSplit):
+			m.SetSplit(!m.split)
+		case bubbleskey.Matches(msg, keys.ToggleContext):
+			m.SetContextExpanded(!m.expanded)
+		}
+	}
+	return m, nil
+}
+
+// ── rendering ─────────────────────────────────────────────────────────────────
+
+func (m *Model) View() tea.View {
+	if m.width <= 0 || m.height <= 0 {
+		return tea.NewView("")
+	}
+	t := m.activeTheme()
+	out := make([]string, 0, m.height)
+	for i := m.offset; len(out) < m.height; i++ {
+		if i >= len(m.rows) {
+			out = append(out, styles.Row(t.BackgroundPanel(), t.Text(), m.width, ""))
+			continue
+		}
+		out = append(out, m.renderRow(t, m.rows[i]))
+	}
+	return tea.NewView(strings.Join(out, "\n"))
+}
+
+func (m *Model) renderRow(t theme.Theme, r row) string {
+	switch r.kind {
+	case rowFile:
+		return m.renderFile(t, m.files[r.file])
+	case rowHunk:
+		bg := t.BackgroundPanel()
+		h := m.files[r.file].Hunks[r.hunk]
+		head := lipgloss.NewStyle().Background(bg).Foreground(t.Info()).Render(h.ranges())
+		if h.Section != "" {
+			head += lipgloss.NewStyle().Background(bg).Foreground(t.TextMuted()).Render(" " + h.Section)
+		}
+		return styles.RowClip(bg, t.TextMuted(), m.width, styles.Reopen(head, bg, t.TextMuted()))
+	case rowFold:
+		bg := t.DiffContextBG()
+		text := fmt.Sprintf("⋯ %d unchanged lines", r.folded)
+		return styles.RowClip(bg, t.DiffLineNum(), m.width, strings.Repeat(" ", m.numWidth)+" "+text)
+	}
+	if !m.split {
+		return m.renderUnified(t, r.left)
+	}
+	left := (m.width - 1) / 2
+	right := m.width - left - 1
+	sep := lipgloss.NewStyle().Background(t.BackgroundPanel()).Foreground(t.BorderSubtle()).Render("│")
+	if m.width < 3 {
+		sep = ""
+		right = m.width - left
+	}
+	return styles.RowClip(t.BackgroundPanel(), t.Text(), m.width,
+		m.renderSide(t, r.left, left, true)+sep+m.renderSide(t, r.right, right, false))
+}
+
+func (m *Model) renderFile(t theme.Theme, f File) string {
+	bg := t.BackgroundPanel()
+	base := lipgloss.NewStyle().Background(bg)
+	name := f.Name()
+	if f.OldName != "" && f.NewName != "" && f.OldName != f.NewName {
+		name = f.OldName + " → " + f.NewName
+	}
+	added, removed := f.Stats()
+	stats := base.Foreground(t.DiffAdded()).Render(fmt.Sprintf("+%d", added)) +
+		base.Render(" ") + base.Foreground(t.DiffRemoved()).Render(fmt.Sprintf("−%d", removed))
+	if f.Binary {
+		stats = base.Foreground(t.TextMuted()).Render("binary")
+	}
+	room := m.width - lipgloss.Width(stats) - 1
+	if room < 1 {
+		return styles.RowClip(bg, t.TextAccent(), m.width, base.Foreground(t.TextAccent()).Bold(true).Render(name))
+	}
+	title := base.Foreground(t.TextAccent()).Bold(true).Render(ansi.Truncate(name, room, "…"))
+	gap := base.Render(strings.Repeat(" ", max(1, m.width-lipgloss.Width(title)-lipgloss.Width(stats))))
+	return styles.RowClip(bg, t.Text(), m.width, styles.Reopen(title+gap+stats, bg, t.Text()))
+}
+
+// lineColors returns a line's row background, gutter background, gutter
+// foreground and intraline highlight for its kind.
+func lineColors(t theme.Theme, k LineKind) (bg, gutterBG, gutterFG, hl color.Color) {
+	switch k {
+	case Added:
+		return t.DiffAddedBG(), t.DiffAddedLineNumBG(), t.DiffAdded(), t.DiffHighlightAdded()
+	case Removed:
+		return t.DiffRemovedBG(), t.DiffRemovedLineNumBG(), t.DiffRemoved(), t.DiffHighlightRemoved()
+	}
+	return t.DiffContextBG(), t.DiffContextBG(), t.DiffLineNum(), nil
+}
+
+func marker(k LineKind) string {
+	switch k {
+	case Added:
+		return "+"
+	case Removed:
+		return "-"
+	}
+	return " "
+}
+
+func (m *Model) num(n int) string {
+	if n == 0 {
+		return strings.Repeat(" ", m.numWidth)
+	}
+	return fmt.Sprintf("%*d", m.numWidth, n)
+}
+
+// renderUnified draws "old new ± text" across the full width.
+func (m *Model) renderUnified(t theme.Theme, l *Line) string {
+	bg, gbg, gfg, _ := lineColors(t, l.Kind)
+	gutter := lipgloss.NewStyle().Background(gbg).Foreground(gfg).
+		Render(m.num(l.Old) + " " + m.num(l.New) + " ")
+	mark := lipgloss.NewStyle().Background(bg).Foreground(gfg).Render(marker(l.Kind) + " ")
+	return styles.RowClip(bg, t.Text(), m.width, styles.Reopen(gutter+mark+m.renderText(t, l), bg, t.Text()))
+}
+
+// renderSide draws one half of a side-by-side row: the old side's number
+// on the left, the new side's on the right. A missing line is left blank.
+func (m *Model) renderSide(t theme.Theme, l *Line, width int, old bool) string {
+	if width <= 0 {
+		return ""
+	}
+	if l == nil {
+		return styles.Row(t.BackgroundPanel(), t.Text(), width, "")
+	}
+	bg, gbg, gfg, _ := lineColors(t, l.Kind)
+	n := l.New
+	if old {
+		n = l.Old
+	}
+	gutter := lipgloss.NewStyle().Background(gbg).Foreground(gfg).Render(m.num(n) + " ")
+	mark := lipgloss.NewStyle().Background(bg).Foreground(gfg).Render(marker(l.Kind) + " ")
+	return styles.RowClip(bg, t.Text(), width, styles.Reopen(gutter+mark+m.renderText(t, l), bg, t.Text()))
+}
+
+// renderText draws a line's text with its intraline highlight.
+func (m *Model) renderText(t theme.Theme, l *Line) string {
+	bg, _, _, hl := lineColors(t, l.Kind)
+	base := lipgloss.NewStyle().Background(bg).Foreground(t.Text())
+	expand := func(s string) string { return strings.ReplaceAll(s, "\t", strings.Repeat(" ", tabWidth)) }
+	sp, ok := m.intraline[l]
+	if !ok || hl == nil || sp.from >= sp.to {
+		return base.Render(expand(l.Text))
+	}
+	r := []rune(l.Text)
+	return base.Render(expand(string(r[:sp.from]))) +
+		base.Background(hl).Render(expand(string(r[sp.from:sp.to]))) +
+		base.Render(expand(string(r[sp.to:])))
+}
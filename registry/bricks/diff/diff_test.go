@@ -0,0 +1,235 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+const sample = `diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,5 +1,6 @@ package main
+ package main
+ 
+-import "fmt"
++import (
++	"fmt"
++)
+ 
+ func main() {
+@@ -10,3 +11,3 @@ func main() {
+ 	x := 1
+-	fmt.Println("hi", x)
++	fmt.Println("hello", x)
+ }
+diff --git a/old.txt b/new.txt
+similarity index 90%
+rename from old.txt
+rename to new.txt
+--- a/old.txt
++++ b/new.txt
+@@ -1 +1 @@
+-one
+\ No newline at end of file
++two
+`
+
+func mustParse(t *testing.T, text string) []File {
+	t.Helper()
+	files, err := Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func TestParse(t *testing.T) {
+	files := mustParse(t, sample)
+	if len(files) != 2 {
+		t.Fatalf("files = %d, want 2", len(files))
+	}
+	f := files[0]
+	if f.Name() != "main.go" || len(f.Hunks) != 2 {
+		t.Fatalf("first file = %q with %d hunks", f.Name(), len(f.Hunks))
+	}
+	if a, r := f.Stats(); a != 4 || r != 2 {
+		t.Fatalf("stats = +%d -%d, want +4 -2", a, r)
+	}
+	h := f.Hunks[1]
+	if h.Header() != "@@ -10,3 +11,3 @@ func main() {" {
+		t.Fatalf("header = %q", h.Header())
+	}
+	if l := h.Lines[2]; l.Kind != Added || l.New != 12 || l.Old != 0 || l.Text != "\tfmt.Println(\"hello\", x)" {
+		t.Fatalf("added line = %+v", l)
+	}
+	if l := h.Lines[3]; l.Kind != Context || l.Old != 12 || l.New != 13 {
+		t.Fatalf("context line = %+v", l)
+	}
+
+	g := files[1]
+	if g.OldName != "old.txt" || g.NewName != "new.txt" || !g.Hunks[0].Lines[0].NoNewline {
+		t.Fatalf("renamed file = %+v", g)
+	}
+
+	if _, err := Parse("@@ -1,x +1 @@\n"); err == nil {
+		t.Fatal("a bad hunk header should fail")
+	}
+	if _, err := Parse("--- a/x\n+++ b/x\n@@ -1,2 +1,2 @@\n a\n"); err == nil {
+		t.Fatal("a short hunk should fail")
+	}
+	if files := mustParse(t, "--- /dev/null\n+++ b/new.go\n@@ -0,0 +1 @@\n+x\n"); files[0].OldName != "" || files[0].Name() != "new.go" {
+		t.Fatalf("new file = %+v", files[0])
+	}
+}
+
+func TestConformance(t *testing.T) {
+	for _, split := range []bool{false, true} {
+		t.Run(fmt.Sprintf("split=%v", split), func(t *testing.T) {
+			testkit.RunConformance(t, testkit.Conformance{
+				New: func() testkit.Brick {
+					m := New()
+					_ = m.SetDiff(sample)
+					m.SetSplit(split)
+					return m
+				},
+			})
+		})
+	}
+}
+
+func TestUnifiedRows(t *testing.T) {
+	m := New(Files(mustParse(t, sample)...))
+	m.SetSize(40, 12)
+	lines := testkit.FrameOf(m.View()).Lines()
+	want := []string{
+		"main.go",
+		"@@ -1,5 +1,6 @@ package main",
+		" 1  1   package main",
+		" 2  2",
+		" 3    - import \"fmt\"",
+		"    3 + import (",
+	}
+	for i, w := range want {
+		if got := strings.TrimRight(lines[i], " "); !strings.HasPrefix(got, w) || (i > 0 && got != w) {
+			t.Fatalf("row %d = %q, want %q\n%s", i, got, w, strings.Join(lines, "\n"))
+		}
+	}
+	if !strings.HasSuffix(lines[0], "+4 −2") {
+		t.Fatalf("file row should end with its stats: %q", lines[0])
+	}
+}
+
+func TestSplitPairsChanges(t *testing.T) {
+	m := New(Files(mustParse(t, sample)...), Split())
+	m.SetSize(60, 20)
+	lines := testkit.FrameOf(m.View()).Lines()
+	var pair string
+	for _, l := range lines {
+		if strings.Contains(l, `"hi"`) {
+			pair = l
+		}
+	}
+	if !strings.Contains(pair, `"hello"`) {
+		t.Fatalf("the removal and its replacement should share a row:\n%s", strings.Join(lines, "\n"))
+	}
+	if i := strings.Index(pair, "│"); i < 0 {
+		t.Fatalf("no divider in %q", pair)
+	}
+}
+
+func TestIntraline(t *testing.T) {
+	files := mustParse(t, sample)
+	spans := pairIntraline(files)
+	h := files[0].Hunks[1]
+	if sp := spans[&h.Lines[1]]; sp != (span{14, 16}) {
+		t.Fatalf("removed span = %+v, want the changed word", sp)
+	}
+	if sp := spans[&h.Lines[2]]; sp != (span{14, 19}) {
+		t.Fatalf("added span = %+v, want the changed word", sp)
+	}
+	if _, ok := spans[&files[1].Hunks[0].Lines[0]]; ok {
+		t.Fatal("lines with nothing in common should not be highlighted")
+	}
+}
+
+func TestHunkNavigation(t *testing.T) {
+	m := New(Files(mustParse(t, sample)...))
+	m.Focus()
+	d := testkit.Boot(t, m, 40, 4)
+	d.Press("n")
+	if f, h := m.Hunk(); f != 0 || h != 0 || m.Offset() != 1 {
+		t.Fatalf("hunk = %d/%d at offset %d", f, h, m.Offset())
+	}
+	d.Press("n").RequireContains("@@ -10,3 +11,3 @@")
+	d.Press("n").RequireContains("@@ -1 +1 @@") // the last hunk cannot reach the top
+	d.Press("N", "N")
+	if _, h := m.Hunk(); h != 0 {
+		t.Fatalf("hunk = %d after going back twice", h)
+	}
+	d.Press("s")
+	if !m.IsSplit() {
+		t.Fatal("s should switch to side-by-side")
+	}
+	if _, h := m.Hunk(); h != 0 {
+		t.Fatalf("switching modes should keep the hunk, got %d", h)
+	}
+}
+
+func TestFoldContext(t *testing.T) {
+	var b strings.Builder
+	b.WriteString("--- a/f\n+++ b/f\n@@ -1,20 +1,20 @@\n")
+	for i := 1; i <= 20; i++ {
+		if i == 10 {
+			b.WriteString("-old\n+new\n")
+			continue
+		}
+		fmt.Fprintf(&b, " line %d\n", i)
+	}
+	m := New(Files(mustParse(t, b.String())...), ContextLines(2))
+	m.Focus()
+	d := testkit.Boot(t, m, 40, 12)
+	d.RequireContains("⋯ 7 unchanged lines").RequireContains("⋯ 8 unchanged lines")
+	d.RequireContains("line 8").RequireNotContains("line 7").RequireContains("line 12").RequireNotContains("line 13")
+	d.Press("c").RequireContains("line 1").RequireNotContains("unchanged")
+	if !m.IsContextExpanded() {
+		t.Fatal("c should expand the context")
+	}
+}
+
+func TestWheelAndBlur(t *testing.T) {
+	m := New(Files(mustParse(t, sample)...))
+	d := testkit.Boot(t, m, 40, 4)
+	d.Press("n", "G")
+	if m.Offset() != 0 {
+		t.Fatal("a blurred diff should ignore keys")
+	}
+	d.Send(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if m.Offset() != 3 {
+		t.Fatalf("offset = %d after a wheel notch, want 3", m.Offset())
+	}
+}
+
+func TestSetKeymapUsesRegistryKeys(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(Scope, keymap.Binding{Action: ActionToggleSplit, Keys: []string{"v"}})
+	m := New(Files(mustParse(t, sample)...))
+	m.SetKeymap(r)
+	m.Focus()
+	m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	if m.IsSplit() {
+		t.Fatal("s should no longer toggle")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'v', Text: "v"})
+	if !m.IsSplit() {
+		t.Fatal("v should toggle")
+	}
+	if _, ok := r.Lookup(ActionNextHunk); !ok {
+		t.Fatal("SetKeymap should register the remaining defaults")
+	}
+}
@@ -0,0 +1,202 @@
+package diff
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// LineKind says which side of the diff a line belongs to.
+type LineKind int
+
+const (
+	Context LineKind = iota
+	Added
+	Removed
+)
+
+// Line is one line of a hunk. Old and New are 1-based line numbers, 0 on
+// the side the line is not on.
+type Line struct {
+	Kind      LineKind
+	Text      string
+	Old       int
+	New       int
+	NoNewline bool // followed by "\ No newline at end of file"
+}
+
+// Hunk is one @@ section.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Section            string // text after the closing @@, usually a function
+	Lines              []Line
+}
+
+// Header is the hunk's @@ line.
+func (h Hunk) Header() string {
+	if h.Section != "" {
+		return h.ranges() + " " + h.Section
+	}
+	return h.ranges()
+}
+
+// ranges is the header without the section.
+func (h Hunk) ranges() string {
+	return fmt.Sprintf("@@ -%s +%s @@", lineRange(h.OldStart, h.OldLines), lineRange(h.NewStart, h.NewLines))
+}
+
+func lineRange(start, n int) string {
+	if n == 1 {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d,%d", start, n)
+}
+
+// File is the diff of one file. A name is "" on the side where the file
+// does not exist.
+type File struct {
+	OldName string
+	NewName string
+	Binary  bool
+	Hunks   []Hunk
+}
+
+// Name is the new name, or the old one for a deleted file.
+func (f File) Name() string {
+	if f.NewName != "" {
+		return f.NewName
+	}
+	return f.OldName
+}
+
+// Stats counts the added and removed lines.
+func (f File) Stats() (added, removed int) {
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			switch l.Kind {
+			case Added:
+				added++
+			case Removed:
+				removed++
+			}
+		}
+	}
+	return added, removed
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// Parse reads unified diff text, as printed by git diff or diff -u. Lines
+// outside files and hunks, such as commit headers, are skipped.
+func Parse(text string) ([]File, error) {
+	var (
+		files            []File
+		f                *File
+		h                *Hunk
+		oldLeft, newLeft int // lines the current hunk still expects
+		oldN, newN       int
+	)
+	newFile := func() {
+		files = append(files, File{})
+		f, h = &files[len(files)-1], nil
+	}
+	for i, line := range strings.Split(strings.TrimSuffix(text, "\n"), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+
+		if h != nil && (oldLeft > 0 || newLeft > 0) {
+			l := Line{Text: line}
+			switch {
+			case line == "" || line[0] == ' ':
+				l.Kind, l.Old, l.New = Context, oldN, newN
+				oldN, newN, oldLeft, newLeft = oldN+1, newN+1, oldLeft-1, newLeft-1
+			case line[0] == '+':
+				l.Kind, l.New = Added, newN
+				newN, newLeft = newN+1, newLeft-1
+			case line[0] == '-':
+				l.Kind, l.Old = Removed, oldN
+				oldN, oldLeft = oldN+1, oldLeft-1
+			case line[0] == '\\':
+				if n := len(h.Lines); n > 0 {
+					h.Lines[n-1].NoNewline = true
+				}
+				continue
+			default:
+				return nil, fmt.Errorf("diff: line %d: want a hunk line, got %q", i+1, line)
+			}
+			if line != "" {
+				l.Text = line[1:]
+			}
+			h.Lines = append(h.Lines, l)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			newFile()
+			if a, b, ok := strings.Cut(line[len("diff --git "):], " b/"); ok {
+				f.OldName, f.NewName = trimName(a), b
+			}
+		case strings.HasPrefix(line, "--- "):
+			if f == nil || len(f.Hunks) > 0 {
+				newFile()
+			}
+			f.OldName = fileName(line[4:])
+		case strings.HasPrefix(line, "+++ ") && f != nil:
+			f.NewName = fileName(line[4:])
+		case strings.HasPrefix(line, "rename from ") && f != nil:
+			f.OldName = line[len("rename from "):]
+		case strings.HasPrefix(line, "rename to ") && f != nil:
+			f.NewName = line[len("rename to "):]
+		case strings.HasPrefix(line, "Binary files ") && f != nil:
+			f.Binary = true
+		case strings.HasPrefix(line, "@@"):
+			sm := hunkHeader.FindStringSubmatch(line)
+			if sm == nil {
+				return nil, fmt.Errorf("diff: line %d: bad hunk header %q", i+1, line)
+			}
+			if f == nil {
+				newFile()
+			}
+			hk := Hunk{OldStart: atoi(sm[1], 0), OldLines: atoi(sm[2], 1), NewStart: atoi(sm[3], 0), NewLines: atoi(sm[4], 1), Section: sm[5]}
+			f.Hunks = append(f.Hunks, hk)
+			h = &f.Hunks[len(f.Hunks)-1]
+			oldLeft, newLeft = hk.OldLines, hk.NewLines
+			oldN, newN = max(1, hk.OldStart), max(1, hk.NewStart)
+		case strings.HasPrefix(line, `\`) && h != nil:
+			if n := len(h.Lines); n > 0 {
+				h.Lines[n-1].NoNewline = true
+			}
+		}
+	}
+	if h != nil && (oldLeft > 0 || newLeft > 0) {
+		return nil, fmt.Errorf("diff: hunk %q ends early", h.Header())
+	}
+	return files, nil
+}
+
+// fileName reads the name from a ---/+++ line: "" for /dev/null, without
+// the a/ or b/ prefix and any trailing timestamp.
+func fileName(s string) string {
+	s, _, _ = strings.Cut(s, "\t")
+	if s == "/dev/null" {
+		return ""
+	}
+	return trimName(s)
+}
+
+func trimName(s string) string {
+	if strings.HasPrefix(s, "a/") || strings.HasPrefix(s, "b/") {
+		return s[2:]
+	}
+	return s
+}
+
+func atoi(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -13,9 +13,11 @@ import (
 	"strings"
 
 	bubblesfilepicker "charm.land/bubbles/v2/filepicker"
+	bubbleskey "charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 )
 
@@ -26,8 +28,24 @@ type Model struct {
 	focused      bool
 	selectedPath string
 	status       string
+	keymap       *keymap.Registry // nil = bubbles/filepicker defaults
 }
 
+// Scope and action IDs the picker bindings register under in a
+// keymap.Registry.
+const (
+	Scope          keymap.Scope = "filepicker"
+	ActionUp                    = "filepicker.up"
+	ActionDown                  = "filepicker.down"
+	ActionPageUp                = "filepicker.pageup"
+	ActionPageDown              = "filepicker.pagedown"
+	ActionTop                   = "filepicker.top"
+	ActionBottom                = "filepicker.bottom"
+	ActionBack                  = "filepicker.back"
+	ActionOpen                  = "filepicker.open"
+	ActionSelect                = "filepicker.select"
+)
+
 func New(startDir string) *Model {
 	fp := bubblesfilepicker.New()
 	fp.CurrentDirectory = cleanPath(startDir)
@@ -38,16 +56,56 @@ func New(startDir string) *Model {
 
 func (m *Model) Init() tea.Cmd { return m.picker.Init() }
 
+// SetKeymap reads the picker keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		km := bubblesfilepicker.DefaultKeyMap()
+		r.Register(Scope,
+			keymap.FromKey(ActionUp, km.Up),
+			keymap.FromKey(ActionDown, km.Down),
+			keymap.FromKey(ActionPageUp, km.PageUp),
+			keymap.FromKey(ActionPageDown, km.PageDown),
+			keymap.FromKey(ActionTop, km.GoToTop),
+			keymap.FromKey(ActionBottom, km.GoToLast),
+			keymap.FromKey(ActionBack, km.Back),
+			keymap.FromKey(ActionOpen, km.Open),
+			keymap.FromKey(ActionSelect, km.Select),
+		)
+	}
+	m.keymap = r
+	m.syncKeys()
+}
+
+// syncKeys copies the registry's keys into the inner picker.
+func (m *Model) syncKeys() {
+	def := bubblesfilepicker.DefaultKeyMap()
+	pick := m.keymap.KeyOr
+	m.picker.KeyMap = bubblesfilepicker.KeyMap{
+		Up:       pick(ActionUp, def.Up),
+		Down:     pick(ActionDown, def.Down),
+		PageUp:   pick(ActionPageUp, def.PageUp),
+		PageDown: pick(ActionPageDown, def.PageDown),
+		GoToTop:  pick(ActionTop, def.GoToTop),
+		GoToLast: pick(ActionBottom, def.GoToLast),
+		Back:     pick(ActionBack, def.Back),
+		Open:     pick(ActionOpen, def.Open),
+		Select:   pick(ActionSelect, def.Select),
+	}
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.SetSize(msg.Width, msg.Height)
 		return m, nil
+	case tea.MouseWheelMsg:
+		return m, m.wheel(msg.Button)
 	}
-	if !m.focused {
-		if _, ok := msg.(tea.KeyMsg); ok {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		if !m.focused {
 			return m, nil
 		}
+		m.syncKeys()
 	}
 	updated, cmd := m.picker.Update(msg)
 	m.picker = updated
@@ -61,6 +119,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// wheel moves the highlight one entry per notch. The inner picker only
+// reads keys, so the notch goes in as an up or down press against a keymap
+// that binds nothing else.
+func (m *Model) wheel(button tea.MouseButton) tea.Cmd {
+	press := tea.KeyPressMsg{Code: tea.KeyDown}
+	if button == tea.MouseWheelUp {
+		press = tea.KeyPressMsg{Code: tea.KeyUp}
+	} else if button != tea.MouseWheelDown {
+		return nil
+	}
+	m.picker.KeyMap = bubblesfilepicker.KeyMap{
+		Up:   bubbleskey.NewBinding(bubbleskey.WithKeys("up")),
+		Down: bubbleskey.NewBinding(bubbleskey.WithKeys("down")),
+	}
+	updated, cmd := m.picker.Update(press)
+	m.picker = updated
+	m.syncKeys()
+	return cmd
+}
+
 func (m *Model) View() tea.View {
 	m.syncStyles()
 	out := m.picker.View()
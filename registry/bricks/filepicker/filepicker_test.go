@@ -65,6 +65,32 @@ func TestDidSelectDisabledFileSetsBlockedStatus(t *testing.T) {
 	}
 }
 
+func TestWheelMovesHighlight(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", "b.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("write temp file: %v", err)
+		}
+	}
+
+	m := New(dir)
+	_, _ = m.Update(m.Init()())
+	_, _ = m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if got := filepath.Base(m.HighlightedPath()); got != "b.go" {
+		t.Fatalf("highlighted %q after wheel down, want b.go", got)
+	}
+	_, _ = m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if got := filepath.Base(m.HighlightedPath()); got != "a.go" {
+		t.Fatalf("highlighted %q after wheel up, want a.go", got)
+	}
+
+	// The wheel leaves the picker's own keys alone.
+	_, _ = m.Update(tea.KeyPressMsg(tea.Key{Code: 'j', Text: "j"}))
+	if got := filepath.Base(m.HighlightedPath()); got != "b.go" {
+		t.Fatalf("highlighted %q after j, want b.go", got)
+	}
+}
+
 func TestCleanPathDefaultsToDot(t *testing.T) {
 	if got := cleanPath("   "); got != "." {
 		t.Fatalf("expected dot path, got %q", got)
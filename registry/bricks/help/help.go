@@ -0,0 +1,416 @@
+// Brick: Help
+// +-----------------------------------+
+// | Global                            |
+// |   ctrl+k  palette                 |
+// |   q       quit                    |
+// | Tabs                              |
+// |   left    prev tab                |
+// +-----------------------------------+
+// Grouped, scrollable key binding cheat sheet, or a single-line hint strip
+// in compact mode.
+// Copy this file into your project: bento add help
+package help
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+// Group is one titled section of the cheat sheet, usually the bindings of
+// one brick or keymap scope.
+type Group struct {
+	Title    string
+	Bindings []bubbleskey.Binding
+}
+
+// FromBindings groups keymap bindings, e.g. a brick's KeyMap.Bindings().
+func FromBindings(title string, bindings ...keymap.Binding) Group {
+	g := Group{Title: title, Bindings: make([]bubbleskey.Binding, len(bindings))}
+	for i, b := range bindings {
+		g.Bindings[i] = b.Key()
+	}
+	return g
+}
+
+// FromKeymap returns one group per scope with the bindings that have help
+// text, in the order the scopes are given. With no scopes every scope is
+// listed in registration order. Groups are titled after their scope.
+func FromKeymap(r *keymap.Registry, scopes ...keymap.Scope) []Group {
+	byScope := make(map[keymap.Scope][]keymap.Binding)
+	order := scopes
+	for _, b := range r.Help(scopes...) {
+		if len(scopes) == 0 && byScope[b.Scope] == nil {
+			order = append(order, b.Scope)
+		}
+		byScope[b.Scope] = append(byScope[b.Scope], b)
+	}
+	groups := make([]Group, 0, len(order))
+	for _, s := range order {
+		if bs := byScope[s]; len(bs) > 0 {
+			groups = append(groups, FromBindings(scopeTitle(s), bs...))
+		}
+	}
+	return groups
+}
+
+func scopeTitle(s keymap.Scope) string {
+	r, n := utf8.DecodeRuneInString(string(s))
+	return string(unicode.ToUpper(r)) + string(s)[n:]
+}
+
+type KeyMap struct {
+	Up       bubbleskey.Binding
+	Down     bubbleskey.Binding
+	PageUp   bubbleskey.Binding
+	PageDown bubbleskey.Binding
+	Top      bubbleskey.Binding
+	Bottom   bubbleskey.Binding
+	Close    bubbleskey.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:       bubbleskey.NewBinding(bubbleskey.WithKeys("up", "k"), bubbleskey.WithHelp("↑/k", "scroll up")),
+		Down:     bubbleskey.NewBinding(bubbleskey.WithKeys("down", "j"), bubbleskey.WithHelp("↓/j", "scroll down")),
+		PageUp:   bubbleskey.NewBinding(bubbleskey.WithKeys("pgup", "ctrl+u"), bubbleskey.WithHelp("pgup", "page up")),
+		PageDown: bubbleskey.NewBinding(bubbleskey.WithKeys("pgdown", "ctrl+d"), bubbleskey.WithHelp("pgdown", "page down")),
+		Top:      bubbleskey.NewBinding(bubbleskey.WithKeys("home", "g"), bubbleskey.WithHelp("g", "top")),
+		Bottom:   bubbleskey.NewBinding(bubbleskey.WithKeys("end", "G"), bubbleskey.WithHelp("G", "bottom")),
+		Close:    bubbleskey.NewBinding(bubbleskey.WithKeys("esc", "?", "q"), bubbleskey.WithHelp("esc", "close")),
+	}
+}
+
+// Scope and action IDs the help bindings register under in a keymap.Registry.
+const (
+	Scope          keymap.Scope = "help"
+	ActionUp                    = "help.up"
+	ActionDown                  = "help.down"
+	ActionPageUp                = "help.pageup"
+	ActionPageDown              = "help.pagedown"
+	ActionTop                   = "help.top"
+	ActionBottom                = "help.bottom"
+	ActionClose                 = "help.close"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionUp, km.Up),
+		keymap.FromKey(ActionDown, km.Down),
+		keymap.FromKey(ActionPageUp, km.PageUp),
+		keymap.FromKey(ActionPageDown, km.PageDown),
+		keymap.FromKey(ActionTop, km.Top),
+		keymap.FromKey(ActionBottom, km.Bottom),
+		keymap.FromKey(ActionClose, km.Close),
+	}
+}
+
+// wheelRows is how far one mouse wheel notch scrolls the sheet.
+const wheelRows = 3
+
+type Option func(*Model)
+
+// Groups sets the groups to list.
+func Groups(groups ...Group) Option {
+	return func(m *Model) { m.SetGroups(groups...) }
+}
+
+// Compact renders every binding on one row, for a footer or status line,
+// instead of the grouped sheet.
+func Compact() Option { return func(m *Model) { m.compact = true } }
+
+// OnClose sets the message emitted when a Close key is pressed, e.g.
+// dialog.Close when the sheet is hosted in a dialog.
+func OnClose(fn func() tea.Msg) Option { return func(m *Model) { m.onClose = fn } }
+
+// WithTheme sets the theme for this help instance.
+func WithTheme(t theme.Theme) Option { return func(m *Model) { m.theme = t } }
+
+// Model lists key bindings. The grouped sheet scrolls when it is taller than
+// its pane; compact mode fits as many bindings as the row allows.
+type Model struct {
+	groups  []Group
+	compact bool
+	offset  int
+	width   int
+	height  int
+	focused bool
+	onClose func() tea.Msg
+	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
+	theme   theme.Theme      // nil = use theme.CurrentTheme()
+}
+
+func New(opts ...Option) *Model {
+	m := &Model{keys: DefaultKeyMap()}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SetGroups replaces the listed groups and scrolls back to the top.
+func (m *Model) SetGroups(groups ...Group) {
+	m.groups = append([]Group(nil), groups...)
+	m.offset = 0
+}
+
+func (m *Model) SetCompact(v bool)   { m.compact = v }
+func (m *Model) IsCompact() bool     { return m.compact }
+func (m *Model) Offset() int         { return m.offset }
+func (m *Model) Focus()              { m.focused = true }
+func (m *Model) Blur()               { m.focused = false }
+func (m *Model) IsFocused() bool     { return m.focused }
+func (m *Model) Init() tea.Cmd       { return nil }
+func (m *Model) SetSize(w, h int)    { m.width, m.height = w, h; m.scrollTo(m.offset) }
+func (m *Model) GetSize() (int, int) { return m.width, m.rows() }
+
+// SetTheme updates the theme. Call on ThemeChangedMsg.
+func (m *Model) SetTheme(t theme.Theme) { m.theme = t }
+
+func (m *Model) activeTheme() theme.Theme {
+	if m.theme != nil {
+		return m.theme
+	}
+	return theme.CurrentTheme()
+}
+
+// SetKeymap reads the help keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{
+		Up:       r.KeyOr(ActionUp, k.Up),
+		Down:     r.KeyOr(ActionDown, k.Down),
+		PageUp:   r.KeyOr(ActionPageUp, k.PageUp),
+		PageDown: r.KeyOr(ActionPageDown, k.PageDown),
+		Top:      r.KeyOr(ActionTop, k.Top),
+		Bottom:   r.KeyOr(ActionBottom, k.Bottom),
+		Close:    r.KeyOr(ActionClose, k.Close),
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if w, ok := msg.(tea.MouseWheelMsg); ok && !m.compact {
+		switch w.Button {
+		case tea.MouseWheelUp:
+			m.scrollTo(m.offset - wheelRows)
+		case tea.MouseWheelDown:
+			m.scrollTo(m.offset + wheelRows)
+		}
+		return m, nil
+	}
+	k, ok := msg.(tea.KeyMsg)
+	if !ok || !m.focused {
+		return m, nil
+	}
+	keys := m.keyMap()
+	if bubbleskey.Matches(k, keys.Close) && m.onClose != nil {
+		return m, m.onClose
+	}
+	if m.compact {
+		return m, nil
+	}
+	page := max(1, m.viewRows()-1)
+	switch {
+	case bubbleskey.Matches(k, keys.Up):
+		m.scrollTo(m.offset - 1)
+	case bubbleskey.Matches(k, keys.Down):
+		m.scrollTo(m.offset + 1)
+	case bubbleskey.Matches(k, keys.PageUp):
+		m.scrollTo(m.offset - page)
+	case bubbleskey.Matches(k, keys.PageDown):
+		m.scrollTo(m.offset + page)
+	case bubbleskey.Matches(k, keys.Top):
+		m.scrollTo(0)
+	case bubbleskey.Matches(k, keys.Bottom):
+		m.scrollTo(len(m.lines()))
+	}
+	return m, nil
+}
+
+func (m *Model) View() tea.View {
+	t := m.activeTheme()
+	if m.compact {
+		return tea.NewView(m.renderCompact(t))
+	}
+	return tea.NewView(m.renderSheet(t))
+}
+
+// ── sheet ─────────────────────────────────────────────────────────────────────
+
+type helpEntry struct{ key, desc string }
+
+// helpLine is one sheet row: a group title or a binding.
+type helpLine struct {
+	title string
+	entry helpEntry
+}
+
+// entries returns the bindings of g worth listing: enabled, with help text.
+func entries(g Group) []helpEntry {
+	out := make([]helpEntry, 0, len(g.Bindings))
+	for _, b := range g.Bindings {
+		h := b.Help()
+		if !b.Enabled() || h.Desc == "" {
+			continue
+		}
+		k := h.Key
+		if k == "" && len(b.Keys()) > 0 {
+			k = b.Keys()[0]
+		}
+		out = append(out, helpEntry{key: k, desc: h.Desc})
+	}
+	return out
+}
+
+func (m *Model) lines() []helpLine {
+	var out []helpLine
+	for _, g := range m.groups {
+		es := entries(g)
+		if len(es) == 0 {
+			continue
+		}
+		if len(out) > 0 {
+			out = append(out, helpLine{})
+		}
+		if g.Title != "" {
+			out = append(out, helpLine{title: g.Title})
+		}
+		for _, e := range es {
+			out = append(out, helpLine{entry: e})
+		}
+	}
+	return out
+}
+
+// rows is the rendered height: one row in compact mode, the pane height
+// otherwise, or every line when no height was set.
+func (m *Model) rows() int {
+	switch {
+	case m.compact:
+		return 1
+	case m.height > 0:
+		return m.height
+	default:
+		return len(m.lines())
+	}
+}
+
+// viewRows is how many lines fit above the scroll indicator.
+func (m *Model) viewRows() int {
+	n := len(m.lines())
+	if m.height <= 0 || n <= m.height {
+		return n
+	}
+	return max(1, m.height-1)
+}
+
+func (m *Model) scrollTo(offset int) {
+	m.offset = max(0, min(offset, len(m.lines())-m.viewRows()))
+}
+
+func (m *Model) renderSheet(t theme.Theme) string {
+	bg, fg := t.DialogBG(), t.DialogFG()
+	lines := m.lines()
+	keyWidth := 0
+	for _, l := range lines {
+		keyWidth = max(keyWidth, lipgloss.Width(l.entry.key))
+	}
+
+	titleStyle := lipgloss.NewStyle().Background(bg).Foreground(t.TextAccent()).Bold(true)
+	keyStyle := lipgloss.NewStyle().Background(bg).Foreground(fg).Bold(true).Width(keyWidth)
+	descStyle := lipgloss.NewStyle().Background(bg).Foreground(t.TextMuted())
+	render := func(l helpLine) string {
+		switch {
+		case l.title != "":
+			return titleStyle.Render(l.title)
+		case l.entry.key == "":
+			return ""
+		}
+		return "  " + keyStyle.Render(l.entry.key) + "  " + descStyle.Render(l.entry.desc)
+	}
+
+	width := m.width
+	if width <= 0 {
+		for _, l := range lines {
+			width = max(width, lipgloss.Width(render(l)))
+		}
+	}
+	if width <= 0 {
+		return ""
+	}
+
+	visible := m.viewRows()
+	start := min(m.offset, max(0, len(lines)-visible))
+	rows := make([]string, 0, m.rows())
+	for _, l := range lines[start : start+visible] {
+		rows = append(rows, styles.RowClip(bg, fg, width, styles.Reopen(render(l), bg, fg)))
+	}
+	if visible < len(lines) && len(rows) < m.rows() {
+		pos := fmt.Sprintf("%d–%d of %d", start+1, start+visible, len(lines))
+		pad := max(0, width-lipgloss.Width(pos))
+		rows = append(rows, styles.RowClip(bg, t.TextMuted(), width, strings.Repeat(" ", pad)+pos))
+	}
+	for len(rows) < m.rows() {
+		rows = append(rows, styles.Row(bg, fg, width, ""))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// ── compact ───────────────────────────────────────────────────────────────────
+
+// renderCompact lays the bindings out on one footer row. Bindings that do
+// not fit are dropped from the end and replaced by an ellipsis.
+func (m *Model) renderCompact(t theme.Theme) string {
+	bg, fg, muted := t.FooterBG(), t.FooterFG(), t.FooterMuted()
+	keyStyle := lipgloss.NewStyle().Background(bg).Foreground(fg).Bold(true)
+	descStyle := lipgloss.NewStyle().Background(bg).Foreground(muted)
+	const gap, more = "  ", "…"
+
+	var parts []string
+	for _, g := range m.groups {
+		for _, e := range entries(g) {
+			parts = append(parts, keyStyle.Render(e.key)+" "+descStyle.Render(e.desc))
+		}
+	}
+	line := ""
+	for i, p := range parts {
+		next := p
+		if i > 0 {
+			next = line + gap + p
+		}
+		room := m.width
+		if i < len(parts)-1 {
+			room -= lipgloss.Width(gap + more)
+		}
+		if m.width > 0 && lipgloss.Width(next) > room {
+			if i == 0 {
+				line = p // clipped below
+			} else {
+				line += gap + descStyle.Render(more)
+			}
+			break
+		}
+		line = next
+	}
+	if m.width <= 0 {
+		return styles.Reopen(line, bg, fg)
+	}
+	return styles.RowClip(bg, fg, m.width, styles.Reopen(line, bg, fg))
+}
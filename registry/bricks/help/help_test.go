@@ -0,0 +1,142 @@
+package help
+
+import (
+	"strings"
+	"testing"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func binding(keys, help, desc string) bubbleskey.Binding {
+	return bubbleskey.NewBinding(bubbleskey.WithKeys(strings.Fields(keys)...), bubbleskey.WithHelp(help, desc))
+}
+
+func sample() []Group {
+	return []Group{
+		{Title: "Global", Bindings: []bubbleskey.Binding{
+			binding("ctrl+k", "ctrl+k", "palette"),
+			binding("q ctrl+c", "q", "quit"),
+		}},
+		{Title: "Tabs", Bindings: []bubbleskey.Binding{
+			binding("left h", "left", "prev tab"),
+			binding("right l", "right", "next tab"),
+		}},
+	}
+}
+
+func TestConformance(t *testing.T) {
+	t.Run("sheet", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New: func() testkit.Brick { return New(Groups(sample()...)) },
+		})
+	})
+	t.Run("compact", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New:         func() testkit.Brick { return New(Groups(sample()...), Compact()) },
+			FixedHeight: 1,
+		})
+	})
+}
+
+func TestSheetGroupsAndAlignsKeys(t *testing.T) {
+	hidden := binding("x", "x", "hidden")
+	hidden.SetEnabled(false)
+	groups := append(sample(), Group{Title: "Empty", Bindings: []bubbleskey.Binding{hidden, binding("y", "y", "")}})
+
+	m := New(Groups(groups...))
+	m.SetSize(30, 8)
+	want := []string{
+		"Global",
+		"  ctrl+k  palette",
+		"  q       quit",
+		"",
+		"Tabs",
+		"  left    prev tab",
+		"  right   next tab",
+		"",
+	}
+	got := testkit.FrameOf(m.View()).Lines()
+	for i, w := range want {
+		if strings.TrimRight(got[i], " ") != w {
+			t.Fatalf("row %d = %q, want %q\n%s", i, got[i], w, strings.Join(got, "\n"))
+		}
+	}
+}
+
+func TestSheetScrolls(t *testing.T) {
+	m := New(Groups(sample()...))
+	m.SetSize(30, 4)
+	m.Focus()
+
+	d := testkit.Boot(t, m, 30, 4)
+	d.RequireContains("Global").RequireContains("1–3 of 7")
+	d.Press("j", "j").RequireNotContains("Global").RequireContains("3–5 of 7")
+	d.Press("G").RequireContains("next tab").RequireContains("5–7 of 7")
+	if m.Offset() != 4 {
+		t.Fatalf("offset = %d at the bottom, want 4", m.Offset())
+	}
+	d.Press("j").RequireContains("5–7 of 7")
+	d.Press("g").RequireContains("Global")
+
+	m.Blur()
+	d.Press("G").RequireContains("Global")
+
+	m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if m.Offset() != 3 {
+		t.Fatalf("offset = %d after a wheel notch, want 3", m.Offset())
+	}
+}
+
+func TestCompactFitsOneRow(t *testing.T) {
+	m := New(Groups(sample()...), Compact())
+	m.SetSize(80, 1)
+	got := testkit.FrameOf(m.View()).Lines()
+	if len(got) != 1 || !strings.HasPrefix(got[0], "ctrl+k palette  q quit  left prev tab  right next tab") {
+		t.Fatalf("compact row = %q", got)
+	}
+
+	m.SetSize(28, 1)
+	got = testkit.FrameOf(m.View()).Lines()
+	if strings.TrimRight(got[0], " ") != "ctrl+k palette  q quit  …" {
+		t.Fatalf("narrow compact row = %q", got[0])
+	}
+}
+
+func TestCloseEmitsOnClose(t *testing.T) {
+	type closed struct{}
+	m := New(Groups(sample()...), OnClose(func() tea.Msg { return closed{} }))
+	m.Focus()
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if cmd == nil {
+		t.Fatal("esc should close")
+	}
+	if _, ok := cmd().(closed); !ok {
+		t.Fatalf("close emitted %T", cmd())
+	}
+}
+
+func TestFromKeymapGroupsByScope(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(keymap.Global,
+		keymap.Binding{Action: "app.quit", Keys: []string{"q"}, Help: "quit"},
+		keymap.Binding{Action: "app.none", Keys: []string{"z"}},
+	)
+	r.Register("tabs", keymap.Binding{Action: "tabs.next", Keys: []string{"l"}, HelpKey: "→", Help: "next tab"})
+	r.Register("palette", keymap.Binding{Action: "palette.up", Help: "up"})
+
+	groups := FromKeymap(r)
+	if len(groups) != 2 || groups[0].Title != "Global" || groups[1].Title != "Tabs" {
+		t.Fatalf("groups = %+v", groups)
+	}
+	if h := groups[1].Bindings[0].Help(); h.Key != "→" || h.Desc != "next tab" {
+		t.Fatalf("tabs binding help = %+v", h)
+	}
+
+	groups = FromKeymap(r, "tabs", keymap.Global)
+	if len(groups) != 2 || groups[0].Title != "Tabs" {
+		t.Fatalf("scoped groups should follow the given order, got %+v", groups)
+	}
+}
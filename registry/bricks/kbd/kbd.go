@@ -12,6 +12,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 )
 
@@ -29,6 +30,14 @@ func New(command, label string) *Model {
 	return &Model{command: command, label: label, active: true, variant: "normal"}
 }
 
+// FromBinding shows a keymap binding's key and help. A binding with no keys
+// renders inactive.
+func FromBinding(b keymap.Binding) *Model {
+	m := New(b.Label(), b.Help)
+	m.SetActive(len(b.Keys) > 0)
+	return m
+}
+
 func (m *Model) SetCommand(v string)                 { m.command = v }
 func (m *Model) SetLabel(v string)                   { m.label = v }
 func (m *Model) SetActive(v bool)                    { m.active = v }
@@ -16,6 +16,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 	"github.com/cloudboy-jh/bentotui/theme/styles"
 )
@@ -70,9 +71,22 @@ type Model struct {
 	formatter RowFormatter
 	inner     bubbleslist.Model
 	delegate  *rowDelegate
-	theme     theme.Theme // nil = use theme.CurrentTheme()
+	keymap    *keymap.Registry // nil = bubbles/list defaults
+	theme     theme.Theme      // nil = use theme.CurrentTheme()
 }
 
+// Scope and action IDs the list bindings register under in a
+// keymap.Registry.
+const (
+	Scope          keymap.Scope = "list"
+	ActionUp                    = "list.up"
+	ActionDown                  = "list.down"
+	ActionPrevPage              = "list.prevpage"
+	ActionNextPage              = "list.nextpage"
+	ActionTop                   = "list.top"
+	ActionBottom                = "list.bottom"
+)
+
 type Density string
 
 const (
@@ -218,6 +232,9 @@ func (l *Model) SetDensity(v Density) {
 	l.syncInner()
 }
 
+// Cursor is the index of the selected item, not counting section rows.
+func (l *Model) Cursor() int { return l.cursor }
+
 func (l *Model) SetCursor(i int) {
 	count := l.itemCount()
 	if count <= 0 {
@@ -247,16 +264,55 @@ func (l *Model) activeTheme() theme.Theme {
 
 func (l *Model) Init() tea.Cmd { return nil }
 
+// SetKeymap reads the list keys from r.
+func (l *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		km := bubbleslist.DefaultKeyMap()
+		r.Register(Scope,
+			keymap.FromKey(ActionUp, km.CursorUp),
+			keymap.FromKey(ActionDown, km.CursorDown),
+			keymap.FromKey(ActionPrevPage, km.PrevPage),
+			keymap.FromKey(ActionNextPage, km.NextPage),
+			keymap.FromKey(ActionTop, km.GoToStart),
+			keymap.FromKey(ActionBottom, km.GoToEnd),
+		)
+	}
+	l.keymap = r
+	l.syncKeys()
+}
+
+// syncKeys copies the registry's keys into the inner list.
+func (l *Model) syncKeys() {
+	def := bubbleslist.DefaultKeyMap()
+	pick := l.keymap.KeyOr
+	km := &l.inner.KeyMap
+	km.CursorUp = pick(ActionUp, def.CursorUp)
+	km.CursorDown = pick(ActionDown, def.CursorDown)
+	km.PrevPage = pick(ActionPrevPage, def.PrevPage)
+	km.NextPage = pick(ActionNextPage, def.NextPage)
+	km.GoToStart = pick(ActionTop, def.GoToStart)
+	km.GoToEnd = pick(ActionBottom, def.GoToEnd)
+}
+
 func (l *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		l.SetSize(msg.Width, msg.Height)
 		return l, nil
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			l.SetCursor(l.cursor - 1)
+		case tea.MouseWheelDown:
+			l.SetCursor(l.cursor + 1)
+		}
+		return l, nil
 	}
-	if !l.focused {
-		if _, ok := msg.(tea.KeyMsg); ok {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		if !l.focused {
 			return l, nil
 		}
+		l.syncKeys()
 	}
 	updated, cmd := l.inner.Update(msg)
 	l.inner = updated
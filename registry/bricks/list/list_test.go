@@ -7,6 +7,8 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
 )
 
 func nonEmptyLines(s string) []string {
@@ -141,6 +143,26 @@ func TestBlurBlocksNavigation(t *testing.T) {
 	}
 }
 
+func TestWheelMovesCursorWithoutFocus(t *testing.T) {
+	l := New(20)
+	l.SetSize(24, 4)
+	l.AppendSection("TODAY")
+	l.Append("one")
+	l.Append("two")
+	l.Blur()
+
+	down := tea.MouseWheelMsg{Button: tea.MouseWheelDown}
+	_, _ = l.Update(down)
+	_, _ = l.Update(down)
+	if l.Cursor() != 1 {
+		t.Fatalf("cursor = %d after scrolling past the end, want 1", l.Cursor())
+	}
+	_, _ = l.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if out := ansi.Strip(viewString(l.View())); !strings.Contains(out, "> one") {
+		t.Fatalf("expected 'one' selected after wheel up, got:\n%s", out)
+	}
+}
+
 func TestWindowSizeMsgAppliesSize(t *testing.T) {
 	l := New(20)
 	_, _ = l.Update(tea.WindowSizeMsg{Width: 40, Height: 7})
@@ -149,3 +171,44 @@ func TestWindowSizeMsgAppliesSize(t *testing.T) {
 		t.Fatalf("expected size 40x7, got %dx%d", w, h)
 	}
 }
+
+func TestConformance(t *testing.T) {
+	testkit.RunConformance(t, testkit.Conformance{
+		New: func() testkit.Brick {
+			l := New(20)
+			l.AppendSection("services")
+			l.AppendRow(Row{Primary: "api", RightStat: "12ms", Tone: ToneSuccess})
+			l.AppendRow(Row{Primary: "worker", RightStat: "3 jobs", Tone: ToneWarn})
+			return l
+		},
+		WindowSize: true,
+		// Rows below the last item are left for the canvas to show through.
+		Transparent: true,
+	})
+}
+
+func TestKeymapFileRemapsNavigation(t *testing.T) {
+	r := keymap.NewRegistry()
+	l := New(10)
+	l.SetSize(20, 5)
+	l.Append("a")
+	l.Append("b")
+	l.Append("c")
+	l.SetKeymap(r)
+	if err := r.Load(strings.NewReader("list.down = ctrl+n\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	l.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if l.cursor != 0 {
+		t.Fatal("j should no longer move down after the remap")
+	}
+	l.Update(tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	if l.cursor != 1 {
+		t.Fatalf("ctrl+n should move down, cursor %d", l.cursor)
+	}
+	l.Update(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	if l.cursor != 0 {
+		t.Fatal("unmapped actions keep their defaults")
+	}
+}
@@ -18,6 +18,7 @@ import (
 	bubbleslist "charm.land/bubbles/v2/list"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 	"github.com/cloudboy-jh/bentotui/theme/styles"
 )
@@ -37,7 +38,7 @@ type KeyMap struct {
 
 func DefaultKeyMap() KeyMap {
 	return KeyMap{
-		Toggle: bubbleskey.NewBinding(bubbleskey.WithKeys("enter", " "), bubbleskey.WithHelp("enter", "open")),
+		Toggle: bubbleskey.NewBinding(bubbleskey.WithKeys("enter", "space"), bubbleskey.WithHelp("enter", "open")),
 		Close:  bubbleskey.NewBinding(bubbleskey.WithKeys("esc"), bubbleskey.WithHelp("esc", "close")),
 		Up:     bubbleskey.NewBinding(bubbleskey.WithKeys("up", "k"), bubbleskey.WithHelp("up", "up")),
 		Down:   bubbleskey.NewBinding(bubbleskey.WithKeys("down", "j"), bubbleskey.WithHelp("down", "down")),
@@ -45,6 +46,28 @@ func DefaultKeyMap() KeyMap {
 	}
 }
 
+// Scope and action IDs the select bindings register under in a
+// keymap.Registry.
+const (
+	Scope        keymap.Scope = "select"
+	ActionToggle              = "select.toggle"
+	ActionClose               = "select.close"
+	ActionUp                  = "select.up"
+	ActionDown                = "select.down"
+	ActionChoose              = "select.choose"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionToggle, km.Toggle),
+		keymap.FromKey(ActionClose, km.Close),
+		keymap.FromKey(ActionUp, km.Up),
+		keymap.FromKey(ActionDown, km.Down),
+		keymap.FromKey(ActionChoose, km.Choose),
+	}
+}
+
 type selectItem struct {
 	label string
 	value string
@@ -108,6 +131,7 @@ type Model struct {
 	height      int
 	inner       bubbleslist.Model
 	keys        KeyMap
+	keymap      *keymap.Registry // nil = use keys
 }
 
 func New(items ...Item) *Model {
@@ -198,6 +222,25 @@ func (m *Model) Value() string {
 	return item.Label
 }
 
+// SetKeymap reads the select keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{
+		Toggle: r.KeyOr(ActionToggle, k.Toggle),
+		Close:  r.KeyOr(ActionClose, k.Close),
+		Up:     r.KeyOr(ActionUp, k.Up),
+		Down:   r.KeyOr(ActionDown, k.Down),
+		Choose: r.KeyOr(ActionChoose, k.Choose),
+	}
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if !m.focused {
 		return m, nil
@@ -206,23 +249,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if !ok {
 		return m, nil
 	}
+	keys := m.keyMap()
 
 	if !m.open {
-		if bubbleskey.Matches(k, m.keys.Toggle, m.keys.Choose) {
+		if bubbleskey.Matches(k, keys.Toggle, keys.Choose) {
 			m.Open()
 		}
 		return m, nil
 	}
 
-	if bubbleskey.Matches(k, m.keys.Close) {
+	if bubbleskey.Matches(k, keys.Close) {
 		m.Close()
 		return m, nil
 	}
 
+	m.inner.KeyMap.CursorUp = keys.Up
+	m.inner.KeyMap.CursorDown = keys.Down
 	updated, cmd := m.inner.Update(msg)
 	m.inner = updated
 	m.cursor = m.inner.Index()
-	if bubbleskey.Matches(k, m.keys.Choose) {
+	if bubbleskey.Matches(k, keys.Choose) {
 		m.selected = m.cursor
 		m.open = false
 	}
@@ -0,0 +1,149 @@
+package surface
+
+import (
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// Begin starts a new frame on a retained surface. Keep one Surface on your
+// model (the zero value is ready to use) and call Begin at the top of View()
+// instead of New:
+//
+//	m.surf.Begin(m.width, m.height)
+//	m.surf.Fill(t.Background())
+//	m.surf.Draw(0, 0, screen)
+//	v := tea.NewView(m.surf.Render())
+//
+// The cells of the last frame are kept for diffing; the buffer that held the
+// frame before that is cleared and reused, so steady-state frames allocate no
+// new cell storage. A size change drops all retained state and the next
+// Render serializes the full frame, as it does when the previous frame was
+// never rendered: the cached rows are then older than the frame being
+// diffed against.
+func (s *Surface) Begin(width, height int) {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	if s.frame == nil {
+		s.frame = &frame{}
+	}
+	s.pending = [layerCount][]func(){}
+	if !s.rendered {
+		s.rows = nil
+	}
+	s.rendered = false
+	if width != s.width || height != s.height || s.buf.Buffer == nil {
+		s.buf = uv.NewScreenBuffer(width, height)
+		s.width = width
+		s.height = height
+		s.prev = nil
+		s.rows = nil
+		s.memo = nil
+		return
+	}
+
+	next := s.prev
+	s.prev = s.buf.Buffer
+	if next == nil {
+		next = uv.NewBuffer(width, height)
+	} else {
+		next.Clear()
+	}
+	s.buf.Buffer = next
+
+	for key, e := range s.memo {
+		if !e.used {
+			delete(s.memo, key)
+			continue
+		}
+		e.used = false
+	}
+}
+
+// DirtyRows returns the rows whose cells differ from the previous frame, in
+// ascending order. Every row is dirty on the first frame, after a resize, and
+// on surfaces created with New.
+func (s *Surface) DirtyRows() []int {
+	s.composite()
+	out := make([]int, 0, s.height)
+	for y := 0; y < s.height; y++ {
+		if _, _, changed := s.rowSpan(y); changed {
+			out = append(out, y)
+		}
+	}
+	return out
+}
+
+// Damage returns one rectangle per dirty row covering the span of cells that
+// changed since the previous frame. Rectangles are in surface coordinates and
+// ordered top to bottom.
+func (s *Surface) Damage() []uv.Rectangle {
+	s.composite()
+	out := make([]uv.Rectangle, 0)
+	for y := 0; y < s.height; y++ {
+		x0, x1, changed := s.rowSpan(y)
+		if changed {
+			out = append(out, uv.Rect(x0, y, x1-x0, 1))
+		}
+	}
+	return out
+}
+
+// rowSpan returns the half-open column span [x0, x1) of cells in row y that
+// differ from the previous frame.
+func (s *Surface) rowSpan(y int) (x0, x1 int, changed bool) {
+	if s.prev == nil || s.prev.Width() != s.width || s.prev.Height() != s.height {
+		return 0, s.width, true
+	}
+	cur := s.buf.Line(y)
+	old := s.prev.Line(y)
+	x0 = -1
+	for x := 0; x < s.width; x++ {
+		if cur.At(x).Equal(old.At(x)) {
+			continue
+		}
+		if x0 < 0 {
+			x0 = x
+		}
+		x1 = x + 1
+	}
+	if x0 < 0 {
+		return 0, 0, false
+	}
+	return x0, x1, true
+}
+
+// ── memo ──────────────────────────────────────────────────────────────────────
+
+// memoEntry caches the parsed cells of one brick render so unchanged bricks
+// skip both View() and ANSI parsing on the next frame.
+type memoEntry struct {
+	version uint64
+	cells   []spriteCell
+	used    bool
+}
+
+// DrawMemo draws the output of render at (x, y) like Draw, but remembers the
+// parsed cells under key. While version stays the same, later frames replay
+// the cached cells without calling render at all. Bump version whenever the
+// brick's content, size or theme changes.
+//
+// Entries that are not drawn during a frame are dropped at the next Begin, and
+// a resize clears the memo entirely.
+func (s *Surface) DrawMemo(x, y int, key string, version uint64, render func() string) {
+	if render == nil {
+		return
+	}
+	if s.memo == nil {
+		s.memo = make(map[string]*memoEntry)
+	}
+	e, ok := s.memo[key]
+	if !ok || e.version != version {
+		e = &memoEntry{version: version, cells: s.parse(render())}
+		s.memo[key] = e
+	}
+	e.used = true
+	s.blit(x, y, e.cells)
+}
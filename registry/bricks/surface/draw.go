@@ -0,0 +1,280 @@
+package surface
+
+import (
+	"image/color"
+	"strings"
+
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// Drawing primitives operate on cells directly — no lipgloss string is built
+// and reparsed. All coordinates are local to the surface, everything is
+// clipped to its bounds, and draws go to the surface's layer like Draw does.
+//
+// A nil background on any primitive inherits the background of the cell
+// beneath, the same rule Draw applies to inline text.
+
+// BorderStyle selects the glyph set used by DrawBox.
+type BorderStyle string
+
+const (
+	BorderNormal  BorderStyle = "normal"  // ┌─┐
+	BorderRounded BorderStyle = "rounded" // ╭─╮
+	BorderThick   BorderStyle = "thick"   // ┏━┓
+	BorderDouble  BorderStyle = "double"  // ╔═╗
+	BorderASCII   BorderStyle = "ascii"   // +-+
+	BorderBlock   BorderStyle = "block"   // ███
+	BorderHidden  BorderStyle = "hidden"  // spaces — keeps the inset, no glyphs
+)
+
+// Align positions text horizontally inside a rectangle.
+type Align string
+
+const (
+	AlignLeft   Align = "left"
+	AlignCenter Align = "center"
+	AlignRight  Align = "right"
+)
+
+// VAlign positions text vertically inside a rectangle.
+type VAlign string
+
+const (
+	VAlignTop    VAlign = "top"
+	VAlignMiddle VAlign = "middle"
+	VAlignBottom VAlign = "bottom"
+)
+
+// Box describes a bordered rectangle for DrawBox.
+type Box struct {
+	Border BorderStyle // "" = BorderNormal
+	FG     color.Color // border glyph color
+	BG     color.Color // border cell background, nil = inherit
+	Fill   color.Color // interior background, nil = leave interior untouched
+
+	Title      string      // optional, drawn into the top edge
+	TitleFG    color.Color // nil = FG
+	TitleAlign Align       // "" = AlignLeft
+}
+
+// TextStyle describes how DrawText paints text inside a rectangle.
+type TextStyle struct {
+	FG     color.Color
+	BG     color.Color // nil = inherit
+	Bold   bool
+	Align  Align  // "" = AlignLeft
+	VAlign VAlign // "" = VAlignTop
+}
+
+// FillRect paints every cell of r with a space styled with bg.
+func (s *Surface) FillRect(r uv.Rectangle, bg color.Color) {
+	area := r.Add(s.origin).Intersect(s.bounds())
+	if area.Empty() {
+		return
+	}
+	cell := &uv.Cell{Content: " ", Width: 1, Style: uv.Style{Bg: bg}}
+	s.paint(func() { s.buf.FillArea(cell, area) })
+}
+
+// HLine draws a horizontal run of glyph starting at (x, y). An empty glyph
+// draws "─".
+func (s *Surface) HLine(x, y, length int, glyph string, fg color.Color) {
+	if glyph == "" {
+		glyph = "─"
+	}
+	cells := make([]spriteCell, 0, max(0, length))
+	for i := 0; i < length; i++ {
+		cells = append(cells, spriteCell{x: i, cell: glyphCell(glyph, fg, nil)})
+	}
+	s.blit(x, y, cells)
+}
+
+// VLine draws a vertical run of glyph starting at (x, y). An empty glyph
+// draws "│".
+func (s *Surface) VLine(x, y, length int, glyph string, fg color.Color) {
+	if glyph == "" {
+		glyph = "│"
+	}
+	cells := make([]spriteCell, 0, max(0, length))
+	for i := 0; i < length; i++ {
+		cells = append(cells, spriteCell{y: i, cell: glyphCell(glyph, fg, nil)})
+	}
+	s.blit(x, y, cells)
+}
+
+// DrawBox draws a bordered rectangle. The title, if any, is clipped to the
+// top edge between the corners. Rectangles smaller than 2x2 are ignored.
+func (s *Surface) DrawBox(r uv.Rectangle, b Box) {
+	w, h := r.Dx(), r.Dy()
+	if w < 2 || h < 2 {
+		return
+	}
+	if b.Fill != nil && w > 2 && h > 2 {
+		s.FillRect(uv.Rect(r.Min.X+1, r.Min.Y+1, w-2, h-2), b.Fill)
+	}
+
+	g := borderGlyphs(b.Border)
+	cell := func(x, y int, glyph string) spriteCell {
+		return spriteCell{x: x, y: y, cell: glyphCell(glyph, b.FG, b.BG)}
+	}
+	cells := make([]spriteCell, 0, 2*(w+h))
+	cells = append(cells,
+		cell(0, 0, g.TopLeft.Content),
+		cell(w-1, 0, g.TopRight.Content),
+		cell(0, h-1, g.BottomLeft.Content),
+		cell(w-1, h-1, g.BottomRight.Content),
+	)
+	for x := 1; x < w-1; x++ {
+		cells = append(cells, cell(x, 0, g.Top.Content), cell(x, h-1, g.Bottom.Content))
+	}
+	for y := 1; y < h-1; y++ {
+		cells = append(cells, cell(0, y, g.Left.Content), cell(w-1, y, g.Right.Content))
+	}
+	s.blit(r.Min.X, r.Min.Y, cells)
+
+	title := strings.TrimSpace(b.Title)
+	if title == "" || w < 5 {
+		return
+	}
+	titleFG := b.TitleFG
+	if titleFG == nil {
+		titleFG = b.FG
+	}
+	s.DrawText(uv.Rect(r.Min.X+1, r.Min.Y, w-2, 1), " "+title+" ", TextStyle{
+		FG:    titleFG,
+		BG:    b.BG,
+		Bold:  true,
+		Align: b.TitleAlign,
+	})
+}
+
+// DrawText draws plain text inside r. Lines split on "\n", each line is
+// clipped to the rectangle width, and lines beyond its height are dropped.
+// ANSI sequences in text are not interpreted — use Draw for pre-styled content.
+func (s *Surface) DrawText(r uv.Rectangle, text string, st TextStyle) {
+	w, h := r.Dx(), r.Dy()
+	if w <= 0 || h <= 0 || text == "" {
+		return
+	}
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if len(lines) > h {
+		lines = lines[:h]
+	}
+
+	top := 0
+	switch st.VAlign {
+	case VAlignMiddle:
+		top = (h - len(lines)) / 2
+	case VAlignBottom:
+		top = h - len(lines)
+	}
+
+	style := uv.Style{Fg: st.FG, Bg: st.BG}
+	if st.Bold {
+		style.Attrs |= uv.AttrBold
+	}
+
+	cells := make([]spriteCell, 0, w*len(lines))
+	for i, line := range lines {
+		glyphs := clipCells(s.parse(line), w)
+		lineW := 0
+		for _, c := range glyphs {
+			lineW = max(lineW, c.x+max(1, c.cell.Width))
+		}
+		left := 0
+		switch st.Align {
+		case AlignCenter:
+			left = (w - lineW) / 2
+		case AlignRight:
+			left = w - lineW
+		}
+		for _, c := range glyphs {
+			c.cell.Style = style
+			c.x += left
+			c.y = top + i
+			cells = append(cells, c)
+		}
+	}
+
+	// Clip to r as well as to the surface.
+	s.Sub(r).blit(0, 0, cells)
+}
+
+// Tint blends the foreground and background of every cell in r toward c by
+// amount (0 = unchanged, 1 = fully c). Use it to dim the canvas behind a
+// modal or to wash a disabled pane. Cells with no color set are left alone.
+func (s *Surface) Tint(r uv.Rectangle, c color.Color, amount float64) {
+	if c == nil || amount <= 0 {
+		return
+	}
+	if amount > 1 {
+		amount = 1
+	}
+	area := r.Add(s.origin).Intersect(s.bounds())
+	if area.Empty() {
+		return
+	}
+	s.paint(func() {
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			line := s.buf.Line(y)
+			for x := area.Min.X; x < area.Max.X; x++ {
+				cell := line.At(x)
+				if cell == nil {
+					continue
+				}
+				if cell.Style.Fg != nil {
+					cell.Style.Fg = blend(cell.Style.Fg, c, amount)
+				}
+				if cell.Style.Bg != nil {
+					cell.Style.Bg = blend(cell.Style.Bg, c, amount)
+				}
+			}
+		}
+	})
+}
+
+// glyphCell builds a single styled cell for a border or line glyph.
+func glyphCell(glyph string, fg, bg color.Color) uv.Cell {
+	return uv.Cell{Content: glyph, Width: 1, Style: uv.Style{Fg: fg, Bg: bg}}
+}
+
+// clipCells drops parsed cells that would extend past width.
+func clipCells(cells []spriteCell, width int) []spriteCell {
+	out := cells[:0]
+	for _, c := range cells {
+		if c.x+max(1, c.cell.Width) <= width {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func borderGlyphs(style BorderStyle) uv.Border {
+	switch style {
+	case BorderRounded:
+		return uv.RoundedBorder()
+	case BorderThick:
+		return uv.ThickBorder()
+	case BorderDouble:
+		return uv.DoubleBorder()
+	case BorderASCII:
+		return uv.ASCIIBorder()
+	case BorderBlock:
+		return uv.BlockBorder()
+	case BorderHidden:
+		return uv.HiddenBorder()
+	default:
+		return uv.NormalBorder()
+	}
+}
+
+// blend linearly interpolates from a toward b by t in RGB space.
+func blend(a, b color.Color, t float64) color.Color {
+	ar, ag, ab, _ := a.RGBA()
+	br, bg, bb, _ := b.RGBA()
+	mix := func(x, y uint32) uint8 {
+		v := float64(x>>8)*(1-t) + float64(y>>8)*t
+		return uint8(v + 0.5)
+	}
+	return color.RGBA{R: mix(ar, br), G: mix(ag, bg), B: mix(ab, bb), A: 0xff}
+}
@@ -0,0 +1,390 @@
+package surface
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// Exporters turn a rendered frame into a self-contained document for docs and
+// PR reviews. Every cell keeps the exact color it was drawn with; cells with
+// no color fall back to ExportOptions.Background / Foreground, which should
+// normally be t.Background() and t.Text() of the theme the bento ran with.
+
+// ExportOptions controls HTML and SVG output.
+type ExportOptions struct {
+	Title      string      // document title, "" = "bento"
+	Background color.Color // default cell background, nil = black
+	Foreground color.Color // default cell foreground, nil = white
+	FontFamily string      // "" = a monospace stack
+	FontSize   int         // pixels, 0 = 14
+	Padding    int         // pixels around the grid, 0 = none
+}
+
+func (o ExportOptions) withDefaults() ExportOptions {
+	if o.Title == "" {
+		o.Title = "bento"
+	}
+	if o.Background == nil {
+		o.Background = color.Black
+	}
+	if o.Foreground == nil {
+		o.Foreground = color.White
+	}
+	if o.FontFamily == "" {
+		o.FontFamily = `"JetBrains Mono", "SFMono-Regular", Menlo, Consolas, monospace`
+	}
+	if o.FontSize <= 0 {
+		o.FontSize = 14
+	}
+	if o.Padding < 0 {
+		o.Padding = 0
+	}
+	return o
+}
+
+// FromView draws a tea.View onto a new surface so it can be exported. The
+// view's BackgroundColor, if set, is used as the canvas fill. A width or
+// height of zero is taken from the content bounds.
+func FromView(v tea.View, width, height int) *Surface {
+	content := viewContent(v)
+	if width <= 0 || height <= 0 {
+		b := uv.NewStyledString(content).Bounds()
+		if width <= 0 {
+			width = b.Dx()
+		}
+		if height <= 0 {
+			height = b.Dy()
+		}
+	}
+	s := New(width, height)
+	if v.BackgroundColor != nil {
+		s.Fill(v.BackgroundColor)
+	}
+	s.Draw(0, 0, content)
+	return s
+}
+
+// WriteHTML writes the surface as a standalone HTML page: one <pre> with a
+// styled <span> per run of identically styled cells.
+func WriteHTML(w io.Writer, s *Surface, opts ExportOptions) error {
+	o := opts.withDefaults()
+	s.composite()
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(o.Title))
+	fmt.Fprintf(bw, "<style>\nbody{margin:0;background:%s}\n", hexColor(o.Background))
+	fmt.Fprintf(bw, "pre.bento{margin:0;padding:%dpx;font-family:%s;font-size:%dpx;line-height:1.2;color:%s;background:%s}\n",
+		o.Padding, o.FontFamily, o.FontSize, hexColor(o.Foreground), hexColor(o.Background))
+	bw.WriteString("pre.bento span{white-space:pre}\n</style>\n</head>\n<body>\n<pre class=\"bento\">")
+
+	for y := 0; y < s.height; y++ {
+		if y > 0 {
+			bw.WriteString("\n")
+		}
+		for _, r := range styledRuns(s.buf.Line(y)) {
+			fg, bg := o.cellColors(r.style)
+			css := fmt.Sprintf("color:%s;background:%s", hexColor(fg), hexColor(bg))
+			css += cssAttrs(r.style)
+			fmt.Fprintf(bw, `<span style="%s">%s</span>`, css, html.EscapeString(r.text))
+		}
+	}
+
+	bw.WriteString("</pre>\n</body>\n</html>\n")
+	return bw.Flush()
+}
+
+// WriteSVG writes the surface as a standalone SVG image. Each run becomes a
+// background <rect> plus a <text> stretched to the run's exact cell width, so
+// the grid stays aligned regardless of the viewer's font metrics.
+func WriteSVG(w io.Writer, s *Surface, opts ExportOptions) error {
+	o := opts.withDefaults()
+	s.composite()
+
+	cellW := float64(o.FontSize) * 0.6
+	cellH := float64(o.FontSize) * 1.2
+	pad := float64(o.Padding)
+	totalW := cellW*float64(s.width) + 2*pad
+	totalH := cellH*float64(s.height) + 2*pad
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.1f" height="%.1f" viewBox="0 0 %.1f %.1f">`+"\n",
+		totalW, totalH, totalW, totalH)
+	fmt.Fprintf(bw, "<title>%s</title>\n", html.EscapeString(o.Title))
+	fmt.Fprintf(bw, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", hexColor(o.Background))
+	fmt.Fprintf(bw, `<g font-family='%s' font-size="%d" xml:space="preserve">`+"\n", strings.ReplaceAll(o.FontFamily, "'", ""), o.FontSize)
+
+	for y := 0; y < s.height; y++ {
+		top := pad + cellH*float64(y)
+		for _, r := range styledRuns(s.buf.Line(y)) {
+			fg, bg := o.cellColors(r.style)
+			x := pad + cellW*float64(r.col)
+			width := cellW * float64(r.width)
+			fmt.Fprintf(bw, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n",
+				x, top, width, cellH, hexColor(bg))
+			if strings.TrimSpace(r.text) == "" || r.style.Attrs&uv.AttrConceal != 0 {
+				continue
+			}
+			fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" textLength="%.1f" lengthAdjust="spacingAndGlyphs" fill="%s"%s>%s</text>`+"\n",
+				x, top+cellH*0.8, width, hexColor(fg), svgAttrs(r.style), html.EscapeString(r.text))
+		}
+	}
+
+	bw.WriteString("</g>\n</svg>\n")
+	return bw.Flush()
+}
+
+// ── asciicast ─────────────────────────────────────────────────────────────────
+
+// Recorder writes frames to an asciinema v2 .cast stream. Each distinct frame
+// becomes one output event that homes the cursor and repaints the screen;
+// consecutive identical frames are skipped.
+type Recorder struct {
+	w      io.Writer
+	width  int
+	height int
+	title  string
+	start  time.Time
+	now    func() time.Time
+	last   string
+	header bool
+	err    error
+}
+
+// NewRecorder creates a recorder for a width x height terminal. The header is
+// written lazily with the first frame.
+func NewRecorder(w io.Writer, width, height int, title string) *Recorder {
+	return &Recorder{
+		w:      w,
+		width:  max(1, width),
+		height: max(1, height),
+		title:  title,
+		now:    time.Now,
+	}
+}
+
+// Frame records a rendered frame at the current wall-clock offset.
+func (r *Recorder) Frame(frame string) error {
+	return r.FrameAt(r.elapsed(), frame)
+}
+
+// FrameAt records a frame at an explicit offset from the start of the
+// recording. Use it for deterministic casts in tests and doc generators.
+func (r *Recorder) FrameAt(at time.Duration, frame string) error {
+	if err := r.writeHeader(); err != nil {
+		return err
+	}
+	if frame == r.last {
+		return nil
+	}
+	r.last = frame
+	data := "\x1b[H\x1b[2J" + strings.ReplaceAll(frame, "\n", "\r\n")
+	return r.event(at, "o", data)
+}
+
+// Resize records a terminal resize event.
+func (r *Recorder) Resize(width, height int) error {
+	if err := r.writeHeader(); err != nil {
+		return err
+	}
+	r.last = ""
+	return r.event(r.elapsed(), "r", fmt.Sprintf("%dx%d", width, height))
+}
+
+// Err returns the first write error, if any.
+func (r *Recorder) Err() error { return r.err }
+
+// Wrap returns a tea.Model that records every frame the wrapped model
+// renders, and every window resize it receives, into r.
+func (r *Recorder) Wrap(m tea.Model) tea.Model {
+	return recordedModel{model: m, rec: r}
+}
+
+type recordedModel struct {
+	model tea.Model
+	rec   *Recorder
+}
+
+func (m recordedModel) Init() tea.Cmd { return m.model.Init() }
+
+func (m recordedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if ws, ok := msg.(tea.WindowSizeMsg); ok {
+		_ = m.rec.Resize(ws.Width, ws.Height)
+	}
+	next, cmd := m.model.Update(msg)
+	m.model = next
+	return m, cmd
+}
+
+func (m recordedModel) View() tea.View {
+	v := m.model.View()
+	_ = m.rec.Frame(viewContent(v))
+	return v
+}
+
+func (r *Recorder) elapsed() time.Duration {
+	if r.start.IsZero() {
+		return 0
+	}
+	return r.now().Sub(r.start)
+}
+
+func (r *Recorder) writeHeader() error {
+	if r.err != nil || r.header {
+		return r.err
+	}
+	r.start = r.now()
+	header := map[string]any{
+		"version":   2,
+		"width":     r.width,
+		"height":    r.height,
+		"timestamp": r.start.Unix(),
+	}
+	if r.title != "" {
+		header["title"] = r.title
+	}
+	r.header = true
+	return r.writeJSON(header)
+}
+
+func (r *Recorder) event(at time.Duration, kind, data string) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.writeJSON([]any{at.Seconds(), kind, data})
+}
+
+func (r *Recorder) writeJSON(v any) error {
+	b, err := json.Marshal(v)
+	if err == nil {
+		_, err = r.w.Write(append(b, '\n'))
+	}
+	if err != nil {
+		r.err = err
+	}
+	return err
+}
+
+// ── helpers ───────────────────────────────────────────────────────────────────
+
+// run is a horizontal span of cells sharing one style.
+type run struct {
+	col   int
+	width int
+	text  string
+	style uv.Style
+}
+
+func styledRuns(line uv.Line) []run {
+	out := make([]run, 0, 8)
+	var cur *run
+	for x := 0; x < len(line); x++ {
+		c := line.At(x)
+		if c == nil || c.Width == 0 {
+			continue // wide-cell placeholder
+		}
+		content := c.Content
+		if content == "" {
+			content = " "
+		}
+		if cur != nil && cur.style.Equal(&c.Style) {
+			cur.text += content
+			cur.width += c.Width
+			continue
+		}
+		out = append(out, run{col: x, width: c.Width, text: content, style: c.Style})
+		cur = &out[len(out)-1]
+	}
+	return out
+}
+
+func (o ExportOptions) cellColors(st uv.Style) (fg, bg color.Color) {
+	fg, bg = st.Fg, st.Bg
+	if fg == nil {
+		fg = o.Foreground
+	}
+	if bg == nil {
+		bg = o.Background
+	}
+	if st.Attrs&uv.AttrReverse != 0 {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+func cssAttrs(st uv.Style) string {
+	var b strings.Builder
+	if st.Attrs&uv.AttrBold != 0 {
+		b.WriteString(";font-weight:bold")
+	}
+	if st.Attrs&uv.AttrFaint != 0 {
+		b.WriteString(";opacity:0.6")
+	}
+	if st.Attrs&uv.AttrItalic != 0 {
+		b.WriteString(";font-style:italic")
+	}
+	if st.Attrs&uv.AttrConceal != 0 {
+		b.WriteString(";visibility:hidden")
+	}
+	var deco []string
+	if st.Underline != uv.UnderlineNone {
+		deco = append(deco, "underline")
+	}
+	if st.Attrs&uv.AttrStrikethrough != 0 {
+		deco = append(deco, "line-through")
+	}
+	if len(deco) > 0 {
+		b.WriteString(";text-decoration:" + strings.Join(deco, " "))
+	}
+	return b.String()
+}
+
+func svgAttrs(st uv.Style) string {
+	var b strings.Builder
+	if st.Attrs&uv.AttrBold != 0 {
+		b.WriteString(` font-weight="bold"`)
+	}
+	if st.Attrs&uv.AttrFaint != 0 {
+		b.WriteString(` fill-opacity="0.6"`)
+	}
+	if st.Attrs&uv.AttrItalic != 0 {
+		b.WriteString(` font-style="italic"`)
+	}
+	var deco []string
+	if st.Underline != uv.UnderlineNone {
+		deco = append(deco, "underline")
+	}
+	if st.Attrs&uv.AttrStrikethrough != 0 {
+		deco = append(deco, "line-through")
+	}
+	if len(deco) > 0 {
+		b.WriteString(` text-decoration="` + strings.Join(deco, " ") + `"`)
+	}
+	return b.String()
+}
+
+func hexColor(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+func viewContent(v tea.View) string {
+	if v.Content == nil {
+		return ""
+	}
+	if r, ok := v.Content.(interface{ Render() string }); ok {
+		return r.Render()
+	}
+	if s, ok := v.Content.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return fmt.Sprint(v.Content)
+}
@@ -0,0 +1,70 @@
+package surface
+
+import (
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// Layer is a z-order slot. Draws into higher layers are composited on top of
+// lower ones when the frame is rendered.
+type Layer int
+
+const (
+	LayerBase    Layer = iota // rooms, cards, bars — painted immediately
+	LayerOverlay              // popovers, dropdowns, drawers
+	LayerToast                // transient notifications
+	LayerModal                // dialogs, always on top
+
+	layerCount
+)
+
+// Sub returns a child surface clipped to rect (in this surface's local
+// coordinates). The child's (0, 0) is rect's top-left corner, Width/Height
+// report the clipped size, and nothing drawn into it can land outside rect or
+// outside its parent. The child shares the parent's frame and layer.
+func (s *Surface) Sub(rect uv.Rectangle) *Surface {
+	abs := rect.Add(s.origin)
+	return &Surface{
+		frame:  s.frame,
+		origin: abs.Min,
+		clip:   abs.Intersect(s.bounds()),
+		sub:    true,
+		layer:  s.layer,
+	}
+}
+
+// Layer returns a view of the same region that draws into layer l. Draws made
+// through it are deferred and composited over the base layer in z-order at
+// Render (or DirtyRows/Damage) time, so a modal drawn before the rooms below
+// it still ends up on top.
+func (s *Surface) Layer(l Layer) *Surface {
+	if l < LayerBase {
+		l = LayerBase
+	}
+	if l >= layerCount {
+		l = layerCount - 1
+	}
+	child := *s
+	child.layer = l
+	return &child
+}
+
+// paint runs op now for the base layer, or queues it for composite.
+func (s *Surface) paint(op func()) {
+	if s.layer == LayerBase {
+		op()
+		return
+	}
+	s.pending[s.layer] = append(s.pending[s.layer], op)
+}
+
+// composite flushes queued layer draws onto the frame buffer, lowest layer
+// first. Within a layer, draws keep the order they were made in.
+func (s *Surface) composite() {
+	for l := LayerOverlay; l < layerCount; l++ {
+		ops := s.pending[l]
+		s.pending[l] = nil
+		for _, op := range ops {
+			op()
+		}
+	}
+}
@@ -17,13 +17,32 @@
 //     own styled cells; surrounding filled cells are never touched.
 //  4. Call Render() once and pass the result to tea.NewView.
 //
+// For apps that redraw often (dashboards ticking every second), keep one
+// Surface on the model and call Begin(width, height) instead of New at the top
+// of View(). The surface then diffs each frame against the previous one,
+// re-serializes only the rows that changed, and exposes the damage through
+// DirtyRows() and Damage(). DrawMemo skips re-rendering bricks whose version
+// stamp has not changed since the last frame.
+//
+// Sub(rect) returns a child surface clipped to a pane with its own local
+// coordinates, so a brick drawn into one pane can never spill into its
+// neighbors. Layer(l) returns a view that draws into one of the z-layers
+// (base, overlay, toast, modal); higher layers are composited on top at
+// Render time regardless of the order the draw calls were made in.
+//
+// The primitives in draw.go (FillRect, HLine, VLine, DrawBox, DrawText, Tint)
+// write cells directly, so frames, dividers and labels do not need to be built
+// as lipgloss strings and reparsed. export.go writes a finished frame as HTML,
+// SVG or an asciinema recording.
+//
 // This keeps the Ultraviolet cell buffer as the single source of truth for
 // what every terminal cell contains on each frame — no ANSI whitespace-reset
 // bleed, no partial clears, no string concatenation outside this surface.
 //
-// Copy this file into your project: bento add surface
+// Copy this directory into your project: bento add surface
 //
 // Dependencies:
+//   - charm.land/bubbletea/v2
 //   - github.com/charmbracelet/ultraviolet
 package surface
 
@@ -36,10 +55,32 @@ import (
 
 // Surface is the full-terminal cell buffer. Build one per frame in View(),
 // fill it, draw components onto it, then call Render().
+//
+// A Surface is also a view onto that buffer: Sub and Layer return surfaces
+// that share the same frame but draw at a different origin, clip rectangle or
+// z-layer.
 type Surface struct {
+	*frame
+	origin uv.Position  // absolute position of local (0, 0)
+	clip   uv.Rectangle // absolute clip rectangle, only used when sub is set
+	sub    bool
+	layer  Layer
+}
+
+// frame is the state shared by a root surface and all of its views.
+type frame struct {
 	buf    uv.ScreenBuffer
 	width  int
 	height int
+
+	// Layer retention — see layers.go.
+	pending [layerCount][]func()
+
+	// Frame retention — see damage.go.
+	prev     *uv.Buffer // previous frame cells, nil = everything dirty
+	rows     []string   // serialized rows of the last rendered frame
+	rendered bool       // Render ran since Begin, so rows match this frame
+	memo     map[string]*memoEntry
 }
 
 // New creates a Surface sized to the full terminal (width x height).
@@ -51,23 +92,25 @@ func New(width, height int) *Surface {
 	if height < 1 {
 		height = 1
 	}
-	return &Surface{
+	return &Surface{frame: &frame{
 		buf:    uv.NewScreenBuffer(width, height),
 		width:  width,
 		height: height,
-	}
+	}}
 }
 
 // Fill paints every cell of the surface with a space styled with bg.
 // Always call this first — it is the root canvas layer that every
-// component draws on top of.
+// component draws on top of. On a sub-surface only the clipped pane is
+// painted.
 func (s *Surface) Fill(bg color.Color) {
 	cell := &uv.Cell{
 		Content: " ",
 		Width:   1,
 		Style:   uv.Style{Bg: bg},
 	}
-	s.buf.Fill(cell)
+	area := s.bounds()
+	s.paint(func() { s.buf.FillArea(cell, area) })
 }
 
 // overlayScreen wraps the real ScreenBuffer but intercepts SetCell so that
@@ -98,21 +141,13 @@ func (o overlayScreen) SetCell(x, y int, c *uv.Cell) {
 
 // Draw places a pre-rendered ANSI string at (x, y) as an overlay.
 // Only the content's own styled cells are written; all surrounding cells
-// filled by Fill() are left completely untouched.
+// filled by Fill() are left completely untouched. Coordinates are local to
+// the surface and anything outside its bounds is clipped.
 func (s *Surface) Draw(x, y int, content string) {
-	if content == "" || x >= s.width || y >= s.height {
-		return
-	}
-	ss := uv.NewStyledString(content)
-	b := ss.Bounds()
-	w := min(b.Dx(), s.width-x)
-	h := min(b.Dy(), s.height-y)
-	if w <= 0 || h <= 0 {
+	if content == "" || x >= s.Width() || y >= s.Height() {
 		return
 	}
-	// Use the overlay screen so pre-clear SetCell(nil) calls are dropped.
-	ov := overlayScreen{s.buf}
-	ss.Draw(ov, uv.Rect(x, y, w, h))
+	s.blit(x, y, s.parse(content))
 }
 
 // DrawCenter places a pre-rendered ANSI string centered on the surface.
@@ -123,23 +158,108 @@ func (s *Surface) DrawCenter(content string) {
 	}
 	ss := uv.NewStyledString(content)
 	b := ss.Bounds()
-	x := max(0, (s.width-b.Dx())/2)
-	y := max(0, (s.height-b.Dy())/2)
+	x := max(0, (s.Width()-b.Dx())/2)
+	y := max(0, (s.Height()-b.Dy())/2)
 	s.Draw(x, y, content)
 }
 
 // Render serializes the cell buffer to an ANSI string for tea.NewView.
-// uv.Buffer.Render() emits \r\n; we normalize to \n so Bubble Tea does
-// not emit a raw carriage return that resets the cursor column mid-frame.
+// Rows are joined with \n rather than the \r\n uv.Buffer.Render() emits so
+// Bubble Tea does not see a raw carriage return that resets the cursor column
+// mid-frame. Only rows that changed since the previous frame are serialized
+// again; unchanged rows reuse the cached string.
 func (s *Surface) Render() string {
-	return strings.ReplaceAll(s.buf.Render(), "\r\n", "\n")
+	s.composite()
+	if len(s.rows) != s.height {
+		s.rows = make([]string, s.height)
+		for y := range s.rows {
+			s.rows[y] = s.buf.Line(y).Render()
+		}
+	} else {
+		for _, y := range s.DirtyRows() {
+			s.rows[y] = s.buf.Line(y).Render()
+		}
+	}
+	s.rendered = true
+	return strings.Join(s.rows, "\n")
 }
 
 // Width returns the surface width in cells.
-func (s *Surface) Width() int { return s.width }
+func (s *Surface) Width() int { return s.bounds().Dx() }
 
 // Height returns the surface height in cells.
-func (s *Surface) Height() int { return s.height }
+func (s *Surface) Height() int { return s.bounds().Dy() }
+
+// bounds returns the absolute rectangle this surface may draw into.
+func (s *Surface) bounds() uv.Rectangle {
+	full := uv.Rect(0, 0, s.width, s.height)
+	if !s.sub {
+		return full
+	}
+	return s.clip.Intersect(full)
+}
+
+// parse decomposes a pre-rendered ANSI string into the cells it actually
+// writes, relative to its own origin. Pre-clear cells are not recorded.
+func (s *Surface) parse(content string) []spriteCell {
+	if content == "" {
+		return nil
+	}
+	ss := uv.NewStyledString(content)
+	rec := &recorder{bounds: ss.Bounds(), method: s.buf.WidthMethod()}
+	ss.Draw(rec, rec.bounds)
+	return rec.cells
+}
+
+// blit replays parsed cells at local (x, y) through the overlay screen,
+// clipped to the surface bounds.
+func (s *Surface) blit(x, y int, cells []spriteCell) {
+	if len(cells) == 0 {
+		return
+	}
+	area := s.bounds()
+	ox, oy := s.origin.X+x, s.origin.Y+y
+	s.paint(func() {
+		ov := overlayScreen{s.buf}
+		for _, c := range cells {
+			cx, cy := ox+c.x, oy+c.y
+			if !fits(area, cx, cy, c.cell.Width) {
+				continue
+			}
+			cell := c.cell
+			ov.SetCell(cx, cy, &cell)
+		}
+	})
+}
+
+// fits reports whether a cell of the given width at absolute (x, y) lies
+// entirely inside area.
+func fits(area uv.Rectangle, x, y, width int) bool {
+	return y >= area.Min.Y && y < area.Max.Y &&
+		x >= area.Min.X && x+max(1, width) <= area.Max.X
+}
+
+// recorder is a uv.Screen that records every non-nil cell written to it.
+type recorder struct {
+	bounds uv.Rectangle
+	method uv.WidthMethod
+	cells  []spriteCell
+}
+
+type spriteCell struct {
+	x, y int
+	cell uv.Cell
+}
+
+func (r *recorder) Bounds() uv.Rectangle        { return r.bounds }
+func (r *recorder) CellAt(int, int) *uv.Cell    { return nil }
+func (r *recorder) WidthMethod() uv.WidthMethod { return r.method }
+func (r *recorder) SetCell(x, y int, c *uv.Cell) {
+	if c == nil {
+		return
+	}
+	r.cells = append(r.cells, spriteCell{x: x, y: y, cell: *c})
+}
 
 func min(a, b int) int {
 	if a < b {
@@ -0,0 +1,401 @@
+package surface
+
+import (
+	"encoding/json"
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestRenderExactDimensions(t *testing.T) {
+	s := New(12, 3)
+	s.Fill(lipgloss.Color("#101010"))
+	s.Draw(2, 1, "hello")
+
+	lines := strings.Split(s.Render(), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if got := lipgloss.Width(line); got != 12 {
+			t.Fatalf("line %d width = %d, want 12", i, got)
+		}
+	}
+	if !strings.Contains(ansi.Strip(lines[1]), "hello") {
+		t.Fatalf("expected drawn content on row 1, got %q", ansi.Strip(lines[1]))
+	}
+}
+
+func TestBeginTracksDirtyRows(t *testing.T) {
+	var s Surface
+	bg := lipgloss.Color("#101010")
+
+	s.Begin(10, 4)
+	s.Fill(bg)
+	s.Draw(0, 1, "one")
+	first := s.Render()
+	if got := len(s.DirtyRows()); got != 4 {
+		t.Fatalf("expected every row dirty on first frame, got %d", got)
+	}
+
+	s.Begin(10, 4)
+	s.Fill(bg)
+	s.Draw(0, 1, "one")
+	if got := s.DirtyRows(); len(got) != 0 {
+		t.Fatalf("expected no dirty rows for identical frame, got %v", got)
+	}
+	if s.Render() != first {
+		t.Fatalf("expected identical frame to render identically")
+	}
+
+	s.Begin(10, 4)
+	s.Fill(bg)
+	s.Draw(0, 1, "one")
+	s.Draw(3, 2, "xy")
+	dirty := s.DirtyRows()
+	if len(dirty) != 1 || dirty[0] != 2 {
+		t.Fatalf("expected only row 2 dirty, got %v", dirty)
+	}
+	damage := s.Damage()
+	if len(damage) != 1 || damage[0].Min.X != 3 || damage[0].Dx() != 2 || damage[0].Min.Y != 2 {
+		t.Fatalf("expected damage span x=3 w=2 on row 2, got %v", damage)
+	}
+	out := ansi.Strip(s.Render())
+	if !strings.Contains(out, "xy") {
+		t.Fatalf("expected re-rendered dirty row, got %q", out)
+	}
+}
+
+func TestBeginWithoutRenderDoesNotServeStaleRows(t *testing.T) {
+	var s Surface
+	frame := func(text string) {
+		s.Begin(10, 2)
+		s.Fill(lipgloss.Color("#000000"))
+		s.Draw(0, 0, text)
+	}
+	frame("one")
+	_ = s.Render()
+	frame("two") // skipped: never rendered
+	frame("two")
+	if out := ansi.Strip(s.Render()); !strings.Contains(out, "two") {
+		t.Fatalf("expected the skipped frame's change to be rendered, got %q", out)
+	}
+}
+
+func TestBeginResizeMarksEverythingDirty(t *testing.T) {
+	var s Surface
+	s.Begin(8, 2)
+	s.Fill(lipgloss.Color("#000000"))
+	_ = s.Render()
+
+	s.Begin(9, 3)
+	s.Fill(lipgloss.Color("#000000"))
+	if got := len(s.DirtyRows()); got != 3 {
+		t.Fatalf("expected all rows dirty after resize, got %d", got)
+	}
+	lines := strings.Split(s.Render(), "\n")
+	if len(lines) != 3 || lipgloss.Width(lines[0]) != 9 {
+		t.Fatalf("expected 9x3 frame after resize, got %d lines of width %d", len(lines), lipgloss.Width(lines[0]))
+	}
+}
+
+func TestDrawMemoSkipsRenderForSameVersion(t *testing.T) {
+	var s Surface
+	calls := 0
+	render := func() string {
+		calls++
+		return "card"
+	}
+
+	for i := 0; i < 3; i++ {
+		s.Begin(10, 2)
+		s.Fill(lipgloss.Color("#000000"))
+		s.DrawMemo(1, 0, "card", 1, render)
+		if out := ansi.Strip(s.Render()); !strings.Contains(out, "card") {
+			t.Fatalf("frame %d: expected memoized content, got %q", i, out)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected render called once for stable version, got %d", calls)
+	}
+
+	s.Begin(10, 2)
+	s.Fill(lipgloss.Color("#000000"))
+	s.DrawMemo(1, 0, "card", 2, render)
+	if calls != 2 {
+		t.Fatalf("expected render after version bump, got %d calls", calls)
+	}
+}
+
+func TestDrawMemoEvictsUnusedEntries(t *testing.T) {
+	var s Surface
+	calls := 0
+	render := func() string { calls++; return "x" }
+
+	s.Begin(4, 1)
+	s.DrawMemo(0, 0, "a", 1, render)
+	s.Begin(4, 1) // "a" not drawn this frame
+	s.Begin(4, 1) // evicted here
+	s.DrawMemo(0, 0, "a", 1, render)
+	if calls != 2 {
+		t.Fatalf("expected evicted entry to render again, got %d calls", calls)
+	}
+}
+
+func TestSubClipsToPane(t *testing.T) {
+	s := New(10, 3)
+	s.Fill(lipgloss.Color("#000000"))
+	pane := s.Sub(uv.Rect(2, 1, 4, 1))
+	if pane.Width() != 4 || pane.Height() != 1 {
+		t.Fatalf("expected 4x1 sub-surface, got %dx%d", pane.Width(), pane.Height())
+	}
+	pane.Draw(0, 0, "abcdefgh")
+	pane.Draw(0, 1, "below")
+	pane.Draw(-2, 0, "zz")
+
+	lines := strings.Split(ansi.Strip(s.Render()), "\n")
+	if lines[1] != "  abcd    " {
+		t.Fatalf("expected content clipped to pane, got %q", lines[1])
+	}
+	if strings.TrimSpace(lines[2]) != "" {
+		t.Fatalf("expected nothing below the pane, got %q", lines[2])
+	}
+}
+
+func TestNestedSubUsesLocalCoordinates(t *testing.T) {
+	s := New(12, 4)
+	inner := s.Sub(uv.Rect(2, 1, 8, 3)).Sub(uv.Rect(1, 1, 20, 20))
+	if inner.Width() != 7 || inner.Height() != 2 {
+		t.Fatalf("expected nested sub clipped to parent, got %dx%d", inner.Width(), inner.Height())
+	}
+	inner.Draw(0, 0, "X")
+	lines := strings.Split(ansi.Strip(s.Render()), "\n")
+	if lines[2][3] != 'X' {
+		t.Fatalf("expected X at (3,2), got %q", lines[2])
+	}
+}
+
+func TestLayersCompositeInZOrder(t *testing.T) {
+	s := New(6, 1)
+	s.Fill(lipgloss.Color("#000000"))
+	s.Layer(LayerModal).Draw(0, 0, "MM")
+	s.Layer(LayerToast).Draw(0, 0, "TTTT")
+	s.Layer(LayerOverlay).Draw(0, 0, "OOOOO")
+	s.Draw(0, 0, "bbbbbb")
+
+	if got := ansi.Strip(s.Render()); got != "MMTTOb" {
+		t.Fatalf("expected modal > toast > overlay > base, got %q", got)
+	}
+}
+
+func TestLayerInheritsSubClip(t *testing.T) {
+	s := New(6, 1)
+	s.Sub(uv.Rect(1, 0, 2, 1)).Layer(LayerModal).Draw(0, 0, "XXXX")
+	if got := ansi.Strip(s.Render()); got != " XX   " {
+		t.Fatalf("expected layered draw clipped to pane, got %q", got)
+	}
+}
+
+func TestDrawBoxWithTitle(t *testing.T) {
+	s := New(12, 4)
+	s.DrawBox(uv.Rect(0, 0, 12, 4), Box{Border: BorderRounded, Title: "Logs", TitleAlign: AlignCenter})
+
+	lines := strings.Split(ansi.Strip(s.Render()), "\n")
+	want := []string{
+		"╭── Logs ──╮",
+		"│          │",
+		"│          │",
+		"╰──────────╯",
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("row %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestDrawBoxFillPaintsInterior(t *testing.T) {
+	s := New(5, 3)
+	fill := lipgloss.Color("#ff0000")
+	s.DrawBox(uv.Rect(0, 0, 5, 3), Box{Fill: fill})
+	if got := s.buf.CellAt(2, 1).Style.Bg; !sameColor(got, fill) {
+		t.Fatalf("expected interior filled, got %v", got)
+	}
+	if got := s.buf.CellAt(0, 0).Content; got != "┌" {
+		t.Fatalf("expected normal border corner, got %q", got)
+	}
+}
+
+func TestLinesAndFillRectClip(t *testing.T) {
+	s := New(6, 3)
+	s.HLine(2, 0, 10, "", nil)
+	s.VLine(0, 1, 10, "", nil)
+	bg := lipgloss.Color("#00ff00")
+	s.Sub(uv.Rect(4, 1, 2, 2)).FillRect(uv.Rect(-1, -1, 10, 10), bg)
+
+	lines := strings.Split(ansi.Strip(s.Render()), "\n")
+	if lines[0] != "  ────" {
+		t.Fatalf("expected clipped hline, got %q", lines[0])
+	}
+	if lines[1][0:3] != "│" || lines[2][0:3] != "│" {
+		t.Fatalf("expected vline down column 0, got %q / %q", lines[1], lines[2])
+	}
+	if !sameColor(s.buf.CellAt(4, 1).Style.Bg, bg) || s.buf.CellAt(3, 1).Style.Bg != nil {
+		t.Fatalf("expected FillRect clipped to sub-surface")
+	}
+}
+
+func TestDrawTextAlignment(t *testing.T) {
+	s := New(9, 3)
+	s.DrawText(uv.Rect(0, 0, 9, 3), "hi", TextStyle{Align: AlignRight, VAlign: VAlignBottom})
+	s.DrawText(uv.Rect(0, 0, 9, 3), "mid", TextStyle{Align: AlignCenter, VAlign: VAlignMiddle})
+	s.DrawText(uv.Rect(0, 0, 4, 1), "truncated", TextStyle{})
+
+	lines := strings.Split(ansi.Strip(s.Render()), "\n")
+	want := []string{"trun     ", "   mid   ", "       hi"}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Fatalf("row %d = %q, want %q", i, lines[i], want[i])
+		}
+	}
+}
+
+func TestTintBlendsTowardColor(t *testing.T) {
+	s := New(4, 1)
+	s.Fill(lipgloss.Color("#000000"))
+	s.Tint(uv.Rect(0, 0, 2, 1), lipgloss.Color("#ffffff"), 0.5)
+
+	r, _, _, _ := s.buf.CellAt(0, 0).Style.Bg.RGBA()
+	if r>>8 != 128 {
+		t.Fatalf("expected half-way blend, got red=%d", r>>8)
+	}
+	r, _, _, _ = s.buf.CellAt(3, 0).Style.Bg.RGBA()
+	if r != 0 {
+		t.Fatalf("expected cells outside the region untouched")
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+func TestWriteHTMLKeepsCellColors(t *testing.T) {
+	s := New(6, 2)
+	s.Fill(lipgloss.Color("#102030"))
+	s.Draw(1, 0, lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000")).Bold(true).Render("a<b"))
+
+	var b strings.Builder
+	if err := WriteHTML(&b, s, ExportOptions{Title: "demo"}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{"<title>demo</title>", "color:#ff0000;background:#102030;font-weight:bold", "a&lt;b"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in html:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b") {
+		t.Fatal("html must not contain raw escape sequences")
+	}
+}
+
+func TestWriteSVGStretchesRunsToCellWidth(t *testing.T) {
+	s := New(4, 1)
+	s.Fill(lipgloss.Color("#000000"))
+	s.Draw(0, 0, lipgloss.NewStyle().Foreground(lipgloss.Color("#00ff00")).Render("世x"))
+
+	var b strings.Builder
+	if err := WriteSVG(&b, s, ExportOptions{FontSize: 10}); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	// 世 is two cells wide, x one: the run spans 3 cells of 6px.
+	if !strings.Contains(out, `textLength="18.0"`) || !strings.Contains(out, `fill="#00ff00"`) {
+		t.Fatalf("unexpected svg:\n%s", out)
+	}
+	if !strings.Contains(out, `width="24.0" height="12.0"`) {
+		t.Fatalf("expected 4x1 grid at 6x12px cells:\n%s", out)
+	}
+}
+
+func TestFromViewUsesViewBackground(t *testing.T) {
+	v := tea.NewView("hi")
+	v.BackgroundColor = lipgloss.Color("#123456")
+	s := FromView(v, 4, 2)
+	if s.Width() != 4 || s.Height() != 2 {
+		t.Fatalf("expected 4x2, got %dx%d", s.Width(), s.Height())
+	}
+	c := s.buf.CellAt(3, 1)
+	if c == nil || !sameColor(c.Style.Bg, lipgloss.Color("#123456")) {
+		t.Fatalf("expected view background fill, got %+v", c)
+	}
+
+	auto := FromView(tea.NewView("abc\nde"), 0, 0)
+	if auto.Width() != 3 || auto.Height() != 2 {
+		t.Fatalf("expected content bounds 3x2, got %dx%d", auto.Width(), auto.Height())
+	}
+}
+
+func TestRecorderWritesAsciicast(t *testing.T) {
+	var b strings.Builder
+	r := NewRecorder(&b, 10, 2, "demo")
+	if err := r.FrameAt(0, "one\ntwo"); err != nil {
+		t.Fatal(err)
+	}
+	_ = r.FrameAt(time.Second, "one\ntwo") // duplicate, skipped
+	_ = r.FrameAt(1500*time.Millisecond, "three")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header + 2 events, got %d:\n%s", len(lines), b.String())
+	}
+	var header map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	if header["version"] != float64(2) || header["width"] != float64(10) || header["title"] != "demo" {
+		t.Fatalf("unexpected header %v", header)
+	}
+	var ev []any
+	if err := json.Unmarshal([]byte(lines[1]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev[1] != "o" || !strings.HasSuffix(ev[2].(string), "one\r\ntwo") {
+		t.Fatalf("unexpected first event %v", ev)
+	}
+	if err := json.Unmarshal([]byte(lines[2]), &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev[0] != 1.5 {
+		t.Fatalf("expected second event at 1.5s, got %v", ev[0])
+	}
+}
+
+func TestRecorderWrapRecordsFramesAndResizes(t *testing.T) {
+	var b strings.Builder
+	r := NewRecorder(&b, 10, 2, "")
+	m := r.Wrap(staticModel("frame"))
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 20, Height: 5})
+	_ = m.View()
+	out := b.String()
+	if !strings.Contains(out, `"r","20x5"`) || !strings.Contains(out, "frame") {
+		t.Fatalf("expected resize and frame events:\n%s", out)
+	}
+}
+
+type staticModel string
+
+func (m staticModel) Init() tea.Cmd                       { return nil }
+func (m staticModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return m, nil }
+func (m staticModel) View() tea.View                      { return tea.NewView(string(m)) }
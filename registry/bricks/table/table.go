@@ -15,6 +15,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 )
 
@@ -52,9 +53,24 @@ type Model struct {
 	visual     VisualStyle
 	focused    bool
 	inner      bubblestable.Model
-	theme      theme.Theme // nil = use theme.CurrentTheme()
+	keymap     *keymap.Registry // nil = bubbles/table defaults
+	theme      theme.Theme      // nil = use theme.CurrentTheme()
 }
 
+// Scope and action IDs the table bindings register under in a
+// keymap.Registry.
+const (
+	Scope              keymap.Scope = "table"
+	ActionUp                        = "table.up"
+	ActionDown                      = "table.down"
+	ActionPageUp                    = "table.pageup"
+	ActionPageDown                  = "table.pagedown"
+	ActionHalfPageUp                = "table.halfpageup"
+	ActionHalfPageDown              = "table.halfpagedown"
+	ActionTop                       = "table.top"
+	ActionBottom                    = "table.bottom"
+)
+
 // New creates a table with the given column headers.
 func New(headers ...string) *Model {
 	cols := make([]Column, len(headers))
@@ -153,16 +169,60 @@ func (t *Model) activeTheme() theme.Theme {
 
 func (t *Model) Init() tea.Cmd { return nil }
 
+// SetKeymap reads the table keys from r.
+func (t *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		km := bubblestable.DefaultKeyMap()
+		r.Register(Scope,
+			keymap.FromKey(ActionUp, km.LineUp),
+			keymap.FromKey(ActionDown, km.LineDown),
+			keymap.FromKey(ActionPageUp, km.PageUp),
+			keymap.FromKey(ActionPageDown, km.PageDown),
+			keymap.FromKey(ActionHalfPageUp, km.HalfPageUp),
+			keymap.FromKey(ActionHalfPageDown, km.HalfPageDown),
+			keymap.FromKey(ActionTop, km.GotoTop),
+			keymap.FromKey(ActionBottom, km.GotoBottom),
+		)
+	}
+	t.keymap = r
+	t.syncKeys()
+}
+
+// syncKeys copies the registry's keys into the inner table.
+func (t *Model) syncKeys() {
+	def := bubblestable.DefaultKeyMap()
+	pick := t.keymap.KeyOr
+	t.inner.KeyMap = bubblestable.KeyMap{
+		LineUp:       pick(ActionUp, def.LineUp),
+		LineDown:     pick(ActionDown, def.LineDown),
+		PageUp:       pick(ActionPageUp, def.PageUp),
+		PageDown:     pick(ActionPageDown, def.PageDown),
+		HalfPageUp:   pick(ActionHalfPageUp, def.HalfPageUp),
+		HalfPageDown: pick(ActionHalfPageDown, def.HalfPageDown),
+		GotoTop:      pick(ActionTop, def.GotoTop),
+		GotoBottom:   pick(ActionBottom, def.GotoBottom),
+	}
+}
+
 func (t *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		t.SetSize(msg.Width, msg.Height)
 		return t, nil
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			t.inner.MoveUp(1)
+		case tea.MouseWheelDown:
+			t.inner.MoveDown(1)
+		}
+		return t, nil
 	}
-	if !t.focused {
-		if _, ok := msg.(tea.KeyMsg); ok {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		if !t.focused {
 			return t, nil
 		}
+		t.syncKeys()
 	}
 	updated, cmd := t.inner.Update(msg)
 	t.inner = updated
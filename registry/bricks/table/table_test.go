@@ -83,6 +83,26 @@ func TestFocusBlurControlsKeyboardMovement(t *testing.T) {
 	}
 }
 
+func TestWheelMovesCursor(t *testing.T) {
+	tb := New("NAME")
+	tb.AddRow("one")
+	tb.AddRow("two")
+	tb.AddRow("three")
+	tb.SetSize(20, 5)
+
+	down := tea.MouseWheelMsg{Button: tea.MouseWheelDown}
+	_, _ = tb.Update(down)
+	_, _ = tb.Update(down)
+	_, _ = tb.Update(down)
+	if got := tb.inner.Cursor(); got != 2 {
+		t.Fatalf("cursor = %d after scrolling past the end, want 2", got)
+	}
+	_, _ = tb.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if got := tb.inner.Cursor(); got != 1 {
+		t.Fatalf("cursor = %d after wheel up, want 1", got)
+	}
+}
+
 func TestWindowSizeMsgAppliesSize(t *testing.T) {
 	tb := New("A", "B")
 	_, _ = tb.Update(tea.WindowSizeMsg{Width: 50, Height: 8})
@@ -12,6 +12,8 @@ import (
 	bubbleskey "charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/paginator"
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
 	"github.com/cloudboy-jh/bentotui/theme"
 	"github.com/cloudboy-jh/bentotui/theme/styles"
 )
@@ -33,12 +35,28 @@ func DefaultKeyMap() KeyMap {
 	}
 }
 
+// Scope and action IDs the tab bindings register under in a keymap.Registry.
+const (
+	Scope      keymap.Scope = "tabs"
+	ActionPrev              = "tabs.prev"
+	ActionNext              = "tabs.next"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionPrev, km.Prev),
+		keymap.FromKey(ActionNext, km.Next),
+	}
+}
+
 type Model struct {
 	tabs    []Tab
 	active  int
 	focused bool
 	width   int
 	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
 	pager   paginator.Model
 	theme   theme.Theme // nil = use theme.CurrentTheme()
 }
@@ -89,7 +107,26 @@ func (m *Model) activeTheme() theme.Theme {
 	return theme.CurrentTheme()
 }
 
+// SetKeymap reads the tab keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{Prev: r.KeyOr(ActionPrev, k.Prev), Next: r.KeyOr(ActionNext, k.Next)}
+}
+
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if c, ok := msg.(tea.MouseClickMsg); ok && c.Button == tea.MouseLeft && c.Y == 0 {
+		if i, ok := m.TabAt(c.X); ok {
+			m.SetActive(i)
+		}
+		return m, nil
+	}
 	if !m.focused || len(m.tabs) == 0 {
 		return m, nil
 	}
@@ -97,13 +134,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if !ok {
 		return m, nil
 	}
+	keys := m.keyMap()
 	switch {
-	case bubbleskey.Matches(k, m.keys.Prev):
+	case bubbleskey.Matches(k, keys.Prev):
 		if m.active > 0 {
 			m.active--
 			m.pager.Page = m.active
 		}
-	case bubbleskey.Matches(k, m.keys.Next):
+	case bubbleskey.Matches(k, keys.Next):
 		if m.active < len(m.tabs)-1 {
 			m.active++
 			m.pager.Page = m.active
@@ -117,6 +155,36 @@ func (m *Model) View() tea.View {
 	if len(m.tabs) == 0 {
 		return tea.NewView("")
 	}
+	line := strings.Join(m.parts(), " ")
+	if m.width > 0 {
+		bg := t.BackgroundPanel()
+		fg := t.Text()
+		if m.focused {
+			bg = t.BackgroundInteractive()
+		}
+		if strings.TrimSpace(line) == "" {
+			line = m.pager.View()
+		}
+		return tea.NewView(styles.RowClip(bg, fg, m.width, line))
+	}
+	return tea.NewView(line)
+}
+
+// TabAt returns the tab drawn at column x.
+func (m *Model) TabAt(x int) (int, bool) {
+	start := 0
+	for i, part := range m.parts() {
+		end := start + ansi.StringWidth(part)
+		if x >= start && x < end {
+			return i, true
+		}
+		start = end + 1
+	}
+	return 0, false
+}
+
+// parts renders each tab label, the active one in brackets.
+func (m *Model) parts() []string {
 	parts := make([]string, 0, len(m.tabs))
 	for i, tab := range m.tabs {
 		label := tab.Label
@@ -129,19 +197,7 @@ func (m *Model) View() tea.View {
 			parts = append(parts, " "+label+" ")
 		}
 	}
-	line := strings.Join(parts, " ")
-	if m.width > 0 {
-		bg := t.BackgroundPanel()
-		fg := t.Text()
-		if m.focused {
-			bg = t.BackgroundInteractive()
-		}
-		if strings.TrimSpace(line) == "" {
-			line = m.pager.View()
-		}
-		return tea.NewView(styles.Row(bg, fg, m.width, line))
-	}
-	return tea.NewView(line)
+	return parts
 }
 
 func max(a, b int) int {
@@ -0,0 +1,56 @@
+package tabs
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func TestConformance(t *testing.T) {
+	testkit.RunConformance(t, testkit.Conformance{
+		New: func() testkit.Brick {
+			return New(Tab{ID: "overview", Label: "Overview"}, Tab{ID: "logs", Label: "Logs"})
+		},
+		FixedHeight: 1,
+	})
+}
+
+func TestSetKeymapUsesRegistryKeys(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(Scope, keymap.Binding{Action: ActionNext, Keys: []string{"n"}})
+
+	m := New(Tab{Label: "A"}, Tab{Label: "B"})
+	m.SetKeymap(r)
+	m.Focus()
+	m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if m.Active() != 0 {
+		t.Fatal("the app's binding for tabs.next should replace right")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if m.Active() != 1 {
+		t.Fatal("n should move to the next tab")
+	}
+	if _, ok := r.Lookup(ActionPrev); !ok {
+		t.Fatal("SetKeymap should register the remaining defaults")
+	}
+}
+
+func TestClickSelectsTab(t *testing.T) {
+	m := New(Tab{ID: "overview", Label: "Overview"}, Tab{ID: "logs", Label: "Logs"}, Tab{ID: "errors", Label: "Errors"})
+	m.SetSize(40, 1)
+
+	// [Overview]  Logs   Errors
+	_, _ = m.Update(tea.MouseClickMsg{X: 13, Button: tea.MouseLeft})
+	if m.Active() != 1 {
+		t.Fatalf("active = %d after clicking Logs, want 1", m.Active())
+	}
+	_, _ = m.Update(tea.MouseClickMsg{X: 2, Button: tea.MouseLeft})
+	if m.Active() != 0 {
+		t.Fatalf("active = %d after clicking Overview, want 0", m.Active())
+	}
+	if i, ok := m.TabAt(30); ok {
+		t.Fatalf("TabAt past the last tab = %d", i)
+	}
+}
@@ -0,0 +1,408 @@
+// Brick: TextArea
+// +-----------------------------------+
+// |  1 feat: add the textarea brick   |
+// |  2                                |
+// |  3 Long lines soft wrap at the    |
+// |    pane width.                    |
+// +-----------------------------------+
+// Multi-line text editor backed by bubbles/textarea, with undo/redo.
+// Copy this file into your project: bento add textarea
+package textarea
+
+import (
+	"strings"
+	"unicode"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	bubblestextarea "charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+// KeyMap is the editing keys the brick exposes for remapping. Character
+// movement, deletion and typing use the bubbles/textarea defaults.
+type KeyMap struct {
+	WordForward        bubbleskey.Binding
+	WordBackward       bubbleskey.Binding
+	LineStart          bubbleskey.Binding
+	LineEnd            bubbleskey.Binding
+	InputBegin         bubbleskey.Binding
+	InputEnd           bubbleskey.Binding
+	PageUp             bubbleskey.Binding
+	PageDown           bubbleskey.Binding
+	InsertNewline      bubbleskey.Binding
+	DeleteWordBackward bubbleskey.Binding
+	Undo               bubbleskey.Binding
+	Redo               bubbleskey.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	def := bubblestextarea.DefaultKeyMap()
+	return KeyMap{
+		WordForward:        bubbleskey.NewBinding(bubbleskey.WithKeys("alt+right", "ctrl+right", "alt+f"), bubbleskey.WithHelp("alt+right", "word forward")),
+		WordBackward:       bubbleskey.NewBinding(bubbleskey.WithKeys("alt+left", "ctrl+left", "alt+b"), bubbleskey.WithHelp("alt+left", "word back")),
+		LineStart:          def.LineStart,
+		LineEnd:            def.LineEnd,
+		InputBegin:         def.InputBegin,
+		InputEnd:           def.InputEnd,
+		PageUp:             def.PageUp,
+		PageDown:           def.PageDown,
+		InsertNewline:      def.InsertNewline,
+		DeleteWordBackward: def.DeleteWordBackward,
+		Undo:               bubbleskey.NewBinding(bubbleskey.WithKeys("ctrl+z"), bubbleskey.WithHelp("ctrl+z", "undo")),
+		Redo:               bubbleskey.NewBinding(bubbleskey.WithKeys("ctrl+y"), bubbleskey.WithHelp("ctrl+y", "redo")),
+	}
+}
+
+// Scope and action IDs the textarea bindings register under in a
+// keymap.Registry.
+const (
+	Scope              keymap.Scope = "textarea"
+	ActionWordForward               = "textarea.wordforward"
+	ActionWordBackward              = "textarea.wordbackward"
+	ActionLineStart                 = "textarea.linestart"
+	ActionLineEnd                   = "textarea.lineend"
+	ActionTop                       = "textarea.top"
+	ActionBottom                    = "textarea.bottom"
+	ActionPageUp                    = "textarea.pageup"
+	ActionPageDown                  = "textarea.pagedown"
+	ActionNewline                   = "textarea.newline"
+	ActionDeleteWord                = "textarea.deleteword"
+	ActionUndo                      = "textarea.undo"
+	ActionRedo                      = "textarea.redo"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionWordForward, km.WordForward),
+		keymap.FromKey(ActionWordBackward, km.WordBackward),
+		keymap.FromKey(ActionLineStart, km.LineStart),
+		keymap.FromKey(ActionLineEnd, km.LineEnd),
+		keymap.FromKey(ActionTop, km.InputBegin),
+		keymap.FromKey(ActionBottom, km.InputEnd),
+		keymap.FromKey(ActionPageUp, km.PageUp),
+		keymap.FromKey(ActionPageDown, km.PageDown),
+		keymap.FromKey(ActionNewline, km.InsertNewline),
+		keymap.FromKey(ActionDeleteWord, km.DeleteWordBackward),
+		keymap.FromKey(ActionUndo, km.Undo),
+		keymap.FromKey(ActionRedo, km.Redo),
+	}
+}
+
+// defaultMaxLines caps the line count. It also sizes the line number
+// gutter, which bubbles/textarea derives from the cap.
+const defaultMaxLines = 999
+
+// undoLimit is how many edits Undo can step back through.
+const undoLimit = 100
+
+type Option func(*Model)
+
+// Placeholder sets the text shown while the area is empty.
+func Placeholder(s string) Option { return func(m *Model) { m.inner.Placeholder = s } }
+
+// LineNumbers shows a line number gutter. Soft-wrapped rows leave it blank.
+func LineNumbers() Option { return func(m *Model) { m.inner.ShowLineNumbers = true } }
+
+// MaxLength caps the text at n characters, newlines included. 0 = no limit.
+func MaxLength(n int) Option { return func(m *Model) { m.inner.CharLimit = max(0, n) } }
+
+// MaxLines caps the number of lines (default 999).
+func MaxLines(n int) Option { return func(m *Model) { m.inner.MaxHeight = max(1, n) } }
+
+// Value sets the initial text. It is not undoable.
+func Value(s string) Option { return func(m *Model) { m.inner.SetValue(s) } }
+
+// WithTheme sets the theme for this textarea instance.
+func WithTheme(t theme.Theme) Option { return func(m *Model) { m.theme = t } }
+
+// snapshot is the text and cursor before or after an edit.
+type snapshot struct {
+	value    string
+	row, col int
+}
+
+// editKind groups consecutive edits into one undo step.
+type editKind int
+
+const (
+	editNone editKind = iota
+	editTyping
+	editOther
+)
+
+// Model is a themed multi-line text editor. Long lines soft wrap at the
+// pane width and every row is painted exactly that wide.
+type Model struct {
+	inner   bubblestextarea.Model
+	width   int
+	height  int
+	undo    []snapshot
+	redo    []snapshot
+	last    editKind // kind of the last edit, so runs of typing undo together
+	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
+	theme   theme.Theme      // nil = use theme.CurrentTheme()
+	focused bool
+}
+
+func New(opts ...Option) *Model {
+	ta := bubblestextarea.New()
+	ta.Prompt = ""
+	ta.ShowLineNumbers = false
+	ta.MaxHeight = defaultMaxLines
+	ta.MaxWidth = 0
+	ta.Blur()
+	m := &Model{inner: ta, keys: DefaultKeyMap()}
+	for _, opt := range opts {
+		opt(m)
+	}
+	m.syncKeys()
+	return m
+}
+
+func (m *Model) Value() string { return m.inner.Value() }
+
+// SetValue replaces the text and moves the cursor to the end. It clears
+// the undo history.
+func (m *Model) SetValue(s string) {
+	m.inner.SetValue(s)
+	m.undo, m.redo, m.last = nil, nil, editNone
+}
+
+// Length is the number of characters, newlines included.
+func (m *Model) Length() int    { return m.inner.Length() }
+func (m *Model) LineCount() int { return m.inner.LineCount() }
+
+// Cursor returns the cursor's line and its column in characters.
+func (m *Model) Cursor() (line, col int) {
+	li := m.inner.LineInfo()
+	return m.inner.Line(), li.StartColumn + li.ColumnOffset
+}
+
+func (m *Model) SetPlaceholder(s string) { m.inner.Placeholder = s }
+func (m *Model) SetLineNumbers(v bool)   { m.inner.ShowLineNumbers = v; m.resize() }
+func (m *Model) SetMaxLength(n int)      { m.inner.CharLimit = max(0, n) }
+
+func (m *Model) Focus() {
+	m.focused = true
+	m.inner.Focus()
+}
+
+func (m *Model) Blur() {
+	m.focused = false
+	m.inner.Blur()
+}
+
+func (m *Model) IsFocused() bool { return m.focused }
+func (m *Model) Init() tea.Cmd   { return nil }
+
+// SetTheme updates the theme. Call on ThemeChangedMsg.
+func (m *Model) SetTheme(t theme.Theme) { m.theme = t }
+
+func (m *Model) activeTheme() theme.Theme {
+	if m.theme != nil {
+		return m.theme
+	}
+	return theme.CurrentTheme()
+}
+
+// SetKeymap reads the textarea keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+	m.syncKeys()
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{
+		WordForward:        r.KeyOr(ActionWordForward, k.WordForward),
+		WordBackward:       r.KeyOr(ActionWordBackward, k.WordBackward),
+		LineStart:          r.KeyOr(ActionLineStart, k.LineStart),
+		LineEnd:            r.KeyOr(ActionLineEnd, k.LineEnd),
+		InputBegin:         r.KeyOr(ActionTop, k.InputBegin),
+		InputEnd:           r.KeyOr(ActionBottom, k.InputEnd),
+		PageUp:             r.KeyOr(ActionPageUp, k.PageUp),
+		PageDown:           r.KeyOr(ActionPageDown, k.PageDown),
+		InsertNewline:      r.KeyOr(ActionNewline, k.InsertNewline),
+		DeleteWordBackward: r.KeyOr(ActionDeleteWord, k.DeleteWordBackward),
+		Undo:               r.KeyOr(ActionUndo, k.Undo),
+		Redo:               r.KeyOr(ActionRedo, k.Redo),
+	}
+}
+
+// syncKeys copies the remappable keys into the inner textarea.
+func (m *Model) syncKeys() {
+	keys := m.keyMap()
+	km := &m.inner.KeyMap
+	km.WordForward, km.WordBackward = keys.WordForward, keys.WordBackward
+	km.LineStart, km.LineEnd = keys.LineStart, keys.LineEnd
+	km.InputBegin, km.InputEnd = keys.InputBegin, keys.InputEnd
+	km.PageUp, km.PageDown = keys.PageUp, keys.PageDown
+	km.InsertNewline = keys.InsertNewline
+	km.DeleteWordBackward = keys.DeleteWordBackward
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.KeyPressMsg:
+		if !m.focused {
+			return m, nil
+		}
+		keys := m.keyMap()
+		switch {
+		case bubbleskey.Matches(msg, keys.Undo):
+			m.Undo()
+			return m, nil
+		case bubbleskey.Matches(msg, keys.Redo):
+			m.Redo()
+			return m, nil
+		}
+	case tea.PasteMsg:
+		// Bracketed paste arrives as one message, so it undoes as one step
+		// and newlines in it never trigger InsertNewline's binding.
+		if !m.focused {
+			return m, nil
+		}
+	}
+
+	before := m.snapshot()
+	m.syncKeys()
+	updated, cmd := m.inner.Update(msg)
+	m.inner = updated
+	m.record(before, kindOf(msg))
+	return m, cmd
+}
+
+// kindOf tells typing, which undoes a word at a time, from other edits.
+func kindOf(msg tea.Msg) editKind {
+	k, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return editOther
+	}
+	r := []rune(k.Text)
+	if len(r) == 1 && !unicode.IsSpace(r[0]) {
+		return editTyping
+	}
+	return editOther
+}
+
+// record pushes the state before an edit onto the undo stack, unless the
+// edit continues a run of typing.
+func (m *Model) record(before snapshot, kind editKind) {
+	if m.inner.Value() == before.value {
+		if before != m.snapshot() {
+			m.last = editNone // the cursor moved, so the next edit starts a step
+		}
+		return
+	}
+	m.redo = nil
+	if kind == editTyping && m.last == editTyping {
+		return
+	}
+	m.undo = append(m.undo, before)
+	if len(m.undo) > undoLimit {
+		m.undo = m.undo[1:]
+	}
+	m.last = kind
+}
+
+// Undo reverts the last edit. It reports false when there is none.
+func (m *Model) Undo() bool {
+	if len(m.undo) == 0 {
+		return false
+	}
+	m.redo = append(m.redo, m.snapshot())
+	m.restore(m.undo[len(m.undo)-1])
+	m.undo = m.undo[:len(m.undo)-1]
+	return true
+}
+
+// Redo reapplies the last undone edit. It reports false when there is none.
+func (m *Model) Redo() bool {
+	if len(m.redo) == 0 {
+		return false
+	}
+	m.undo = append(m.undo, m.snapshot())
+	m.restore(m.redo[len(m.redo)-1])
+	m.redo = m.redo[:len(m.redo)-1]
+	return true
+}
+
+func (m *Model) snapshot() snapshot {
+	line, col := m.Cursor()
+	return snapshot{value: m.inner.Value(), row: line, col: col}
+}
+
+func (m *Model) restore(s snapshot) {
+	m.inner.SetValue(s.value)
+	m.inner.MoveToBegin()
+	// CursorDown steps over soft-wrapped rows, so walk until the line is right.
+	for i := 0; m.inner.Line() < s.row && i < len(s.value); i++ {
+		m.inner.CursorDown()
+	}
+	m.inner.SetCursorColumn(s.col)
+	m.last = editNone
+}
+
+func (m *Model) View() tea.View {
+	if m.width <= 0 || m.height <= 0 {
+		return tea.NewView("")
+	}
+	t := m.activeTheme()
+	m.syncStyles(t)
+	bg, fg := t.InputBG(), t.InputFG()
+
+	lines := strings.Split(m.inner.View(), "\n")
+	rows := make([]string, m.height)
+	for i := range rows {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+		}
+		rows[i] = styles.RowClip(bg, fg, m.width, styles.Reopen(line, bg, fg))
+	}
+	return tea.NewView(strings.Join(rows, "\n"))
+}
+
+func (m *Model) syncStyles(t theme.Theme) {
+	base := lipgloss.NewStyle().Background(t.InputBG()).Foreground(t.InputFG())
+	state := bubblestextarea.StyleState{
+		Base:             base,
+		Text:             base,
+		CursorLine:       base,
+		LineNumber:       base.Foreground(t.TextMuted()),
+		CursorLineNumber: base.Foreground(t.TextAccent()),
+		EndOfBuffer:      base.Foreground(t.TextMuted()),
+		Placeholder:      base.Foreground(t.InputPlaceholder()),
+		Prompt:           base,
+	}
+	m.inner.SetStyles(bubblestextarea.Styles{
+		Focused: state,
+		Blurred: state,
+		Cursor:  bubblestextarea.CursorStyle{Color: t.InputCursor()},
+	})
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = max(0, width)
+	m.height = max(0, height)
+	m.resize()
+}
+
+func (m *Model) resize() {
+	m.inner.SetWidth(max(1, m.width))
+	m.inner.SetHeight(max(1, m.height))
+}
+
+func (m *Model) GetSize() (int, int) { return m.width, m.height }
@@ -0,0 +1,154 @@
+package textarea
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func TestConformance(t *testing.T) {
+	t.Run("plain", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New: func() testkit.Brick { return New(Value("feat: first line\n\nbody text that runs long")) },
+		})
+	})
+	t.Run("line numbers", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New: func() testkit.Brick { return New(LineNumbers(), Placeholder("Commit message")) },
+		})
+	})
+}
+
+func TestSoftWrapKeepsRowsExact(t *testing.T) {
+	m := New()
+	m.Focus()
+	d := testkit.Boot(t, m, 12, 4)
+	d.Type("the quick brown fox jumps")
+
+	lines := d.Frame().Lines()
+	if len(lines) != 4 {
+		t.Fatalf("rows = %d, want 4", len(lines))
+	}
+	for i, l := range lines {
+		if w := ansi.StringWidth(l); w != 12 {
+			t.Fatalf("row %d is %d wide: %q", i, w, l)
+		}
+	}
+	if m.LineCount() != 1 || !strings.Contains(lines[1], "brown") {
+		t.Fatalf("the line should wrap, not split:\n%s", strings.Join(lines, "\n"))
+	}
+}
+
+func TestLineNumbers(t *testing.T) {
+	m := New(LineNumbers(), Value("one\ntwo"))
+	m.SetSize(20, 3)
+	lines := testkit.FrameOf(m.View()).Lines()
+	if !strings.Contains(lines[0], "1 one") || !strings.Contains(lines[1], "2 two") {
+		t.Fatalf("numbered rows = %q", lines)
+	}
+	m.SetLineNumbers(false)
+	if lines = testkit.FrameOf(m.View()).Lines(); strings.Contains(lines[0], "1") {
+		t.Fatalf("gutter still shown: %q", lines[0])
+	}
+}
+
+func TestMaxLength(t *testing.T) {
+	m := New(MaxLength(5))
+	m.Focus()
+	testkit.Boot(t, m, 20, 3).Type("abcdefgh").Send(tea.PasteMsg{Content: "xyz"})
+	if m.Value() != "abcde" {
+		t.Fatalf("value = %q, want it capped at 5", m.Value())
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	m := New()
+	m.Focus()
+	d := testkit.Boot(t, m, 30, 3)
+	d.Type("hello world")
+	if m.Value() != "hello world" {
+		t.Fatalf("value = %q", m.Value())
+	}
+
+	d.Press("ctrl+z")
+	if m.Value() != "hello " {
+		t.Fatalf("undo should drop the last word, got %q", m.Value())
+	}
+	d.Press("ctrl+z", "ctrl+z")
+	if m.Value() != "" {
+		t.Fatalf("undo to the start, got %q", m.Value())
+	}
+	if m.Undo() {
+		t.Fatal("nothing left to undo")
+	}
+	d.Press("ctrl+y", "ctrl+y", "ctrl+y")
+	if m.Value() != "hello world" {
+		t.Fatalf("redo should restore, got %q", m.Value())
+	}
+	if line, col := m.Cursor(); line != 0 || col != 11 {
+		t.Fatalf("cursor = %d,%d after redo, want 0,11", line, col)
+	}
+
+	d.Press("ctrl+z").Type("!")
+	if m.Redo() {
+		t.Fatal("a new edit should clear the redo stack")
+	}
+	d.Send(tea.PasteMsg{Content: "one\ntwo"}).Press("ctrl+z")
+	if m.Value() != "hello !" {
+		t.Fatalf("a paste should undo in one step, got %q", m.Value())
+	}
+}
+
+func TestWordMotionAndNewline(t *testing.T) {
+	m := New()
+	m.Focus()
+	d := testkit.Boot(t, m, 30, 3)
+	d.Type("alpha beta gamma").Press("alt+left", "alt+left")
+	if _, col := m.Cursor(); col != 6 {
+		t.Fatalf("cursor col = %d after two words back, want 6", col)
+	}
+	d.Press("ctrl+right")
+	if _, col := m.Cursor(); col != 10 {
+		t.Fatalf("cursor col = %d after a word forward, want 10", col)
+	}
+	d.Press("enter")
+	if m.Value() != "alpha beta\n gamma" {
+		t.Fatalf("value = %q after enter", m.Value())
+	}
+	if line, _ := m.Cursor(); line != 1 {
+		t.Fatalf("cursor line = %d, want 1", line)
+	}
+}
+
+func TestBlurredIgnoresInput(t *testing.T) {
+	m := New(Value("keep"))
+	d := testkit.Boot(t, m, 20, 3)
+	d.Type("x").Send(tea.PasteMsg{Content: "y"}).Press("ctrl+z")
+	if m.Value() != "keep" {
+		t.Fatalf("value = %q while blurred", m.Value())
+	}
+}
+
+func TestSetKeymapUsesRegistryKeys(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(Scope, keymap.Binding{Action: ActionUndo, Keys: []string{"ctrl+u"}})
+	m := New()
+	m.SetKeymap(r)
+	m.Focus()
+	d := testkit.Boot(t, m, 20, 3)
+	d.Type("abc").Press("ctrl+z")
+	if m.Value() != "abc" {
+		t.Fatal("ctrl+z should no longer undo")
+	}
+	d.Press("ctrl+u")
+	if m.Value() != "" {
+		t.Fatalf("ctrl+u should undo, got %q", m.Value())
+	}
+	if _, ok := r.Lookup(ActionRedo); !ok {
+		t.Fatal("SetKeymap should register the remaining defaults")
+	}
+}
@@ -0,0 +1,817 @@
+// Brick: Tree
+// +-----------------------------------+
+// | ▾ cluster                         |
+// | ├─▾ default                       |
+// | │ ├── api-7f9c        Running     |
+// | │ └── worker-2d1a     Pending     |
+// | └─▸ kube-system                   |
+// +-----------------------------------+
+// Expandable tree with lazy-loaded children, indentation guides, per-node
+// icons and tones, and a filter that keeps ancestors of matches visible.
+// Copy this file into your project: bento add tree
+package tree
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"unicode"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+type Tone string
+
+const (
+	ToneNeutral Tone = "neutral"
+	ToneInfo    Tone = "info"
+	ToneSuccess Tone = "success"
+	ToneWarn    Tone = "warn"
+	ToneDanger  Tone = "danger"
+)
+
+// Node is one entry in the tree. Set Lazy on a node whose children come
+// from the Provider the first time it is expanded.
+type Node struct {
+	ID       string
+	Label    string
+	Icon     string // drawn before the label in the node's tone
+	Detail   string // right-aligned, muted
+	Tone     Tone
+	Children []*Node
+	Lazy     bool
+	Expanded bool
+	Data     any
+
+	loaded  bool
+	loading bool
+	err     error
+}
+
+// IsBranch reports whether n has or may load children.
+func (n *Node) IsBranch() bool { return len(n.Children) > 0 || (n.Lazy && !n.loaded) }
+
+// Provider loads the children of a Lazy node. The returned command must
+// produce a ChildrenMsg for n.
+type Provider func(n *Node) tea.Cmd
+
+// ChildrenMsg delivers the children of Parent. With Err set the node shows
+// the error under it and stays unloaded, so expanding it again retries.
+type ChildrenMsg struct {
+	Parent   *Node
+	Children []*Node
+	Err      error
+}
+
+type KeyMap struct {
+	Up       bubbleskey.Binding
+	Down     bubbleskey.Binding
+	PageUp   bubbleskey.Binding
+	PageDown bubbleskey.Binding
+	Top      bubbleskey.Binding
+	Bottom   bubbleskey.Binding
+	Collapse bubbleskey.Binding
+	Expand   bubbleskey.Binding
+	Toggle   bubbleskey.Binding
+	Select   bubbleskey.Binding
+	Filter   bubbleskey.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:       bubbleskey.NewBinding(bubbleskey.WithKeys("up", "k"), bubbleskey.WithHelp("↑/k", "up")),
+		Down:     bubbleskey.NewBinding(bubbleskey.WithKeys("down", "j"), bubbleskey.WithHelp("↓/j", "down")),
+		PageUp:   bubbleskey.NewBinding(bubbleskey.WithKeys("pgup"), bubbleskey.WithHelp("pgup", "page up")),
+		PageDown: bubbleskey.NewBinding(bubbleskey.WithKeys("pgdown"), bubbleskey.WithHelp("pgdown", "page down")),
+		Top:      bubbleskey.NewBinding(bubbleskey.WithKeys("home", "g"), bubbleskey.WithHelp("g", "top")),
+		Bottom:   bubbleskey.NewBinding(bubbleskey.WithKeys("end", "G"), bubbleskey.WithHelp("G", "bottom")),
+		Collapse: bubbleskey.NewBinding(bubbleskey.WithKeys("left", "h"), bubbleskey.WithHelp("←/h", "collapse")),
+		Expand:   bubbleskey.NewBinding(bubbleskey.WithKeys("right", "l"), bubbleskey.WithHelp("→/l", "expand")),
+		Toggle:   bubbleskey.NewBinding(bubbleskey.WithKeys("space"), bubbleskey.WithHelp("space", "toggle")),
+		Select:   bubbleskey.NewBinding(bubbleskey.WithKeys("enter"), bubbleskey.WithHelp("enter", "open")),
+		Filter:   bubbleskey.NewBinding(bubbleskey.WithKeys("/"), bubbleskey.WithHelp("/", "filter")),
+	}
+}
+
+// Scope and action IDs the tree bindings register under in a
+// keymap.Registry.
+const (
+	Scope          keymap.Scope = "tree"
+	ActionUp                    = "tree.up"
+	ActionDown                  = "tree.down"
+	ActionPageUp                = "tree.pageup"
+	ActionPageDown              = "tree.pagedown"
+	ActionTop                   = "tree.top"
+	ActionBottom                = "tree.bottom"
+	ActionCollapse              = "tree.collapse"
+	ActionExpand                = "tree.expand"
+	ActionToggle                = "tree.toggle"
+	ActionSelect                = "tree.select"
+	ActionFilter                = "tree.filter"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionUp, km.Up),
+		keymap.FromKey(ActionDown, km.Down),
+		keymap.FromKey(ActionPageUp, km.PageUp),
+		keymap.FromKey(ActionPageDown, km.PageDown),
+		keymap.FromKey(ActionTop, km.Top),
+		keymap.FromKey(ActionBottom, km.Bottom),
+		keymap.FromKey(ActionCollapse, km.Collapse),
+		keymap.FromKey(ActionExpand, km.Expand),
+		keymap.FromKey(ActionToggle, km.Toggle),
+		keymap.FromKey(ActionSelect, km.Select),
+		keymap.FromKey(ActionFilter, km.Filter),
+	}
+}
+
+type Option func(*Model)
+
+// Roots sets the top-level nodes.
+func Roots(nodes ...*Node) Option { return func(m *Model) { m.SetRoots(nodes...) } }
+
+// WithProvider sets how Lazy nodes load their children.
+func WithProvider(p Provider) Option { return func(m *Model) { m.provider = p } }
+
+// OnSelect sets the message emitted when enter is pressed on a leaf.
+func OnSelect(fn func(n *Node) tea.Msg) Option { return func(m *Model) { m.onSelect = fn } }
+
+// WithTheme sets the theme for this tree instance.
+func WithTheme(t theme.Theme) Option { return func(m *Model) { m.theme = t } }
+
+type rowKind int
+
+const (
+	rowNode rowKind = iota
+	rowLoading
+	rowError
+)
+
+// row is one visible line: a node, or the loading/error note under one.
+type row struct {
+	kind   rowKind
+	node   *Node
+	parent *Node
+	// guides holds, for each ancestor level, whether that ancestor was the
+	// last of its siblings; the final entry is for the row itself.
+	guides []bool
+}
+
+// Model is a navigable tree. Expanded state lives on the nodes, so the
+// same nodes can be shown again without losing it.
+type Model struct {
+	roots    []*Node
+	cursor   int
+	offset   int
+	provider Provider
+	onSelect func(n *Node) tea.Msg
+
+	filter    string
+	filtering bool // the filter prompt is open
+	saved     string
+
+	width   int
+	height  int
+	focused bool
+	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
+	theme   theme.Theme      // nil = use theme.CurrentTheme()
+}
+
+func New(opts ...Option) *Model {
+	m := &Model{keys: DefaultKeyMap()}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SetRoots replaces the tree and moves the cursor to the top.
+func (m *Model) SetRoots(nodes ...*Node) {
+	m.roots = append([]*Node(nil), nodes...)
+	m.cursor, m.offset = 0, 0
+}
+
+func (m *Model) Roots() []*Node { return m.roots }
+
+// Selected returns the node under the cursor, or nil.
+func (m *Model) Selected() *Node {
+	rows := m.rows()
+	if m.cursor < 0 || m.cursor >= len(rows) {
+		return nil
+	}
+	return rows[m.cursor].node
+}
+
+// Find returns the first node with id, depth first, among loaded nodes.
+func (m *Model) Find(id string) *Node {
+	if path := m.path(id); path != nil {
+		return path[len(path)-1]
+	}
+	return nil
+}
+
+// path returns the nodes from a root down to the node with id.
+func (m *Model) path(id string) []*Node {
+	var walk func(nodes []*Node, trail []*Node) []*Node
+	walk = func(nodes []*Node, trail []*Node) []*Node {
+		for _, n := range nodes {
+			next := append(trail[:len(trail):len(trail)], n)
+			if n.ID == id {
+				return next
+			}
+			if p := walk(n.Children, next); p != nil {
+				return p
+			}
+		}
+		return nil
+	}
+	return walk(m.roots, nil)
+}
+
+// Select expands the ancestors of the node with id and moves the cursor to
+// it. It reports false when no loaded node has that id or the filter hides
+// it.
+func (m *Model) Select(id string) bool {
+	path := m.path(id)
+	if path == nil {
+		return false
+	}
+	for _, n := range path[:len(path)-1] {
+		n.Expanded = true
+	}
+	for i, r := range m.rows() {
+		if r.kind == rowNode && r.node == path[len(path)-1] {
+			m.setCursor(i)
+			return true
+		}
+	}
+	return false
+}
+
+// Expand opens n, returning the Provider's command when n is Lazy and not
+// loaded yet.
+func (m *Model) Expand(n *Node) tea.Cmd {
+	if n == nil || !n.IsBranch() {
+		return nil
+	}
+	n.Expanded = true
+	if !n.Lazy || n.loaded || n.loading || m.provider == nil {
+		return nil
+	}
+	n.loading, n.err = true, nil
+	return m.provider(n)
+}
+
+// Collapse closes n.
+func (m *Model) Collapse(n *Node) {
+	if n != nil {
+		n.Expanded = false
+		m.setCursor(m.cursor)
+	}
+}
+
+// SetFilter shows only nodes whose label contains query, with their
+// ancestors. Matching ignores case unless query has an upper-case letter.
+// Children of Lazy nodes that were never loaded are not searched.
+// The cursor stays on the selected node while it matches, and moves to the
+// first match otherwise.
+func (m *Model) SetFilter(query string) {
+	sel := m.Selected()
+	m.filter = query
+	rows := m.rows()
+	first := -1
+	for i, r := range rows {
+		if r.kind != rowNode {
+			continue
+		}
+		if !m.matches(r.node) {
+			continue
+		}
+		if r.node == sel {
+			m.setCursor(i)
+			return
+		}
+		if first < 0 {
+			first = i
+		}
+	}
+	m.setCursor(max(0, first))
+}
+
+func (m *Model) Filter() string { return m.filter }
+
+func (m *Model) matches(n *Node) bool {
+	if m.filter == "" || n == nil {
+		return true
+	}
+	if strings.ContainsFunc(m.filter, unicode.IsUpper) {
+		return strings.Contains(n.Label, m.filter)
+	}
+	return strings.Contains(strings.ToLower(n.Label), strings.ToLower(m.filter))
+}
+
+// keep reports whether n or one of its loaded descendants matches.
+func (m *Model) keep(n *Node) bool {
+	if m.matches(n) {
+		return true
+	}
+	for _, c := range n.Children {
+		if m.keep(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// rows flattens the visible tree. While filtering, every ancestor of a
+// match is shown open and other nodes are hidden.
+func (m *Model) rows() []row {
+	var out []row
+	var walk func(nodes []*Node, parent *Node, guides []bool)
+	walk = func(nodes []*Node, parent *Node, guides []bool) {
+		shown := nodes
+		if m.filter != "" {
+			shown = nil
+			for _, n := range nodes {
+				if m.keep(n) {
+					shown = append(shown, n)
+				}
+			}
+		}
+		for i, n := range shown {
+			g := append(guides[:len(guides):len(guides)], i == len(shown)-1)
+			out = append(out, row{kind: rowNode, node: n, parent: parent, guides: g})
+			if !m.isOpen(n) {
+				continue
+			}
+			switch {
+			case n.loading:
+				out = append(out, row{kind: rowLoading, node: n, parent: n, guides: append(g[:len(g):len(g)], true)})
+			case n.err != nil:
+				out = append(out, row{kind: rowError, node: n, parent: n, guides: append(g[:len(g):len(g)], true)})
+			default:
+				walk(n.Children, n, g)
+			}
+		}
+	}
+	walk(m.roots, nil, nil)
+	return out
+}
+
+// isOpen reports whether n's children are shown: while filtering, exactly
+// when one of them leads to a match.
+func (m *Model) isOpen(n *Node) bool {
+	if m.filter == "" {
+		return n.Expanded
+	}
+	return m.hasMatchBelow(n)
+}
+
+func (m *Model) hasMatchBelow(n *Node) bool {
+	for _, c := range n.Children {
+		if m.keep(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) Focus()          { m.focused = true }
+func (m *Model) IsFocused() bool { return m.focused }
+func (m *Model) Init() tea.Cmd   { return nil }
+
+// Blur also closes the filter prompt, keeping the filter.
+func (m *Model) Blur() {
+	m.focused = false
+	m.filtering = false
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width, m.height = max(0, width), max(0, height)
+	m.setCursor(m.cursor)
+}
+
+func (m *Model) GetSize() (int, int) { return m.width, m.height }
+
+// SetTheme updates the theme. Call on ThemeChangedMsg.
+func (m *Model) SetTheme(t theme.Theme) { m.theme = t }
+
+func (m *Model) activeTheme() theme.Theme {
+	if m.theme != nil {
+		return m.theme
+	}
+	return theme.CurrentTheme()
+}
+
+// SetKeymap reads the tree keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{
+		Up:       r.KeyOr(ActionUp, k.Up),
+		Down:     r.KeyOr(ActionDown, k.Down),
+		PageUp:   r.KeyOr(ActionPageUp, k.PageUp),
+		PageDown: r.KeyOr(ActionPageDown, k.PageDown),
+		Top:      r.KeyOr(ActionTop, k.Top),
+		Bottom:   r.KeyOr(ActionBottom, k.Bottom),
+		Collapse: r.KeyOr(ActionCollapse, k.Collapse),
+		Expand:   r.KeyOr(ActionExpand, k.Expand),
+		Toggle:   r.KeyOr(ActionToggle, k.Toggle),
+		Select:   r.KeyOr(ActionSelect, k.Select),
+		Filter:   r.KeyOr(ActionFilter, k.Filter),
+	}
+}
+
+// statusRows is 1 while the filter prompt or an active filter takes the
+// bottom row.
+func (m *Model) statusRows() int {
+	if m.height >= 2 && (m.filtering || m.filter != "") {
+		return 1
+	}
+	return 0
+}
+
+func (m *Model) viewRows() int { return max(1, m.height-m.statusRows()) }
+
+// setCursor clamps the cursor to the visible rows and scrolls it into view.
+func (m *Model) setCursor(i int) {
+	n := len(m.rows())
+	m.cursor = max(0, min(i, n-1))
+	view := m.viewRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+view {
+		m.offset = m.cursor - view + 1
+	}
+	m.offset = max(0, min(m.offset, n-view))
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+	case ChildrenMsg:
+		m.loaded(msg)
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			m.setCursor(m.cursor - 1)
+		case tea.MouseWheelDown:
+			m.setCursor(m.cursor + 1)
+		}
+	case tea.MouseClickMsg:
+		return m, m.click(msg)
+	case tea.KeyPressMsg:
+		if !m.focused {
+			return m, nil
+		}
+		if m.filtering {
+			m.updatePrompt(msg)
+			return m, nil
+		}
+		return m, m.updateKeys(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) loaded(msg ChildrenMsg) {
+	n := msg.Parent
+	if n == nil {
+		return
+	}
+	sel := m.Selected()
+	n.loading = false
+	if msg.Err != nil {
+		n.err = msg.Err
+		return
+	}
+	n.Children, n.loaded, n.err = msg.Children, true, nil
+	for i, r := range m.rows() {
+		if r.node == sel && r.kind == rowNode {
+			m.setCursor(i)
+			return
+		}
+	}
+	m.setCursor(m.cursor)
+}
+
+// click selects the row under the pointer; a click on a branch's marker
+// toggles it.
+func (m *Model) click(msg tea.MouseClickMsg) tea.Cmd {
+	if msg.Button != tea.MouseLeft || msg.Y < 0 || msg.Y >= m.viewRows() {
+		return nil
+	}
+	rows := m.rows()
+	i := m.offset + msg.Y
+	if i >= len(rows) || rows[i].kind != rowNode {
+		return nil
+	}
+	m.setCursor(i)
+	marker := 2 * (len(rows[i].guides) - 1)
+	if msg.X >= marker && msg.X < marker+2 {
+		return m.toggle(rows[i].node)
+	}
+	return nil
+}
+
+// toggle opens or closes n. The filter decides what is open while it is
+// set, so toggling does nothing then.
+func (m *Model) toggle(n *Node) tea.Cmd {
+	if m.filter != "" {
+		return nil
+	}
+	if n.Expanded {
+		m.Collapse(n)
+		return nil
+	}
+	return m.Expand(n)
+}
+
+func (m *Model) updateKeys(k tea.KeyPressMsg) tea.Cmd {
+	keys := m.keyMap()
+	rows := m.rows()
+	var cur row
+	if m.cursor < len(rows) {
+		cur = rows[m.cursor]
+	}
+	switch {
+	case bubbleskey.Matches(k, keys.Up):
+		m.setCursor(m.cursor - 1)
+	case bubbleskey.Matches(k, keys.Down):
+		m.setCursor(m.cursor + 1)
+	case bubbleskey.Matches(k, keys.PageUp):
+		m.setCursor(m.cursor - m.viewRows())
+	case bubbleskey.Matches(k, keys.PageDown):
+		m.setCursor(m.cursor + m.viewRows())
+	case bubbleskey.Matches(k, keys.Top):
+		m.setCursor(0)
+	case bubbleskey.Matches(k, keys.Bottom):
+		m.setCursor(len(rows) - 1)
+	case bubbleskey.Matches(k, keys.Filter):
+		m.filtering, m.saved = true, m.filter
+		m.setCursor(m.cursor)
+	case cur.node == nil:
+	case bubbleskey.Matches(k, keys.Collapse):
+		// Close an open branch, otherwise step out to the parent.
+		if cur.kind == rowNode && m.isOpen(cur.node) && cur.node.IsBranch() && m.filter == "" {
+			m.Collapse(cur.node)
+		} else if cur.parent != nil {
+			m.cursorTo(rows, cur.parent)
+		}
+	case bubbleskey.Matches(k, keys.Expand):
+		// Open a closed branch, otherwise step into its first child.
+		if !cur.node.IsBranch() || cur.kind != rowNode {
+			return nil
+		}
+		if m.isOpen(cur.node) {
+			m.setCursor(m.cursor + 1)
+		} else if m.filter == "" {
+			return m.Expand(cur.node)
+		}
+	case bubbleskey.Matches(k, keys.Toggle):
+		if cur.kind == rowNode && m.filter == "" {
+			return m.toggle(cur.node)
+		}
+	case bubbleskey.Matches(k, keys.Select):
+		if cur.kind != rowNode {
+			return nil
+		}
+		if cur.node.IsBranch() && m.filter == "" {
+			return m.toggle(cur.node)
+		}
+		if m.onSelect != nil {
+			n := cur.node
+			return func() tea.Msg { return m.onSelect(n) }
+		}
+	}
+	return nil
+}
+
+func (m *Model) cursorTo(rows []row, n *Node) {
+	for i, r := range rows {
+		if r.kind == rowNode && r.node == n {
+			m.setCursor(i)
+			return
+		}
+	}
+}
+
+// updatePrompt edits the filter, reapplying it on every keystroke.
+func (m *Model) updatePrompt(k tea.KeyPressMsg) {
+	query := m.filter
+	switch k.String() {
+	case "esc":
+		m.filtering = false
+		m.SetFilter(m.saved)
+		return
+	case "enter":
+		m.filtering = false
+		m.setCursor(m.cursor)
+		return
+	case "backspace":
+		if query == "" {
+			m.filtering = false
+			m.setCursor(m.cursor)
+			return
+		}
+		r := []rune(query)
+		query = string(r[:len(r)-1])
+	default:
+		if k.Text == "" {
+			return
+		}
+		query += k.Text
+	}
+	m.SetFilter(query)
+}
+
+// ── rendering ─────────────────────────────────────────────────────────────────
+
+func (m *Model) View() tea.View {
+	if m.width <= 0 || m.height <= 0 {
+		return tea.NewView("")
+	}
+	t := m.activeTheme()
+	rows := m.rows()
+	out := make([]string, 0, m.height)
+	for i := m.offset; len(out) < m.height-m.statusRows(); i++ {
+		if i >= len(rows) {
+			out = append(out, styles.Row(t.BackgroundPanel(), t.Text(), m.width, ""))
+			continue
+		}
+		out = append(out, m.renderRow(t, rows[i], i == m.cursor))
+	}
+	if m.statusRows() > 0 {
+		out = append(out, m.renderStatus(t))
+	}
+	return tea.NewView(strings.Join(out, "\n"))
+}
+
+// renderRow draws guides, the expand marker, icon, label and detail, on
+// the same row colors list uses.
+func (m *Model) renderRow(t theme.Theme, r row, selected bool) string {
+	bg, fg := t.BackgroundPanel(), t.Text()
+	switch {
+	case selected && m.focused:
+		bg, fg = t.SelectionBG(), t.SelectionFG()
+	case selected:
+		bg, fg = t.BackgroundInteractive(), t.TextAccent()
+	}
+	plain := lipgloss.NewStyle().Background(bg).Foreground(fg)
+	muted := plain.Foreground(t.TextMuted())
+	tone := plain
+	if c := toneColor(t, r.node.Tone); c != nil && !(selected && m.focused) {
+		tone = plain.Foreground(c)
+	}
+	if selected && m.focused {
+		muted = plain
+	}
+
+	var b strings.Builder
+	b.WriteString(muted.Render(guides(r.guides)))
+	switch r.kind {
+	case rowLoading:
+		b.WriteString(muted.Render("loading…"))
+		return styles.RowClip(bg, fg, m.width, styles.Reopen(b.String(), bg, fg))
+	case rowError:
+		b.WriteString(plain.Foreground(t.Error()).Render("error: " + r.node.err.Error()))
+		return styles.RowClip(bg, fg, m.width, styles.Reopen(b.String(), bg, fg))
+	}
+
+	n := r.node
+	switch {
+	case !n.IsBranch():
+		b.WriteString(muted.Render(leafMarker(r.guides)))
+	case m.isOpen(n):
+		b.WriteString(muted.Render("▾ "))
+	default:
+		b.WriteString(muted.Render("▸ "))
+	}
+	if n.Icon != "" {
+		b.WriteString(tone.Render(n.Icon) + plain.Render(" "))
+		b.WriteString(m.renderLabel(t, plain, n.Label, selected))
+	} else {
+		b.WriteString(m.renderLabel(t, tone, n.Label, selected))
+	}
+	left := b.String()
+
+	if n.Detail == "" {
+		return styles.RowClip(bg, fg, m.width, styles.Reopen(left, bg, fg))
+	}
+	detail := muted.Render(n.Detail)
+	room := m.width - lipgloss.Width(detail) - 1
+	if room < 1 {
+		return styles.RowClip(bg, fg, m.width, styles.Reopen(left, bg, fg))
+	}
+	left = ansi.Truncate(left, room, "…")
+	gap := plain.Render(strings.Repeat(" ", max(1, m.width-lipgloss.Width(left)-lipgloss.Width(detail))))
+	return styles.RowClip(bg, fg, m.width, styles.Reopen(left+gap+detail, bg, fg))
+}
+
+// renderLabel underlines the part of the label the filter matched.
+func (m *Model) renderLabel(t theme.Theme, base lipgloss.Style, label string, selected bool) string {
+	if m.filter == "" {
+		return base.Render(label)
+	}
+	hay, q := label, m.filter
+	if !strings.ContainsFunc(q, unicode.IsUpper) {
+		hay, q = strings.ToLower(label), strings.ToLower(q)
+	}
+	i := strings.Index(hay, q)
+	if i < 0 || len(hay) != len(label) {
+		return base.Render(label)
+	}
+	hit := base.Underline(true)
+	if !selected {
+		hit = hit.Foreground(t.TextAccent())
+	}
+	return base.Render(label[:i]) + hit.Render(label[i:i+len(q)]) + base.Render(label[i+len(q):])
+}
+
+// guides draws the indentation for a row: a bar for every ancestor level
+// that has siblings below it, and a tee or elbow into the row itself.
+// Top-level rows have none.
+func guides(last []bool) string {
+	if len(last) <= 1 {
+		return ""
+	}
+	var b strings.Builder
+	for _, l := range last[1 : len(last)-1] {
+		if l {
+			b.WriteString("  ")
+		} else {
+			b.WriteString("│ ")
+		}
+	}
+	if last[len(last)-1] {
+		b.WriteString("└─")
+	} else {
+		b.WriteString("├─")
+	}
+	return b.String()
+}
+
+// leafMarker continues the guide into a leaf, or leaves room for the
+// marker at the top level.
+func leafMarker(last []bool) string {
+	if len(last) <= 1 {
+		return "  "
+	}
+	return "─ "
+}
+
+func toneColor(t theme.Theme, tone Tone) color.Color {
+	switch tone {
+	case ToneInfo:
+		return t.Info()
+	case ToneSuccess:
+		return t.Success()
+	case ToneWarn:
+		return t.Warning()
+	case ToneDanger:
+		return t.Error()
+	}
+	return nil
+}
+
+// renderStatus shows the filter prompt, or the active filter and how many
+// nodes match it.
+func (m *Model) renderStatus(t theme.Theme) string {
+	bg, muted := t.BackgroundPanel(), t.TextMuted()
+	accent := lipgloss.NewStyle().Background(bg).Foreground(t.TextAccent())
+	dim := lipgloss.NewStyle().Background(bg).Foreground(muted)
+	line := accent.Render("/" + m.filter)
+	if m.filtering {
+		line = accent.Render("/" + m.filter + "▏")
+	}
+	count := 0
+	for _, r := range m.rows() {
+		if r.kind == rowNode && m.matches(r.node) {
+			count++
+		}
+	}
+	switch count {
+	case 0:
+		line += dim.Render("  no matches")
+	case 1:
+		line += dim.Render("  1 match")
+	default:
+		line += dim.Render(fmt.Sprintf("  %d matches", count))
+	}
+	return styles.RowClip(bg, muted, m.width, styles.Reopen(line, bg, muted))
+}
@@ -0,0 +1,210 @@
+package tree
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func sample() []*Node {
+	return []*Node{
+		{ID: "cluster", Label: "cluster", Expanded: true, Children: []*Node{
+			{ID: "default", Label: "default", Expanded: true, Children: []*Node{
+				{ID: "api", Label: "api-7f9c", Detail: "Running", Tone: ToneSuccess, Icon: "●"},
+				{ID: "worker", Label: "worker-2d1a", Detail: "Pending", Tone: ToneWarn, Icon: "●"},
+			}},
+			{ID: "kube-system", Label: "kube-system", Children: []*Node{
+				{ID: "dns", Label: "coredns"},
+			}},
+		}},
+	}
+}
+
+func TestConformance(t *testing.T) {
+	t.Run("tree", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New: func() testkit.Brick { return New(Roots(sample()...)) },
+		})
+	})
+	t.Run("filtered", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New: func() testkit.Brick {
+				m := New(Roots(sample()...))
+				m.SetFilter("dns")
+				return m
+			},
+		})
+	})
+}
+
+func TestGuides(t *testing.T) {
+	m := New(Roots(sample()...))
+	m.SetSize(30, 6)
+	want := []string{
+		"▾ cluster",
+		"├─▾ default",
+		"│ ├── ● api-7f9c",
+		"│ └── ● worker-2d1a",
+		"└─▸ kube-system",
+		"",
+	}
+	got := testkit.FrameOf(m.View()).Lines()
+	for i, w := range want {
+		line := strings.TrimRight(got[i], " ")
+		if i == 2 || i == 3 {
+			line = strings.TrimRight(strings.TrimSuffix(strings.TrimSuffix(line, "Running"), "Pending"), " ")
+		}
+		if line != w {
+			t.Fatalf("row %d = %q, want %q\n%s", i, got[i], w, strings.Join(got, "\n"))
+		}
+	}
+	if !strings.HasSuffix(got[2], "Running") {
+		t.Fatalf("detail should be right-aligned: %q", got[2])
+	}
+}
+
+func TestKeyboardNavigation(t *testing.T) {
+	m := New(Roots(sample()...))
+	m.Focus()
+	d := testkit.Boot(t, m, 30, 8)
+
+	d.Press("G")
+	if m.Selected().ID != "kube-system" {
+		t.Fatalf("selected %q at the bottom", m.Selected().ID)
+	}
+	d.Press("l").RequireContains("coredns")
+	d.Press("l")
+	if m.Selected().ID != "dns" {
+		t.Fatalf("right on an open branch should step in, got %q", m.Selected().ID)
+	}
+	d.Press("h")
+	if m.Selected().ID != "kube-system" {
+		t.Fatalf("left on a leaf should step out, got %q", m.Selected().ID)
+	}
+	d.Press("h").RequireNotContains("coredns")
+	d.Press("k", "k", "k", "space").RequireNotContains("worker")
+	d.Press("enter").RequireContains("worker")
+
+	if !m.Select("dns") || m.Selected().ID != "dns" {
+		t.Fatal("Select should open the ancestors and move to the node")
+	}
+}
+
+func TestEnterOnLeafEmits(t *testing.T) {
+	type opened struct{ id string }
+	m := New(Roots(sample()...), OnSelect(func(n *Node) tea.Msg { return opened{n.ID} }))
+	m.Focus()
+	m.Select("worker")
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter on a leaf should emit")
+	}
+	if msg, ok := cmd().(opened); !ok || msg.id != "worker" {
+		t.Fatalf("emitted %#v", cmd())
+	}
+}
+
+func TestLazyChildren(t *testing.T) {
+	calls := 0
+	fail := true
+	provider := func(n *Node) tea.Cmd {
+		calls++
+		return func() tea.Msg {
+			if fail {
+				return ChildrenMsg{Parent: n, Err: errors.New("forbidden")}
+			}
+			return ChildrenMsg{Parent: n, Children: []*Node{{Label: "pod-a"}, {Label: "pod-b"}}}
+		}
+	}
+	ns := &Node{Label: "namespaces", Lazy: true}
+	m := New(Roots(ns), WithProvider(provider))
+	m.Focus()
+	m.SetSize(30, 4)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	if cmd == nil || calls != 1 {
+		t.Fatal("expanding a lazy node should call the provider")
+	}
+	if out := testkit.FrameOf(m.View()).Plain(); !strings.Contains(out, "loading…") {
+		t.Fatalf("a loading row should show:\n%s", out)
+	}
+	m.Update(cmd())
+	if out := testkit.FrameOf(m.View()).Plain(); !strings.Contains(out, "error: forbidden") {
+		t.Fatalf("the error should show under the node:\n%s", out)
+	}
+
+	fail = false
+	m.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+	_, cmd = m.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+	if cmd == nil || calls != 2 {
+		t.Fatal("expanding again after an error should retry")
+	}
+	m.Update(cmd())
+	if out := testkit.FrameOf(m.View()).Plain(); !strings.Contains(out, "pod-b") {
+		t.Fatalf("loaded children should show:\n%s", out)
+	}
+	m.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+	if _, cmd = m.Update(tea.KeyPressMsg{Code: ' ', Text: " "}); cmd != nil || calls != 2 {
+		t.Fatal("loaded children should not be fetched again")
+	}
+}
+
+func TestFilterKeepsAncestors(t *testing.T) {
+	m := New(Roots(sample()...))
+	m.Focus()
+	d := testkit.Boot(t, m, 30, 6)
+	d.Press("/").Type("dns").RequireContains("/dns▏").RequireContains("1 match")
+	d.RequireContains("cluster").RequireContains("kube-system").RequireContains("coredns").RequireNotContains("api-7f9c")
+	if m.Selected().ID != "dns" {
+		t.Fatalf("the cursor should move to the match, got %q", m.Selected().ID)
+	}
+	d.Press("enter").RequireContains("/dns  1 match")
+	d.Press("/", "esc").RequireContains("/dns")
+	d.Press("/", "backspace", "backspace", "backspace", "enter").RequireContains("api-7f9c")
+	if m.Filter() != "" {
+		t.Fatalf("filter = %q", m.Filter())
+	}
+	if m.Find("kube-system").Expanded {
+		t.Fatal("filtering should not change the expanded state")
+	}
+}
+
+func TestMouse(t *testing.T) {
+	m := New(Roots(sample()...))
+	m.SetSize(30, 6)
+	m.Update(tea.MouseClickMsg{X: 10, Y: 3, Button: tea.MouseLeft})
+	if m.Selected().ID != "worker" {
+		t.Fatalf("click selected %q", m.Selected().ID)
+	}
+	m.Update(tea.MouseClickMsg{X: 2, Y: 4, Button: tea.MouseLeft})
+	if !m.Find("kube-system").Expanded {
+		t.Fatal("a click on the marker should toggle the branch")
+	}
+	m.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if m.Selected().ID != "dns" {
+		t.Fatalf("wheel moved to %q", m.Selected().ID)
+	}
+}
+
+func TestSetKeymapUsesRegistryKeys(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(Scope, keymap.Binding{Action: ActionToggle, Keys: []string{"o"}})
+	m := New(Roots(sample()...))
+	m.SetKeymap(r)
+	m.Focus()
+	m.Update(tea.KeyPressMsg{Code: ' ', Text: " "})
+	if !m.Find("cluster").Expanded {
+		t.Fatal("space should no longer toggle")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'o', Text: "o"})
+	if m.Find("cluster").Expanded {
+		t.Fatal("o should toggle")
+	}
+	if _, ok := r.Lookup(ActionFilter); !ok {
+		t.Fatal("SetKeymap should register the remaining defaults")
+	}
+}
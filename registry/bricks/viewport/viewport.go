@@ -0,0 +1,788 @@
+// Brick: Viewport
+// +-----------------------------------+
+// | 2024-05-01 12:00:01 build started |
+// | 2024-05-01 12:00:04 compiled ok   |
+// | 2024-05-01 12:00:09 tests passed  |
+// | /passed  1/1        follow 3 of 3 |
+// +-----------------------------------+
+// Pager for long plain or ANSI text: incremental search, wrap toggle,
+// jump to line and a follow-tail mode for streaming output.
+// Copy this file into your project: bento add viewport
+package viewport
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	bubbleskey "charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+type KeyMap struct {
+	Up           bubbleskey.Binding
+	Down         bubbleskey.Binding
+	PageUp       bubbleskey.Binding
+	PageDown     bubbleskey.Binding
+	HalfPageUp   bubbleskey.Binding
+	HalfPageDown bubbleskey.Binding
+	Top          bubbleskey.Binding
+	Bottom       bubbleskey.Binding
+	Left         bubbleskey.Binding
+	Right        bubbleskey.Binding
+	Search       bubbleskey.Binding
+	NextMatch    bubbleskey.Binding
+	PrevMatch    bubbleskey.Binding
+	GotoLine     bubbleskey.Binding
+	ToggleWrap   bubbleskey.Binding
+	Follow       bubbleskey.Binding
+}
+
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:           bubbleskey.NewBinding(bubbleskey.WithKeys("up", "k"), bubbleskey.WithHelp("↑/k", "scroll up")),
+		Down:         bubbleskey.NewBinding(bubbleskey.WithKeys("down", "j"), bubbleskey.WithHelp("↓/j", "scroll down")),
+		PageUp:       bubbleskey.NewBinding(bubbleskey.WithKeys("pgup", "b"), bubbleskey.WithHelp("pgup", "page up")),
+		PageDown:     bubbleskey.NewBinding(bubbleskey.WithKeys("pgdown", "space", "f"), bubbleskey.WithHelp("pgdown", "page down")),
+		HalfPageUp:   bubbleskey.NewBinding(bubbleskey.WithKeys("ctrl+u", "u"), bubbleskey.WithHelp("ctrl+u", "half page up")),
+		HalfPageDown: bubbleskey.NewBinding(bubbleskey.WithKeys("ctrl+d", "d"), bubbleskey.WithHelp("ctrl+d", "half page down")),
+		Top:          bubbleskey.NewBinding(bubbleskey.WithKeys("home", "g"), bubbleskey.WithHelp("g", "top")),
+		Bottom:       bubbleskey.NewBinding(bubbleskey.WithKeys("end", "G"), bubbleskey.WithHelp("G", "bottom")),
+		Left:         bubbleskey.NewBinding(bubbleskey.WithKeys("left", "h"), bubbleskey.WithHelp("←/h", "scroll left")),
+		Right:        bubbleskey.NewBinding(bubbleskey.WithKeys("right", "l"), bubbleskey.WithHelp("→/l", "scroll right")),
+		Search:       bubbleskey.NewBinding(bubbleskey.WithKeys("/"), bubbleskey.WithHelp("/", "search")),
+		NextMatch:    bubbleskey.NewBinding(bubbleskey.WithKeys("n"), bubbleskey.WithHelp("n", "next match")),
+		PrevMatch:    bubbleskey.NewBinding(bubbleskey.WithKeys("N"), bubbleskey.WithHelp("N", "previous match")),
+		GotoLine:     bubbleskey.NewBinding(bubbleskey.WithKeys(":"), bubbleskey.WithHelp(":", "go to line")),
+		ToggleWrap:   bubbleskey.NewBinding(bubbleskey.WithKeys("w"), bubbleskey.WithHelp("w", "toggle wrap")),
+		Follow:       bubbleskey.NewBinding(bubbleskey.WithKeys("F"), bubbleskey.WithHelp("F", "follow")),
+	}
+}
+
+// Scope and action IDs the viewport bindings register under in a
+// keymap.Registry.
+const (
+	Scope              keymap.Scope = "viewport"
+	ActionUp                        = "viewport.up"
+	ActionDown                      = "viewport.down"
+	ActionPageUp                    = "viewport.pageup"
+	ActionPageDown                  = "viewport.pagedown"
+	ActionHalfPageUp                = "viewport.halfpageup"
+	ActionHalfPageDown              = "viewport.halfpagedown"
+	ActionTop                       = "viewport.top"
+	ActionBottom                    = "viewport.bottom"
+	ActionLeft                      = "viewport.left"
+	ActionRight                     = "viewport.right"
+	ActionSearch                    = "viewport.search"
+	ActionNextMatch                 = "viewport.nextmatch"
+	ActionPrevMatch                 = "viewport.prevmatch"
+	ActionGotoLine                  = "viewport.gotoline"
+	ActionToggleWrap                = "viewport.wrap"
+	ActionFollow                    = "viewport.follow"
+)
+
+// Bindings returns km as keymap bindings.
+func (km KeyMap) Bindings() []keymap.Binding {
+	return []keymap.Binding{
+		keymap.FromKey(ActionUp, km.Up),
+		keymap.FromKey(ActionDown, km.Down),
+		keymap.FromKey(ActionPageUp, km.PageUp),
+		keymap.FromKey(ActionPageDown, km.PageDown),
+		keymap.FromKey(ActionHalfPageUp, km.HalfPageUp),
+		keymap.FromKey(ActionHalfPageDown, km.HalfPageDown),
+		keymap.FromKey(ActionTop, km.Top),
+		keymap.FromKey(ActionBottom, km.Bottom),
+		keymap.FromKey(ActionLeft, km.Left),
+		keymap.FromKey(ActionRight, km.Right),
+		keymap.FromKey(ActionSearch, km.Search),
+		keymap.FromKey(ActionNextMatch, km.NextMatch),
+		keymap.FromKey(ActionPrevMatch, km.PrevMatch),
+		keymap.FromKey(ActionGotoLine, km.GotoLine),
+		keymap.FromKey(ActionToggleWrap, km.ToggleWrap),
+		keymap.FromKey(ActionFollow, km.Follow),
+	}
+}
+
+const (
+	// wheelRows is how far one mouse wheel notch scrolls.
+	wheelRows = 3
+	// panCols is how far left/right pan unwrapped lines.
+	panCols = 4
+	// tabWidth is how many spaces a tab expands to.
+	tabWidth = 4
+)
+
+type Option func(*Model)
+
+// Content sets the initial text.
+func Content(s string) Option { return func(m *Model) { m.SetContent(s) } }
+
+// Wrap soft wraps long lines at the pane width instead of panning.
+func Wrap() Option { return func(m *Model) { m.wrap = true } }
+
+// Follow keeps the view pinned to the last line as text is appended.
+func Follow() Option { return func(m *Model) { m.follow = true } }
+
+// MaxLines keeps only the last n lines, dropping the oldest as text is
+// appended. 0 = no limit.
+func MaxLines(n int) Option { return func(m *Model) { m.max = max(0, n) } }
+
+// HideStatus drops the status row. The search and go-to-line prompts still
+// take the bottom row while they are open.
+func HideStatus() Option { return func(m *Model) { m.hideStatus = true } }
+
+// WithTheme sets the theme for this viewport instance.
+func WithTheme(t theme.Theme) Option { return func(m *Model) { m.theme = t } }
+
+// match is one search hit, in cells of the line's visible text. line counts
+// from the first line ever appended, so matches survive MaxLines dropping
+// the oldest lines.
+type match struct{ line, col, width int }
+
+type promptKind int
+
+const (
+	promptNone promptKind = iota
+	promptSearch
+	promptLine
+)
+
+// Model pages through lines of text. Offsets count screen rows, so a
+// wrapped line scrolls one row at a time.
+type Model struct {
+	lines      []string
+	widths     []int // cell width of each line
+	open       bool  // the last line has no newline yet; Append continues it
+	base       int   // absolute number of lines[0], bumped as MaxLines drops lines
+	rows       int   // screen rows of all lines, kept in step by setLine
+	max        int
+	offset     int // first visible row
+	pan        int // first visible column when not wrapping
+	wrap       bool
+	follow     bool
+	hideStatus bool
+
+	query   string
+	matches []match
+	current int // index into matches, -1 = none
+
+	prompt promptKind
+	input  string
+	saved  int // offset to go back to when a prompt is cancelled
+
+	width   int
+	height  int
+	focused bool
+	keys    KeyMap
+	keymap  *keymap.Registry // nil = use keys
+	theme   theme.Theme      // nil = use theme.CurrentTheme()
+}
+
+func New(opts ...Option) *Model {
+	m := &Model{keys: DefaultKeyMap(), current: -1}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SetContent replaces the text. A search stays active and is rerun.
+func (m *Model) SetContent(s string) {
+	m.lines, m.widths, m.open = nil, nil, false
+	m.base, m.rows = 0, 0
+	m.Append(s)
+	if !m.follow {
+		m.scrollTo(0)
+	}
+}
+
+// Append adds streamed text. It continues the last line until a newline,
+// so output can be appended in whatever chunks it arrives in. In follow
+// mode the view stays on the last line.
+func (m *Model) Append(s string) {
+	if s == "" {
+		return
+	}
+	parts := strings.Split(s, "\n")
+	from := len(m.lines) // first line to search again
+	if m.open && len(m.lines) > 0 {
+		from--
+		m.setLine(from, m.lines[from]+parts[0])
+		parts = parts[1:]
+	}
+	m.open = parts[len(parts)-1] != ""
+	if !m.open {
+		parts = parts[:len(parts)-1]
+	}
+	for _, p := range parts {
+		m.lines = append(m.lines, "")
+		m.widths = append(m.widths, 0)
+		m.rows++
+		m.setLine(len(m.lines)-1, p)
+	}
+	if m.max > 0 && len(m.lines) > m.max {
+		drop := len(m.lines) - m.max
+		for i := range drop {
+			m.rows -= m.rowsOf(i)
+		}
+		m.lines = m.lines[drop:]
+		m.widths = m.widths[drop:]
+		m.base += drop
+		from = max(0, from-drop)
+	}
+	m.rematchFrom(m.base + from)
+	if m.follow {
+		m.scrollTo(m.maxOffset())
+	} else {
+		m.scrollTo(m.offset)
+	}
+}
+
+func (m *Model) setLine(i int, s string) {
+	s = strings.ReplaceAll(strings.TrimSuffix(s, "\r"), "\t", strings.Repeat(" ", tabWidth))
+	m.rows -= m.rowsOf(i)
+	m.lines[i] = s
+	m.widths[i] = ansi.StringWidth(s)
+	m.rows += m.rowsOf(i)
+}
+
+// LineCount is the number of lines held.
+func (m *Model) LineCount() int { return len(m.lines) }
+
+// Offset is the first visible screen row.
+func (m *Model) Offset() int { return m.offset }
+
+// TopLine is the 0-based line shown on the first row. At zero height the
+// offset can sit past the last row; that still reports the last line.
+func (m *Model) TopLine() int {
+	line, _ := m.lineAt(m.offset)
+	return max(0, min(line, len(m.lines)-1))
+}
+
+// AtBottom reports whether the last line is in view.
+func (m *Model) AtBottom() bool { return m.offset >= m.maxOffset() }
+
+func (m *Model) SetWrap(v bool) {
+	if v == m.wrap {
+		return
+	}
+	top := m.TopLine()
+	m.wrap, m.pan = v, 0
+	m.recount()
+	m.scrollTo(m.rowOf(top, 0))
+	m.keepFollowing()
+}
+
+func (m *Model) IsWrapped() bool { return m.wrap }
+
+// SetFollow pins the view to the last line (true) or lets it scroll freely.
+// Scrolling up turns following off.
+func (m *Model) SetFollow(v bool) {
+	m.follow = v
+	m.keepFollowing()
+}
+
+func (m *Model) IsFollowing() bool { return m.follow }
+
+// GotoLine scrolls line n (1-based) to the top of the view.
+func (m *Model) GotoLine(n int) {
+	if len(m.lines) == 0 {
+		return
+	}
+	n = max(1, min(n, len(m.lines)))
+	m.follow = false
+	m.pan = 0
+	m.scrollTo(m.rowOf(n-1, 0))
+}
+
+// Search highlights every match of query and jumps to the first one at or
+// below the top of the view. Matching ignores case unless query has an
+// upper-case letter. An empty query clears the search.
+func (m *Model) Search(query string) {
+	from := m.base + m.TopLine()
+	m.query = query
+	m.rematch()
+	if len(m.matches) == 0 {
+		return
+	}
+	i := sort.Search(len(m.matches), func(i int) bool { return m.matches[i].line >= from })
+	m.current = i % len(m.matches)
+	m.jump()
+}
+
+// Query is the active search, or "".
+func (m *Model) Query() string { return m.query }
+
+// Matches returns the number of search matches and the 0-based index of
+// the current one, -1 when there is none.
+func (m *Model) Matches() (count, current int) { return len(m.matches), m.current }
+
+// NextMatch moves to the next match, wrapping around.
+func (m *Model) NextMatch() { m.stepMatch(1) }
+
+// PrevMatch moves to the previous match, wrapping around.
+func (m *Model) PrevMatch() { m.stepMatch(-1) }
+
+func (m *Model) stepMatch(dir int) {
+	n := len(m.matches)
+	if n == 0 {
+		return
+	}
+	m.current = ((m.current+dir)%n + n) % n
+	m.jump()
+}
+
+// rematch reruns the search over every line, keeping the current match
+// index where it can.
+func (m *Model) rematch() { m.rematchFrom(m.base) }
+
+// rematchFrom reruns the search from absolute line start on. Matches before
+// start are kept, except those on lines MaxLines has dropped.
+func (m *Model) rematchFrom(start int) {
+	if m.query == "" {
+		m.matches, m.current = m.matches[:0], -1
+		return
+	}
+	gone := sort.Search(len(m.matches), func(i int) bool { return m.matches[i].line >= m.base })
+	keep := sort.Search(len(m.matches), func(i int) bool { return m.matches[i].line >= start })
+	m.matches = append(m.matches[:0], m.matches[gone:keep]...)
+	m.current -= gone
+	q, fold := m.query, !strings.ContainsFunc(m.query, unicode.IsUpper)
+	if fold {
+		q = strings.ToLower(q)
+	}
+	for i := start - m.base; i < len(m.lines); i++ {
+		plain := ansi.Strip(m.lines[i])
+		hay := plain
+		if fold {
+			hay = strings.ToLower(plain)
+			if len(hay) != len(plain) {
+				hay = plain // lower-casing moved byte offsets; match exactly
+			}
+		}
+		for at := 0; ; {
+			j := strings.Index(hay[at:], q)
+			if j < 0 {
+				break
+			}
+			start := at + j
+			at = start + len(q)
+			m.matches = append(m.matches, match{
+				line:  m.base + i,
+				col:   ansi.StringWidth(plain[:start]),
+				width: ansi.StringWidth(plain[start:at]),
+			})
+		}
+	}
+	switch {
+	case len(m.matches) == 0:
+		m.current = -1
+	case m.current < 0 || m.current >= len(m.matches):
+		m.current = 0
+	}
+}
+
+// jump scrolls the current match into view.
+func (m *Model) jump() {
+	if m.current < 0 {
+		return
+	}
+	mt := m.matches[m.current]
+	m.follow = false
+	if !m.wrap && (mt.col < m.pan || mt.col+mt.width > m.pan+m.width) {
+		m.pan = m.clampPan(mt.col - m.width/3)
+	}
+	row := m.rowOf(mt.line-m.base, mt.col)
+	if row < m.offset || row >= m.offset+m.viewRows() {
+		m.scrollTo(row - m.viewRows()/3)
+	}
+}
+
+// ── geometry ──────────────────────────────────────────────────────────────────
+
+// rowsOf is how many screen rows line i takes.
+func (m *Model) rowsOf(i int) int {
+	if !m.wrap || m.width <= 0 || i >= len(m.widths) {
+		return 1
+	}
+	return max(1, (m.widths[i]+m.width-1)/m.width)
+}
+
+func (m *Model) totalRows() int { return m.rows }
+
+// recount redoes the row total after the wrap mode or width changes.
+func (m *Model) recount() {
+	m.rows = 0
+	for i := range m.lines {
+		m.rows += m.rowsOf(i)
+	}
+}
+
+// rowOf returns the screen row that shows column col of line.
+func (m *Model) rowOf(line, col int) int {
+	if !m.wrap {
+		return line
+	}
+	row := 0
+	for i := 0; i < line && i < len(m.lines); i++ {
+		row += m.rowsOf(i)
+	}
+	if m.width > 0 {
+		row += min(col/m.width, m.rowsOf(line)-1)
+	}
+	return row
+}
+
+// lineAt returns the line shown on screen row row and which of its wrapped
+// rows that is.
+func (m *Model) lineAt(row int) (line, sub int) {
+	if !m.wrap {
+		return row, 0
+	}
+	for i := range m.lines {
+		n := m.rowsOf(i)
+		if row < n {
+			return i, row
+		}
+		row -= n
+	}
+	return len(m.lines), 0
+}
+
+// statusRows is 1 when the bottom row is taken by the status or a prompt.
+func (m *Model) statusRows() int {
+	if m.height < 2 && m.prompt == promptNone {
+		return 0
+	}
+	if m.hideStatus && m.prompt == promptNone {
+		return 0
+	}
+	return 1
+}
+
+func (m *Model) viewRows() int { return max(0, m.height-m.statusRows()) }
+
+func (m *Model) maxOffset() int { return max(0, m.totalRows()-m.viewRows()) }
+
+func (m *Model) scrollTo(offset int) { m.offset = max(0, min(offset, m.maxOffset())) }
+
+// scrollBy scrolls by n rows. Scrolling up stops following the tail.
+func (m *Model) scrollBy(n int) {
+	if n < 0 {
+		m.follow = false
+	}
+	m.scrollTo(m.offset + n)
+}
+
+func (m *Model) keepFollowing() {
+	if m.follow {
+		m.scrollTo(m.maxOffset())
+	}
+}
+
+func (m *Model) clampPan(x int) int {
+	widest := 0
+	for _, w := range m.widths {
+		widest = max(widest, w)
+	}
+	return max(0, min(x, widest-m.width))
+}
+
+// ── lifecycle ─────────────────────────────────────────────────────────────────
+
+func (m *Model) Focus()          { m.focused = true }
+func (m *Model) IsFocused() bool { return m.focused }
+func (m *Model) Init() tea.Cmd   { return nil }
+
+// Blur also closes an open prompt, keeping what was typed.
+func (m *Model) Blur() {
+	m.focused = false
+	m.prompt = promptNone
+}
+
+func (m *Model) SetSize(width, height int) {
+	top := m.TopLine()
+	resized := max(0, width) != m.width
+	m.width, m.height = max(0, width), max(0, height)
+	if resized {
+		m.recount()
+	}
+	m.scrollTo(m.rowOf(top, 0))
+	m.pan = m.clampPan(m.pan)
+	m.keepFollowing()
+}
+
+func (m *Model) GetSize() (int, int) { return m.width, m.height }
+
+// SetTheme updates the theme. Call on ThemeChangedMsg.
+func (m *Model) SetTheme(t theme.Theme) { m.theme = t }
+
+func (m *Model) activeTheme() theme.Theme {
+	if m.theme != nil {
+		return m.theme
+	}
+	return theme.CurrentTheme()
+}
+
+// SetKeymap reads the viewport keys from r.
+func (m *Model) SetKeymap(r *keymap.Registry) {
+	if r != nil {
+		r.Register(Scope, m.keys.Bindings()...)
+	}
+	m.keymap = r
+}
+
+func (m *Model) keyMap() KeyMap {
+	r, k := m.keymap, m.keys
+	return KeyMap{
+		Up:           r.KeyOr(ActionUp, k.Up),
+		Down:         r.KeyOr(ActionDown, k.Down),
+		PageUp:       r.KeyOr(ActionPageUp, k.PageUp),
+		PageDown:     r.KeyOr(ActionPageDown, k.PageDown),
+		HalfPageUp:   r.KeyOr(ActionHalfPageUp, k.HalfPageUp),
+		HalfPageDown: r.KeyOr(ActionHalfPageDown, k.HalfPageDown),
+		Top:          r.KeyOr(ActionTop, k.Top),
+		Bottom:       r.KeyOr(ActionBottom, k.Bottom),
+		Left:         r.KeyOr(ActionLeft, k.Left),
+		Right:        r.KeyOr(ActionRight, k.Right),
+		Search:       r.KeyOr(ActionSearch, k.Search),
+		NextMatch:    r.KeyOr(ActionNextMatch, k.NextMatch),
+		PrevMatch:    r.KeyOr(ActionPrevMatch, k.PrevMatch),
+		GotoLine:     r.KeyOr(ActionGotoLine, k.GotoLine),
+		ToggleWrap:   r.KeyOr(ActionToggleWrap, k.ToggleWrap),
+		Follow:       r.KeyOr(ActionFollow, k.Follow),
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+	case tea.MouseWheelMsg:
+		switch msg.Button {
+		case tea.MouseWheelUp:
+			m.scrollBy(-wheelRows)
+		case tea.MouseWheelDown:
+			m.scrollBy(wheelRows)
+		}
+		return m, nil
+	case tea.KeyPressMsg:
+		if !m.focused {
+			return m, nil
+		}
+		if m.prompt != promptNone {
+			m.updatePrompt(msg)
+			return m, nil
+		}
+		m.updateKeys(msg)
+	}
+	return m, nil
+}
+
+func (m *Model) updateKeys(k tea.KeyPressMsg) {
+	keys := m.keyMap()
+	page := max(1, m.viewRows())
+	switch {
+	case bubbleskey.Matches(k, keys.Up):
+		m.scrollBy(-1)
+	case bubbleskey.Matches(k, keys.Down):
+		m.scrollBy(1)
+	case bubbleskey.Matches(k, keys.PageUp):
+		m.scrollBy(-page)
+	case bubbleskey.Matches(k, keys.PageDown):
+		m.scrollBy(page)
+	case bubbleskey.Matches(k, keys.HalfPageUp):
+		m.scrollBy(-max(1, page/2))
+	case bubbleskey.Matches(k, keys.HalfPageDown):
+		m.scrollBy(max(1, page/2))
+	case bubbleskey.Matches(k, keys.Top):
+		m.scrollBy(-m.offset)
+	case bubbleskey.Matches(k, keys.Bottom):
+		m.scrollTo(m.maxOffset())
+	case bubbleskey.Matches(k, keys.Left):
+		if !m.wrap {
+			m.pan = m.clampPan(m.pan - panCols)
+		}
+	case bubbleskey.Matches(k, keys.Right):
+		if !m.wrap {
+			m.pan = m.clampPan(m.pan + panCols)
+		}
+	case bubbleskey.Matches(k, keys.Search):
+		m.openPrompt(promptSearch)
+	case bubbleskey.Matches(k, keys.GotoLine):
+		m.openPrompt(promptLine)
+	case bubbleskey.Matches(k, keys.NextMatch):
+		m.NextMatch()
+	case bubbleskey.Matches(k, keys.PrevMatch):
+		m.PrevMatch()
+	case bubbleskey.Matches(k, keys.ToggleWrap):
+		m.SetWrap(!m.wrap)
+	case bubbleskey.Matches(k, keys.Follow):
+		m.SetFollow(!m.follow)
+	}
+}
+
+func (m *Model) openPrompt(p promptKind) {
+	m.prompt, m.input, m.saved = p, "", m.offset
+	m.scrollTo(m.offset) // the prompt may have taken the last content row
+}
+
+// updatePrompt edits the search or line prompt. Searching is incremental:
+// every keystroke reruns the search from where the prompt was opened.
+func (m *Model) updatePrompt(k tea.KeyPressMsg) {
+	switch k.String() {
+	case "esc":
+		if m.prompt == promptSearch {
+			m.query = ""
+			m.rematch()
+		}
+		m.prompt = promptNone
+		m.scrollTo(m.saved)
+		return
+	case "enter":
+		if m.prompt == promptLine {
+			if n, err := strconv.Atoi(m.input); err == nil {
+				m.GotoLine(n)
+			}
+		}
+		m.prompt = promptNone
+		m.scrollTo(m.offset)
+		return
+	case "backspace":
+		if m.input == "" {
+			m.prompt = promptNone
+			return
+		}
+		r := []rune(m.input)
+		m.input = string(r[:len(r)-1])
+	default:
+		if k.Text == "" || (m.prompt == promptLine && strings.ContainsFunc(k.Text, func(r rune) bool { return !unicode.IsDigit(r) })) {
+			return
+		}
+		m.input += k.Text
+	}
+	if m.prompt == promptSearch {
+		m.scrollTo(m.saved)
+		m.Search(m.input)
+		if len(m.matches) == 0 {
+			m.scrollTo(m.saved)
+		}
+	}
+}
+
+// ── rendering ─────────────────────────────────────────────────────────────────
+
+func (m *Model) View() tea.View {
+	if m.width <= 0 || m.height <= 0 {
+		return tea.NewView("")
+	}
+	t := m.activeTheme()
+	bg, fg := t.BackgroundPanel(), t.Text()
+
+	rows := make([]string, 0, m.height)
+	line, sub := m.lineAt(m.offset)
+	for len(rows) < m.viewRows() {
+		if line >= len(m.lines) {
+			rows = append(rows, styles.Row(bg, fg, m.width, ""))
+			continue
+		}
+		start := m.pan
+		if m.wrap {
+			start = sub * m.width
+		}
+		content := ansi.Cut(m.highlight(t, line), start, start+m.width)
+		rows = append(rows, styles.RowClip(bg, fg, m.width, styles.Reopen(content, bg, fg)))
+		if sub++; !m.wrap || sub >= m.rowsOf(line) {
+			line, sub = line+1, 0
+		}
+	}
+	if m.statusRows() > 0 {
+		rows = append(rows, m.renderStatus(t))
+	}
+	return tea.NewView(strings.Join(rows, "\n"))
+}
+
+// highlight paints the search matches on line i. The current match takes
+// the selection colors, the others the interactive tint list rows use.
+func (m *Model) highlight(t theme.Theme, i int) string {
+	s, abs := m.lines[i], m.base+i
+	first := sort.Search(len(m.matches), func(j int) bool { return m.matches[j].line >= abs })
+	if first == len(m.matches) || m.matches[first].line != abs {
+		return s
+	}
+	hit := lipgloss.NewStyle().Background(t.BackgroundInteractive()).Foreground(t.TextAccent())
+	cur := lipgloss.NewStyle().Background(t.SelectionBG()).Foreground(t.SelectionFG())
+
+	var b strings.Builder
+	at := 0
+	for j := first; j < len(m.matches) && m.matches[j].line == abs; j++ {
+		mt := m.matches[j]
+		b.WriteString(ansi.Cut(s, at, mt.col))
+		style := hit
+		if j == m.current {
+			style = cur
+		}
+		b.WriteString(style.Render(ansi.Strip(ansi.Cut(s, mt.col, mt.col+mt.width))))
+		at = mt.col + mt.width
+	}
+	// Cut keeps the escapes before at, so the line's own styling resumes.
+	b.WriteString(ansi.Cut(s, at, m.widths[i]))
+	return b.String()
+}
+
+// renderStatus draws the prompt while one is open, otherwise the search,
+// the wrap and follow flags, and which lines are in view.
+func (m *Model) renderStatus(t theme.Theme) string {
+	bg, muted := t.BackgroundPanel(), t.TextMuted()
+	accent := lipgloss.NewStyle().Background(bg).Foreground(t.TextAccent())
+	dim := lipgloss.NewStyle().Background(bg).Foreground(muted)
+
+	switch m.prompt {
+	case promptSearch:
+		return styles.RowClip(bg, muted, m.width, styles.Reopen(accent.Render("/"+m.input+"▏"), bg, muted))
+	case promptLine:
+		return styles.RowClip(bg, muted, m.width, styles.Reopen(accent.Render(":"+m.input+"▏"), bg, muted))
+	}
+
+	left := ""
+	if m.query != "" {
+		left = accent.Render("/" + m.query)
+		if len(m.matches) == 0 {
+			left += dim.Render("  no matches")
+		} else {
+			left += dim.Render(fmt.Sprintf("  %d/%d", m.current+1, len(m.matches)))
+		}
+	}
+	var right []string
+	if m.wrap {
+		right = append(right, accent.Render("wrap"))
+	}
+	if m.follow {
+		right = append(right, accent.Render("follow"))
+	}
+	if n := len(m.lines); n > 0 {
+		first := m.TopLine() + 1
+		last, _ := m.lineAt(m.offset + m.viewRows() - 1)
+		last = max(first, min(last+1, n))
+		if first == last {
+			right = append(right, dim.Render(fmt.Sprintf("%d of %d", first, n)))
+		} else {
+			right = append(right, dim.Render(fmt.Sprintf("%d–%d of %d", first, last, n)))
+		}
+	}
+	r := strings.Join(right, dim.Render("  "))
+	pad := max(1, m.width-lipgloss.Width(left)-lipgloss.Width(r))
+	line := left + dim.Render(strings.Repeat(" ", pad)) + r
+	if lipgloss.Width(left)+lipgloss.Width(r)+1 > m.width {
+		line = r // keep the position when both do not fit
+	}
+	return styles.RowClip(bg, muted, m.width, styles.Reopen(line, bg, muted))
+}
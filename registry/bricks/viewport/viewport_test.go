@@ -0,0 +1,249 @@
+package viewport
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/cloudboy-jh/bentotui/keymap"
+	"github.com/cloudboy-jh/bentotui/testkit"
+)
+
+func numbered(n int) string {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func TestConformance(t *testing.T) {
+	sample := "\x1b[1mbuild\x1b[0m started\n\tcompiling a fairly long package path that overflows narrow panes\n\x1b[32mok\x1b[0m tests passed\n"
+	t.Run("plain", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New: func() testkit.Brick { return New(Content(sample)) },
+		})
+	})
+	t.Run("wrapped search", func(t *testing.T) {
+		testkit.RunConformance(t, testkit.Conformance{
+			New: func() testkit.Brick {
+				m := New(Content(sample), Wrap())
+				m.Search("pass")
+				return m
+			},
+		})
+	})
+}
+
+func TestScrollAndStatus(t *testing.T) {
+	m := New(Content(numbered(20)))
+	m.Focus()
+	d := testkit.Boot(t, m, 20, 5)
+	d.RequireContains("line 1").RequireContains("1–4 of 20")
+	d.Press("j", "j").RequireContains("3–6 of 20")
+	d.Press("G").RequireContains("line 20").RequireContains("17–20 of 20")
+	d.Press("g").RequireContains("1–4 of 20")
+
+	d.Send(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	if m.TopLine() != 3 {
+		t.Fatalf("top line = %d after a wheel notch, want 3", m.TopLine())
+	}
+	m.Blur()
+	d.Send(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	if m.TopLine() != 0 {
+		t.Fatalf("the wheel should scroll a blurred viewport, top = %d", m.TopLine())
+	}
+}
+
+func TestIncrementalSearch(t *testing.T) {
+	m := New(Content(numbered(30)))
+	m.Focus()
+	d := testkit.Boot(t, m, 20, 5)
+
+	d.Press("/").Type("2").RequireContains("/2▏")
+	if n, cur := m.Matches(); n != 13 || cur != 0 {
+		t.Fatalf("matches = %d, current = %d for %q", n, cur, "2")
+	}
+	d.Type("5").Press("enter")
+	if m.Query() != "25" || m.TopLine() > 24 || m.TopLine()+4 <= 24 {
+		t.Fatalf("line 25 should be in view, top = %d", m.TopLine())
+	}
+	d.RequireContains("/25  1/1")
+
+	d.Press("g", "/").Type("LINE 1").Press("enter")
+	if n, _ := m.Matches(); n != 0 {
+		t.Fatalf("an upper-case query should match case, got %d matches", n)
+	}
+	d.Press("/").Type("line 1").Press("enter")
+	if n, _ := m.Matches(); n != 11 {
+		t.Fatalf("matches = %d for %q", n, "line 1")
+	}
+	d.Press("N")
+	if _, cur := m.Matches(); cur != 10 || m.TopLine() > 18 || m.TopLine()+4 <= 18 {
+		t.Fatalf("N should wrap to the last match, current = %d, top = %d", cur, m.TopLine())
+	}
+	d.Press("n")
+	if _, cur := m.Matches(); cur != 0 {
+		t.Fatalf("n should wrap to the first match, current = %d", cur)
+	}
+
+	top := m.TopLine()
+	d.Press("/").Type("zzz").Press("esc")
+	if m.Query() != "" || m.TopLine() != top {
+		t.Fatalf("esc should clear the search and stay put, query %q, top %d", m.Query(), m.TopLine())
+	}
+}
+
+func TestHighlightKeepsRowsExact(t *testing.T) {
+	m := New(Content("\x1b[31mred error\x1b[0m and another error here\n"))
+	m.SetSize(24, 2)
+	m.Search("error")
+	lines := testkit.FrameOf(m.View()).Lines()
+	if !strings.HasPrefix(lines[0], "red error and another er") {
+		t.Fatalf("row = %q", lines[0])
+	}
+	if n, _ := m.Matches(); n != 2 {
+		t.Fatalf("matches = %d, want 2", n)
+	}
+}
+
+func TestWrapAndPan(t *testing.T) {
+	m := New(Content("0123456789abcdefghij\nshort\n"))
+	m.Focus()
+	d := testkit.Boot(t, m, 8, 4)
+	d.RequireContains("01234567").RequireNotContains("89ab")
+	d.Press("l").RequireContains("456789ab")
+	d.Press("w").RequireContains("89abcdef").RequireContains("ghij").RequireContains("wrap")
+	if m.totalRows() != 4 {
+		t.Fatalf("wrapped rows = %d, want 4", m.totalRows())
+	}
+	d.Press("w").RequireContains("01234567")
+}
+
+func TestGotoLine(t *testing.T) {
+	m := New(Content(numbered(50)))
+	m.Focus()
+	d := testkit.Boot(t, m, 20, 6)
+	d.Press(":").Type("x4").Press("2", "enter")
+	if m.TopLine() != 41 {
+		t.Fatalf("top line = %d after :42, want 41", m.TopLine())
+	}
+	m.GotoLine(500)
+	if !m.AtBottom() {
+		t.Fatal("a line past the end should scroll to the bottom")
+	}
+}
+
+func TestFollowTail(t *testing.T) {
+	m := New(Follow(), MaxLines(100))
+	m.Focus()
+	d := testkit.Boot(t, m, 20, 4)
+	m.Append("build ")
+	m.Append("started\nstep 1\n")
+	if m.LineCount() != 2 {
+		t.Fatalf("lines = %d, chunks should join until a newline", m.LineCount())
+	}
+	m.Append(numbered(10))
+	d.Send(nil).RequireContains("line 10").RequireContains("follow")
+
+	d.Press("k")
+	if m.IsFollowing() {
+		t.Fatal("scrolling up should stop following")
+	}
+	m.Append("line 11\n")
+	if m.AtBottom() {
+		t.Fatal("a paused viewport should not jump to new output")
+	}
+	d.Press("F")
+	if !m.AtBottom() || !m.IsFollowing() {
+		t.Fatal("F should resume following at the bottom")
+	}
+
+	m.Append(numbered(200))
+	if m.LineCount() != 100 {
+		t.Fatalf("lines = %d, want MaxLines to cap at 100", m.LineCount())
+	}
+}
+
+func TestBlurredIgnoresKeys(t *testing.T) {
+	m := New(Content(numbered(20)))
+	d := testkit.Boot(t, m, 20, 5)
+	d.Press("j", "G", "/", "w")
+	if m.Offset() != 0 || m.IsWrapped() || m.prompt != promptNone {
+		t.Fatal("a blurred viewport should ignore keys")
+	}
+}
+
+func TestSetKeymapUsesRegistryKeys(t *testing.T) {
+	r := keymap.NewRegistry()
+	r.Register(Scope, keymap.Binding{Action: ActionSearch, Keys: []string{"ctrl+f"}})
+	m := New(Content(numbered(20)))
+	m.SetKeymap(r)
+	m.Focus()
+	d := testkit.Boot(t, m, 20, 5)
+	d.Press("/")
+	if m.prompt != promptNone {
+		t.Fatal("/ should no longer open search")
+	}
+	d.Press("ctrl+f").Type("9").Press("enter")
+	if m.Query() != "9" {
+		t.Fatalf("query = %q", m.Query())
+	}
+	if _, ok := r.Lookup(ActionFollow); !ok {
+		t.Fatal("SetKeymap should register the remaining defaults")
+	}
+}
+
+func TestFirstResizeWhileFollowingWrapped(t *testing.T) {
+	m := New(Wrap(), Follow(), Content("a\nb\n"))
+	m.Update(tea.WindowSizeMsg{Width: 40, Height: 10})
+	if m.TopLine() != 0 || !m.AtBottom() {
+		t.Fatalf("top line = %d, at bottom = %v", m.TopLine(), m.AtBottom())
+	}
+
+	m.SetSize(40, 0)
+	m.Append("c\n")
+	m.SetSize(40, 3) // two content rows above the status
+	if m.TopLine() != 1 {
+		t.Fatalf("top line = %d after growing from zero height, want 1", m.TopLine())
+	}
+}
+
+func TestStreamedAppendMatchesFullContent(t *testing.T) {
+	streamed := New(Wrap(), Follow(), MaxLines(5))
+	streamed.SetSize(6, 4)
+	streamed.Search("err")
+	var all []string
+	for i := range 12 {
+		chunk := fmt.Sprintf("line %d ok\n", i)
+		if i%3 == 0 {
+			chunk = fmt.Sprintf("err %d: wrapped past the width\n", i)
+		}
+		streamed.Append(chunk[:4])
+		streamed.Append(chunk[4:])
+		all = append(all, strings.TrimSuffix(chunk, "\n"))
+	}
+
+	whole := New(Wrap(), Follow())
+	whole.SetSize(6, 4)
+	whole.Search("err")
+	whole.SetContent(strings.Join(all[len(all)-5:], "\n") + "\n")
+
+	if got, want := streamed.totalRows(), whole.totalRows(); got != want {
+		t.Fatalf("rows = %d, want %d", got, want)
+	}
+	if got, want := fmt.Sprint(streamed.Matches()), fmt.Sprint(whole.Matches()); got != want {
+		t.Fatalf("matches = %s, want %s", got, want)
+	}
+	if !streamed.AtBottom() || streamed.Offset() != whole.Offset() {
+		t.Fatalf("offset = %d, want %d at the bottom", streamed.Offset(), whole.Offset())
+	}
+	streamed.SetFollow(false)
+	whole.SetFollow(false)
+	streamed.NextMatch()
+	whole.NextMatch()
+	if got, want := testkit.FrameOf(streamed.View()).Plain(), testkit.FrameOf(whole.View()).Plain(); got != want {
+		t.Fatalf("streamed view:\n%s\nwant:\n%s", got, want)
+	}
+}
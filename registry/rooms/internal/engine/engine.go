@@ -80,6 +80,8 @@ func Allocate(specs []Spec, total int) []int {
 	remaining := total - fixedTotal
 
 	if ratioWeight > 0 {
+		// Leave one cell for every fill so ratios cannot starve them.
+		pool := remaining - fillCount
 		ratioAssigned := 0
 		lastRatio := -1
 		for i, s := range specs {
@@ -88,13 +90,13 @@ func Allocate(specs []Spec, total int) []int {
 			}
 			lastRatio = i
 			size := Max(1, s.N)
-			part := (remaining * size) / ratioWeight
+			part := (pool * size) / ratioWeight
 			part = Max(1, part)
 			out[i] = part
 			ratioAssigned += part
 		}
 		if lastRatio >= 0 {
-			out[lastRatio] += remaining - ratioAssigned
+			out[lastRatio] += pool - ratioAssigned
 		}
 	}
 
@@ -136,10 +138,14 @@ func Allocate(specs []Spec, total int) []int {
 		}
 	}
 
-	return rebalance(out, total)
+	return rebalance(out, specs, total)
 }
 
-func rebalance(values []int, total int) []int {
+// rebalance corrects rounding so values sum to total. Growth goes to the
+// last flexible (Fill or Ratio) cell. Shrinking takes from flexible cells
+// first, last to first, and only then from Fixed cells, so fixed sizes hold
+// whenever the layout can fit them. No cell goes below 1.
+func rebalance(values []int, specs []Spec, total int) []int {
 	sum := 0
 	for _, n := range values {
 		sum += n
@@ -150,18 +156,27 @@ func rebalance(values []int, total int) []int {
 	}
 
 	if sum < total {
-		values[len(values)-1] += total - sum
+		grow := len(values) - 1
+		for i := len(specs) - 1; i >= 0; i-- {
+			if specs[i].Kind != Fixed {
+				grow = i
+				break
+			}
+		}
+		values[grow] += total - sum
 		return values
 	}
 
 	diff := sum - total
-	for i := len(values) - 1; i >= 0 && diff > 0; i-- {
-		if values[i] <= 1 {
-			continue
+	for _, fixed := range []bool{false, true} {
+		for i := len(values) - 1; i >= 0 && diff > 0; i-- {
+			if (specs[i].Kind == Fixed) != fixed || values[i] <= 1 {
+				continue
+			}
+			take := Min(diff, values[i]-1)
+			values[i] -= take
+			diff -= take
 		}
-		take := Min(diff, values[i]-1)
-		values[i] -= take
-		diff -= take
 	}
 
 	return values
@@ -190,16 +205,47 @@ func constrainLine(line string, width int) string {
 	if width <= 0 {
 		return ""
 	}
+	// Invalid UTF-8 has no agreed width; render it as U+FFFD like terminals do.
+	line = strings.ToValidUTF8(line, "\uFFFD")
 	if lipgloss.Width(line) > width {
 		line = ansi.Truncate(line, width, "")
 	}
 	if lipgloss.Width(line) < width {
-		line += strings.Repeat(" ", width-lipgloss.Width(line))
+		padded := pad(line, width)
+		if lipgloss.Width(padded) != width {
+			// An unterminated escape sequence swallowed the padding; drop the
+			// styling rather than hand back a line of the wrong width.
+			padded = pad(ansi.Truncate(strings.Map(dropControl, ansi.Strip(line)), width, ""), width)
+		}
+		line = padded
+	}
+	return line
+}
+
+// pad appends spaces until line is width cells. A trailing prepend mark
+// (U+0600 and friends) joins the first space into its grapheme, so one pass
+// can come up a cell short.
+func pad(line string, width int) string {
+	for i := 0; i < 2; i++ {
+		w := lipgloss.Width(line)
+		if w >= width {
+			break
+		}
+		line += strings.Repeat(" ", width-w)
 	}
 	return line
 }
 
+func dropControl(r rune) rune {
+	if r < 0x20 || r == 0x7f || (r >= 0x80 && r < 0xa0) {
+		return -1
+	}
+	return r
+}
+
 func Overlay(bg, fg string, x, y int) string {
+	bg = strings.ToValidUTF8(bg, "\uFFFD")
+	fg = strings.ToValidUTF8(fg, "\uFFFD")
 	bgLines := strings.Split(strings.ReplaceAll(bg, "\r\n", "\n"), "\n")
 	fgLines := strings.Split(strings.ReplaceAll(fg, "\r\n", "\n"), "\n")
 
@@ -225,9 +271,9 @@ func Overlay(bg, fg string, x, y int) string {
 			continue
 		}
 
-		left := ansi.Cut(bgLines[target], 0, start)
-		right := ansi.Cut(bgLines[target], end, bgW)
-		fgPart := ansi.Cut(fgLine, start-x, end-x)
+		left := cutCells(bgLines[target], 0, start)
+		right := cutCells(bgLines[target], end, bgW)
+		fgPart := cutCells(fgLine, start-x, end-x)
 
 		bgLines[target] = constrainLine(left+fgPart+right, bgW)
 	}
@@ -235,6 +281,23 @@ func Overlay(bg, fg string, x, y int) string {
 	return strings.Join(bgLines, "\n")
 }
 
+// cutCells returns columns [from, to) of an ANSI string as exactly to-from
+// cells. A wide rune split by either edge becomes a space, so everything after
+// it keeps its column (ansi.Cut alone would shift the rest left or right).
+func cutCells(s string, from, to int) string {
+	if to <= from {
+		return ""
+	}
+	if from > 0 && lipgloss.Width(s) > from && lipgloss.Width(ansi.Truncate(s, from, "")) < from {
+		return " " + cutCells(s, from+1, to)
+	}
+	part := ansi.Cut(s, from, to)
+	if w := lipgloss.Width(part); w < to-from {
+		part += strings.Repeat(" ", to-from-w)
+	}
+	return part
+}
+
 func Min(a, b int) int {
 	if a < b {
 		return a
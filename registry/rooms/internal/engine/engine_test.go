@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// minSize is the smallest size a spec asks for: N for Fixed, 1 otherwise.
+func minSize(s Spec) int {
+	if s.Kind == Fixed {
+		return Max(1, s.N)
+	}
+	return 1
+}
+
+// checkAllocation verifies the Allocate invariants and returns a description
+// of the first violation, or "".
+func checkAllocation(specs []Spec, total int) string {
+	got := Allocate(specs, total)
+	if len(got) != len(specs) {
+		return fmt.Sprintf("got %d sizes for %d specs", len(got), len(specs))
+	}
+	sum, need := 0, 0
+	for i, n := range got {
+		if n < 1 {
+			return fmt.Sprintf("cell %d has size %d", i, n)
+		}
+		sum += n
+		need += minSize(specs[i])
+	}
+	// Every cell is at least 1, so the sum can only exceed the total when
+	// there are more cells than cells of space.
+	if want := Max(Max(1, total), len(specs)); sum != want {
+		return fmt.Sprintf("sum %d, want %d (sizes %v)", sum, want, got)
+	}
+	if need <= total {
+		for i, n := range got {
+			if n < minSize(specs[i]) {
+				return fmt.Sprintf("cell %d got %d, below its min %d although %d ≤ %d (sizes %v)",
+					i, n, minSize(specs[i]), need, total, got)
+			}
+		}
+	}
+	return ""
+}
+
+func randomSpecs(r *rand.Rand) []Spec {
+	specs := make([]Spec, 1+r.Intn(6))
+	for i := range specs {
+		specs[i] = Spec{Kind: Sizing(r.Intn(3)), N: r.Intn(12) - 1}
+	}
+	return specs
+}
+
+func TestAllocateInvariants(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for range 20000 {
+		specs := randomSpecs(r)
+		total := r.Intn(40) - 2
+		if msg := checkAllocation(specs, total); msg != "" {
+			t.Fatalf("Allocate(%v, %d): %s", specs, total, msg)
+		}
+	}
+}
+
+func TestAllocateRatioShareWithFillAndFixed(t *testing.T) {
+	cases := []struct {
+		specs []Spec
+		total int
+		want  []int
+	}{
+		// Ratio cells used to swallow the fill cell's space, forcing the
+		// fixed cell below its size during rebalance.
+		{[]Spec{{Ratio, 1}, {Fixed, 3}, {Fill, 0}}, 6, []int{2, 3, 1}},
+		// Ratio floors pushed the sum over and the fixed tail paid for it.
+		{[]Spec{{Ratio, 10}, {Ratio, 1}, {Ratio, 1}, {Fixed, 2}}, 5, []int{1, 1, 1, 2}},
+	}
+	for _, tc := range cases {
+		got := Allocate(tc.specs, tc.total)
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Errorf("Allocate(%v, %d) = %v, want %v", tc.specs, tc.total, got, tc.want)
+		}
+	}
+}
+
+func TestRebalancePrefersFlexibleCells(t *testing.T) {
+	specs := []Spec{{Fill, 0}, {Fixed, 4}}
+	got := rebalance([]int{5, 4}, specs, 6)
+	if fmt.Sprint(got) != "[2 4]" {
+		t.Fatalf("expected fill to shrink before fixed, got %v", got)
+	}
+	got = rebalance([]int{2, 4}, specs, 9)
+	if fmt.Sprint(got) != "[5 4]" {
+		t.Fatalf("expected fill to absorb growth, got %v", got)
+	}
+}
+
+// samples mixes ASCII, wide runes, combining marks, emoji, tabs and styling.
+var samples = []string{
+	"",
+	"plain",
+	"世界こんにちは",
+	"é café",
+	"👩‍💻 dev 🚀",
+	"a\tb",
+	"\x1b[31mred\x1b[m and \x1b[1;44mbold blue\x1b[m",
+	lipgloss.NewStyle().Background(lipgloss.Color("#123456")).Render("世 styled 界"),
+	"line one\nline two\r\nline three",
+}
+
+func checkExact(out string, width, height int) string {
+	if width <= 0 || height <= 0 {
+		if out != "" {
+			return fmt.Sprintf("expected empty output for %dx%d", width, height)
+		}
+		return ""
+	}
+	lines := strings.Split(out, "\n")
+	if len(lines) != height {
+		return fmt.Sprintf("got %d lines, want %d", len(lines), height)
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != width {
+			return fmt.Sprintf("line %d is %d wide, want %d: %q", i, w, width, line)
+		}
+	}
+	return ""
+}
+
+func TestConstrainExact(t *testing.T) {
+	for _, s := range samples {
+		for w := -1; w <= 14; w++ {
+			for h := -1; h <= 4; h++ {
+				if msg := checkExact(Constrain(s, w, h), w, h); msg != "" {
+					t.Fatalf("Constrain(%q, %d, %d): %s", s, w, h, msg)
+				}
+			}
+		}
+	}
+}
+
+func TestOverlayKeepsBackgroundDimensions(t *testing.T) {
+	for _, bgSample := range samples {
+		bg := Constrain(bgSample+"\n"+bgSample, 9, 3)
+		for _, fg := range samples {
+			for x := -12; x <= 12; x++ {
+				for y := -3; y <= 4; y++ {
+					if msg := checkExact(Overlay(bg, fg, x, y), 9, 3); msg != "" {
+						t.Fatalf("Overlay(%q, %q, %d, %d): %s", bg, fg, x, y, msg)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestOverlayNegativeX(t *testing.T) {
+	got := Overlay("..........", "abcdef", -2, 0)
+	if got != "cdef......" {
+		t.Fatalf("expected fg clipped on the left, got %q", got)
+	}
+	got = Overlay("..........", "世界x", -1, 0)
+	if got != " 界x......" {
+		t.Fatalf("expected half a wide rune to become a space, got %q", got)
+	}
+}
+
+func TestOverlayOverWideRunes(t *testing.T) {
+	got := Overlay("世界世界世", "ab", 1, 0)
+	if got != " ab 世界世" {
+		t.Fatalf("expected fg at column 1 over split wide runes, got %q", got)
+	}
+}
+
+func FuzzAllocate(f *testing.F) {
+	f.Add([]byte{0, 3, 1, 0, 2, 2}, 10)
+	f.Add([]byte{2, 10, 2, 1, 2, 1, 0, 2}, 5)
+	f.Add([]byte{2, 1, 0, 3, 1, 0}, 6)
+	f.Fuzz(func(t *testing.T, raw []byte, total int) {
+		if len(raw) < 2 || len(raw) > 32 || total > 10000 || total < -10 {
+			t.Skip()
+		}
+		specs := make([]Spec, len(raw)/2)
+		for i := range specs {
+			specs[i] = Spec{Kind: Sizing(raw[2*i] % 3), N: int(raw[2*i+1]) - 1}
+		}
+		if msg := checkAllocation(specs, total); msg != "" {
+			t.Fatalf("Allocate(%v, %d): %s", specs, total, msg)
+		}
+	})
+}
+
+func FuzzConstrain(f *testing.F) {
+	for _, s := range samples {
+		f.Add(s, 7, 3)
+	}
+	f.Fuzz(func(t *testing.T, content string, width, height int) {
+		if width > 200 || height > 50 {
+			t.Skip()
+		}
+		if msg := checkExact(Constrain(content, width, height), width, height); msg != "" {
+			t.Fatalf("Constrain(%q, %d, %d): %s", content, width, height, msg)
+		}
+	})
+}
+
+func FuzzOverlay(f *testing.F) {
+	for _, s := range samples {
+		f.Add(s, s, -2, 1)
+	}
+	f.Fuzz(func(t *testing.T, bg, fg string, x, y int) {
+		if x < -100 || x > 100 || y < -20 || y > 20 {
+			t.Skip()
+		}
+		base := Constrain(bg, 12, 4)
+		if msg := checkExact(Overlay(base, fg, x, y), 12, 4); msg != "" {
+			t.Fatalf("Overlay(%q, %q, %d, %d): %s", base, fg, x, y, msg)
+		}
+	})
+}
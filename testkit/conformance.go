@@ -0,0 +1,333 @@
+package testkit
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/cloudboy-jh/bentotui/theme"
+)
+
+// Brick is the part of the brick contract every brick implements.
+type Brick interface {
+	View() tea.View
+	SetSize(width, height int)
+	GetSize() (width, height int)
+}
+
+// Conformance describes a brick for RunConformance. Only New is required;
+// optional capabilities (SetTheme, Focus/Blur, Update) are detected on the
+// brick itself.
+type Conformance struct {
+	// New returns a fresh brick with representative content.
+	New func() Brick
+
+	// Sizes to render at. nil = DefaultSizes.
+	Sizes [][2]int
+
+	// MinSize is the smallest pane the brick supports. Smaller entries in
+	// Sizes are skipped.
+	MinSize [2]int
+
+	// Inline bricks (badges, key hints, labels) render at their natural size
+	// and ignore SetSize for layout; the size and exact checks only require
+	// that they never render wider than the pane they were given.
+	Inline bool
+
+	// FixedHeight is the row count of single-line bricks (bars, tabs,
+	// progress) that ignore the height passed to SetSize. 0 = the brick
+	// fills the height it is given.
+	FixedHeight int
+
+	// Themes to render with. nil = every preset in theme.Names().
+	Themes []string
+
+	// Keys sent to the brick while blurred; none may change its view.
+	// nil = DefaultFocusKeys.
+	Keys []string
+
+	// WindowSize requires the brick to resize itself on tea.WindowSizeMsg.
+	WindowSize bool
+
+	// Transparent bricks may leave cells without a background so the canvas
+	// shows through; otherwise every cell must be painted.
+	Transparent bool
+}
+
+// DefaultSizes covers degenerate, narrow, typical and large panes.
+var DefaultSizes = [][2]int{{1, 1}, {2, 1}, {7, 2}, {20, 3}, {33, 7}, {80, 24}, {131, 40}}
+
+// DefaultFocusKeys are the keys bricks commonly bind.
+var DefaultFocusKeys = []string{"up", "down", "left", "right", "enter", "space", "tab", "j", "k", "x"}
+
+// RunConformance checks a brick against the bento brick contract, one
+// subtest per rule:
+//
+//   - size:        GetSize reports what SetSize set
+//   - exact:       every row is exactly the pane width, and there are exactly
+//     as many rows as the pane height, at every size and theme
+//   - bleed:       no styling escapes a row, no cursor movement or erase
+//     sequences, and (unless Transparent) every cell has a background
+//   - theme:       SetTheme changes the render, and a brick with no theme
+//     renders like one given theme.CurrentTheme()
+//   - focus:       a blurred brick ignores keys; Focus/Blur toggle IsFocused
+//   - window-size: tea.WindowSizeMsg resizes the brick (when WindowSize)
+//
+// It switches the global theme while it runs and restores it afterwards, so
+// it must not run in parallel with other theme-dependent tests.
+func RunConformance(t *testing.T, c Conformance) {
+	t.Helper()
+	if c.New == nil {
+		t.Fatal("Conformance.New is required")
+	}
+	if c.Sizes == nil {
+		c.Sizes = DefaultSizes
+	}
+	sizes := make([][2]int, 0, len(c.Sizes))
+	for _, sz := range c.Sizes {
+		if sz[0] >= c.MinSize[0] && sz[1] >= c.MinSize[1] {
+			sizes = append(sizes, sz)
+		}
+	}
+	if len(sizes) == 0 {
+		t.Fatalf("no sizes at or above MinSize %v", c.MinSize)
+	}
+	c.Sizes = sizes
+	if c.Themes == nil {
+		c.Themes = theme.Names()
+	}
+	if c.Keys == nil {
+		c.Keys = DefaultFocusKeys
+	}
+	original := theme.CurrentThemeName()
+	t.Cleanup(func() { _, _ = theme.SetTheme(original) })
+
+	t.Run("size", func(t *testing.T) { c.checkSize(t) })
+	t.Run("exact", func(t *testing.T) { c.checkExact(t) })
+	t.Run("bleed", func(t *testing.T) { c.checkBleed(t) })
+	t.Run("theme", func(t *testing.T) { c.checkTheme(t) })
+	t.Run("focus", func(t *testing.T) { c.checkFocus(t) })
+	if c.WindowSize {
+		t.Run("window-size", func(t *testing.T) { c.checkWindowSize(t) })
+	}
+}
+
+func (c Conformance) wantHeight(h int) int {
+	if c.FixedHeight > 0 {
+		return c.FixedHeight
+	}
+	return h
+}
+
+func (c Conformance) checkSize(t *testing.T) {
+	if c.Inline {
+		t.Skip("inline brick")
+	}
+	for _, sz := range c.Sizes {
+		b := c.New()
+		b.SetSize(sz[0], sz[1])
+		w, h := b.GetSize()
+		if w != sz[0] || h != c.wantHeight(sz[1]) {
+			t.Errorf("SetSize(%d, %d): GetSize() = (%d, %d), want (%d, %d)",
+				sz[0], sz[1], w, h, sz[0], c.wantHeight(sz[1]))
+		}
+	}
+}
+
+func (c Conformance) checkExact(t *testing.T) {
+	for _, name := range c.Themes {
+		for _, sz := range c.Sizes {
+			for _, focused := range []bool{false, true} {
+				out := renderCase(c.New(), Case{Width: sz[0], Height: sz[1], Theme: name, Focus: focused})
+				if err := c.exactRows(out, sz[0], c.wantHeight(sz[1])); err != nil {
+					t.Errorf("%s %dx%d focused=%t: %v", name, sz[0], sz[1], focused, err)
+				}
+			}
+		}
+	}
+}
+
+// csiNonSGR matches control sequences other than SGR (ESC [ ... m).
+var csiNonSGR = regexp.MustCompile(`\x1b\[[0-9;?:]*[@-ln-~]`)
+
+func (c Conformance) checkBleed(t *testing.T) {
+	for _, name := range c.Themes {
+		for _, sz := range c.Sizes {
+			out := renderCase(c.New(), Case{Width: sz[0], Height: sz[1], Theme: name})
+			label := fmt.Sprintf("%s %dx%d", name, sz[0], sz[1])
+			if strings.ContainsAny(out, "\r\t") {
+				t.Errorf("%s: output contains a raw carriage return or tab: %q", label, out)
+			}
+			if m := csiNonSGR.FindString(out); m != "" {
+				t.Errorf("%s: output contains non-SGR control sequence %q", label, m)
+			}
+			for y, line := range strings.Split(out, "\n") {
+				if !resetsAtEnd(line) {
+					t.Errorf("%s: row %d leaves styling open: %q", label, y, line)
+					break
+				}
+			}
+			if c.Transparent {
+				continue
+			}
+			f := NewFrame(out)
+			if x, y, ok := unpaintedCell(f); ok {
+				t.Errorf("%s: cell (%d,%d) has no background: %q", label, x, y, f.Lines()[y])
+			}
+		}
+	}
+}
+
+func (c Conformance) checkTheme(t *testing.T) {
+	if _, ok := c.New().(interface{ SetTheme(theme.Theme) }); !ok {
+		t.Skip("brick has no SetTheme")
+	}
+	if len(c.Themes) < 2 {
+		t.Skip("need at least two themes")
+	}
+	sz := c.Sizes[len(c.Sizes)/2]
+	render := func(b Brick) string {
+		b.SetSize(sz[0], sz[1])
+		return NewFrame(ViewString(b.View())).ANSI()
+	}
+
+	a, b := c.Themes[0], c.Themes[1]
+	_, _ = theme.SetTheme(a)
+
+	explicitA := c.New()
+	explicitA.(interface{ SetTheme(theme.Theme) }).SetTheme(theme.Preset(a))
+	fallback := c.New()
+	if render(fallback) != render(explicitA) {
+		t.Errorf("brick without a theme does not render like SetTheme(theme.CurrentTheme())")
+	}
+
+	explicitB := c.New()
+	explicitB.(interface{ SetTheme(theme.Theme) }).SetTheme(theme.Preset(b))
+	if render(explicitA) == render(explicitB) {
+		t.Errorf("SetTheme(%s) and SetTheme(%s) render identically", a, b)
+	}
+
+	_, _ = theme.SetTheme(b)
+	if render(fallback) != render(explicitB) {
+		t.Errorf("brick without a theme does not follow theme.CurrentTheme() after a switch")
+	}
+	if render(explicitA) == render(explicitB) {
+		t.Errorf("explicit SetTheme was overridden by the global theme")
+	}
+}
+
+func (c Conformance) checkFocus(t *testing.T) {
+	b := c.New()
+	blurrer, ok := b.(interface{ Blur() })
+	if !ok {
+		t.Skip("brick has no Focus/Blur")
+	}
+	sz := c.Sizes[len(c.Sizes)/2]
+	b.SetSize(sz[0], sz[1])
+	blurrer.Blur()
+	if f, ok := b.(interface{ IsFocused() bool }); ok && f.IsFocused() {
+		t.Fatal("IsFocused() true after Blur()")
+	}
+
+	before := ViewString(b.View())
+	for _, k := range c.Keys {
+		var cmd tea.Cmd
+		b, cmd = updateBrick(b, Key(k))
+		if cmd != nil {
+			t.Errorf("blurred brick returned a command for key %q", k)
+		}
+	}
+	if after := ViewString(b.View()); after != before {
+		t.Errorf("blurred brick changed its view in response to keys %v", c.Keys)
+	}
+
+	SetFocus(b, true)
+	if f, ok := b.(interface{ IsFocused() bool }); ok && !f.IsFocused() {
+		t.Error("IsFocused() false after Focus()")
+	}
+}
+
+func (c Conformance) checkWindowSize(t *testing.T) {
+	if c.Inline {
+		t.Skip("inline brick")
+	}
+	for _, sz := range c.Sizes {
+		b, _ := updateBrick(c.New(), tea.WindowSizeMsg{Width: sz[0], Height: sz[1]})
+		w, h := b.GetSize()
+		if w != sz[0] || h != c.wantHeight(sz[1]) {
+			t.Errorf("WindowSizeMsg{%d, %d}: GetSize() = (%d, %d)", sz[0], sz[1], w, h)
+		}
+	}
+}
+
+// updateBrick sends msg to b if it has an Update method, keeping the
+// returned model when it is still a Brick.
+func updateBrick(b Brick, msg tea.Msg) (Brick, tea.Cmd) {
+	u, ok := b.(interface {
+		Update(tea.Msg) (tea.Model, tea.Cmd)
+	})
+	if !ok {
+		return b, nil
+	}
+	next, cmd := u.Update(msg)
+	if nb, ok := next.(Brick); ok {
+		return nb, cmd
+	}
+	return b, cmd
+}
+
+func (c Conformance) exactRows(out string, width, height int) error {
+	lines := strings.Split(out, "\n")
+	if c.Inline {
+		for y, line := range lines {
+			if w := ansi.StringWidth(line); w > width {
+				return fmt.Errorf("row %d is %d cells wide, pane is %d", y, w, width)
+			}
+		}
+		return nil
+	}
+	if len(lines) != height {
+		return fmt.Errorf("got %d rows, want %d", len(lines), height)
+	}
+	for y, line := range lines {
+		if w := ansi.StringWidth(line); w != width {
+			return fmt.Errorf("row %d is %d cells wide, want %d", y, w, width)
+		}
+	}
+	return nil
+}
+
+// resetsAtEnd reports whether a row that sets SGR attributes ends with them
+// reset, so the next row starts from a clean pen.
+func resetsAtEnd(line string) bool {
+	last := strings.LastIndex(line, "\x1b[")
+	if last < 0 {
+		return true
+	}
+	seq := line[last:]
+	end := strings.IndexByte(seq, 'm')
+	if end < 0 {
+		return false
+	}
+	params := seq[2:end]
+	return params == "" || params == "0"
+}
+
+func unpaintedCell(f Frame) (int, int, bool) {
+	for y := 0; y < f.Height(); y++ {
+		for x := 0; x < f.Width(); x++ {
+			c := f.Cell(x, y)
+			if c == nil || c.Width == 0 {
+				continue
+			}
+			if c.Style.Bg == nil {
+				return x, y, true
+			}
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,108 @@
+package testkit
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+// pane follows the whole brick contract.
+type pane struct {
+	w, h    int
+	focused bool
+	cursor  int
+	theme   theme.Theme
+}
+
+func (p *pane) SetSize(w, h int)       { p.w, p.h = max(1, w), max(1, h) }
+func (p *pane) GetSize() (int, int)    { return p.w, p.h }
+func (p *pane) SetTheme(t theme.Theme) { p.theme = t }
+func (p *pane) Focus()                 { p.focused = true }
+func (p *pane) Blur()                  { p.focused = false }
+func (p *pane) IsFocused() bool        { return p.focused }
+func (p *pane) Init() tea.Cmd          { return nil }
+
+func (p *pane) activeTheme() theme.Theme {
+	if p.theme != nil {
+		return p.theme
+	}
+	return theme.CurrentTheme()
+}
+
+func (p *pane) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.SetSize(msg.Width, msg.Height)
+	case tea.KeyPressMsg:
+		if p.focused && msg.String() == "down" {
+			p.cursor++
+		}
+	}
+	return p, nil
+}
+
+func (p *pane) View() tea.View {
+	t := p.activeTheme()
+	accent := lipgloss.NewStyle().Foreground(t.TextAccent()).Background(t.BackgroundPanel()).Render("●")
+	rows := make([]string, p.h)
+	for i := range rows {
+		rows[i] = styles.RowClip(t.BackgroundPanel(), t.Text(), p.w, accent+styles.Row(t.BackgroundPanel(), t.Text(), 4, " row"))
+	}
+	return tea.NewView(strings.Join(rows, "\n"))
+}
+
+func TestRunConformancePassesForConformingBrick(t *testing.T) {
+	RunConformance(t, Conformance{
+		New:        func() Brick { return &pane{} },
+		Themes:     []string{"nord", "dracula"},
+		WindowSize: true,
+	})
+}
+
+func TestExactRowsRules(t *testing.T) {
+	c := Conformance{}
+	if err := c.exactRows("abc\nabc", 3, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.exactRows("abc\nab", 3, 2); err == nil || !strings.Contains(err.Error(), "row 1 is 2 cells wide") {
+		t.Fatalf("expected short row error, got %v", err)
+	}
+	if err := c.exactRows("abc", 3, 2); err == nil || !strings.Contains(err.Error(), "got 1 rows") {
+		t.Fatalf("expected row count error, got %v", err)
+	}
+	inline := Conformance{Inline: true}
+	if err := inline.exactRows("ab", 3, 2); err != nil {
+		t.Fatalf("inline brick may be narrower than the pane: %v", err)
+	}
+	if err := inline.exactRows("abcd", 3, 2); err == nil {
+		t.Fatal("inline brick must not be wider than the pane")
+	}
+}
+
+func TestBleedDetectors(t *testing.T) {
+	if !resetsAtEnd("plain") || !resetsAtEnd("\x1b[31mred\x1b[m tail") || !resetsAtEnd("\x1b[1mx\x1b[0m") {
+		t.Fatal("expected closed rows to pass")
+	}
+	if resetsAtEnd("\x1b[31mred") || resetsAtEnd("\x1b[m\x1b[44m ") {
+		t.Fatal("expected open styling to be detected")
+	}
+	if csiNonSGR.FindString("\x1b[2K\x1b[31mx") != "\x1b[2K" {
+		t.Fatal("expected erase-line sequence to be detected")
+	}
+	if csiNonSGR.MatchString("\x1b[38;2;1;2;3mx\x1b[m") {
+		t.Fatal("SGR sequences are allowed")
+	}
+
+	bg := lipgloss.NewStyle().Background(lipgloss.Color("#101010"))
+	if _, _, ok := unpaintedCell(NewFrame(bg.Render("ab"))); ok {
+		t.Fatal("painted frame reported as unpainted")
+	}
+	if x, y, ok := unpaintedCell(NewFrame(bg.Render("ab") + "c")); !ok || x != 2 || y != 0 {
+		t.Fatalf("expected (2,0) unpainted, got (%d,%d) %t", x, y, ok)
+	}
+}
@@ -0,0 +1,355 @@
+package testkit
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// Driver runs a tea.Model headlessly: no terminal, no goroutine loop. Every
+// message sent to it is delivered to Update, the returned commands are
+// executed and their messages delivered in turn until nothing is left to do,
+// and only then does the call return. Tests can therefore script a whole
+// flow — keys, resizes, ticks — and assert on each intermediate frame and on
+// the messages the model emitted along the way. Like Bubble Tea, the driver
+// calls View after every Update, so a frame that panics halfway through a
+// flow fails the test even if the flow ends somewhere safe.
+//
+// Commands that do not return within the command timeout (tea.Tick, cursor
+// blinks, real I/O) are abandoned and counted in Pending; send the message
+// they would have produced explicitly to simulate them.
+type Driver struct {
+	t       testing.TB
+	model   tea.Model
+	width   int
+	height  int
+	timeout time.Duration
+	limit   int
+
+	emitted []tea.Msg
+	frames  []Frame
+	pending int
+	quit    bool
+}
+
+// DriverOption configures a Driver.
+type DriverOption func(*Driver)
+
+// CmdTimeout sets how long a single command may run before it is abandoned.
+// The default is 50ms.
+func CmdTimeout(d time.Duration) DriverOption {
+	return func(dr *Driver) { dr.timeout = d }
+}
+
+// MaxMessages bounds how many messages one step may process before the
+// driver gives up on the model settling. The default is 10000.
+func MaxMessages(n int) DriverOption {
+	return func(dr *Driver) { dr.limit = n }
+}
+
+// Boot starts m at a width x height terminal: it runs Init, delivers a
+// tea.WindowSizeMsg and settles.
+func Boot(t testing.TB, m tea.Model, width, height int, opts ...DriverOption) *Driver {
+	t.Helper()
+	d := &Driver{t: t, model: m, width: width, height: height, timeout: 50 * time.Millisecond, limit: 10000}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.settle(d.run([]tea.Cmd{m.Init()}))
+	return d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Send delivers each message in turn and settles. The frame left once a
+// message has settled is kept in Frames; the frames in between are rendered
+// but not kept.
+func (d *Driver) Send(msgs ...tea.Msg) *Driver {
+	d.t.Helper()
+	for _, msg := range msgs {
+		d.settle([]tea.Msg{msg})
+		d.frames = append(d.frames, d.Frame())
+	}
+	return d
+}
+
+// Press sends key presses by name: "enter", "esc", "ctrl+k", "shift+tab",
+// "a", … See Key for the accepted syntax.
+func (d *Driver) Press(keys ...string) *Driver {
+	d.t.Helper()
+	for _, k := range keys {
+		d.Send(Key(k))
+	}
+	return d
+}
+
+// Type sends one key press per rune of text.
+func (d *Driver) Type(text string) *Driver {
+	d.t.Helper()
+	for _, r := range text {
+		d.Send(runeKey(r, 0))
+	}
+	return d
+}
+
+// Resize sends a tea.WindowSizeMsg.
+func (d *Driver) Resize(width, height int) *Driver {
+	d.t.Helper()
+	d.width, d.height = width, height
+	return d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Repeat sends msg n times — typically a tick message the model would
+// normally receive from tea.Tick.
+func (d *Driver) Repeat(n int, msg tea.Msg) *Driver {
+	d.t.Helper()
+	for range n {
+		d.Send(msg)
+	}
+	return d
+}
+
+// Model returns the current model.
+func (d *Driver) Model() tea.Model { return d.model }
+
+// View returns the model's current view.
+func (d *Driver) View() tea.View { return d.model.View() }
+
+// Frame renders the model's current view.
+func (d *Driver) Frame() Frame { return FrameOf(d.model.View()) }
+
+// Frames returns the frame captured after every step so far, oldest first.
+func (d *Driver) Frames() []Frame { return d.frames }
+
+// Messages returns every message emitted by commands so far, in delivery
+// order. Messages passed to Send are not included.
+func (d *Driver) Messages() []tea.Msg { return d.emitted }
+
+// Pending returns how many commands were abandoned at the timeout.
+func (d *Driver) Pending() int { return d.pending }
+
+// Quit reports whether the model returned tea.Quit.
+func (d *Driver) Quit() bool { return d.quit }
+
+// RequireContains fails the test unless the current frame contains every
+// text.
+func (d *Driver) RequireContains(texts ...string) *Driver {
+	d.t.Helper()
+	plain := d.Frame().Plain()
+	for _, text := range texts {
+		if !strings.Contains(plain, text) {
+			d.t.Fatalf("expected frame to contain %q:\n%s", text, plain)
+		}
+	}
+	return d
+}
+
+// RequireNotContains fails the test if the current frame contains any text.
+func (d *Driver) RequireNotContains(texts ...string) *Driver {
+	d.t.Helper()
+	plain := d.Frame().Plain()
+	for _, text := range texts {
+		if strings.Contains(plain, text) {
+			d.t.Fatalf("expected frame not to contain %q:\n%s", text, plain)
+		}
+	}
+	return d
+}
+
+// RequireSize fails the test unless the current frame is exactly the
+// terminal size.
+func (d *Driver) RequireSize() *Driver {
+	d.t.Helper()
+	f := d.Frame()
+	if f.Width() != d.width || f.Height() != d.height {
+		d.t.Fatalf("expected %dx%d frame, got %dx%d", d.width, d.height, f.Width(), f.Height())
+	}
+	return d
+}
+
+// Golden compares the current frame against GoldenDir/<name>.golden.
+func (d *Driver) Golden(name string, format Format) *Driver {
+	d.t.Helper()
+	AssertGolden(d.t, filepath.Join(GoldenDir, name+".golden"), d.Frame(), format)
+	return d
+}
+
+// Emitted returns the messages of type T the driver's model emitted so far.
+func Emitted[T any](d *Driver) []T {
+	var out []T
+	for _, msg := range d.emitted {
+		if v, ok := msg.(T); ok {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// settle delivers queued messages breadth-first until no command produces
+// anything new.
+func (d *Driver) settle(queue []tea.Msg) {
+	d.t.Helper()
+	for n := 0; len(queue) > 0; n++ {
+		if n >= d.limit {
+			d.t.Fatalf("model did not settle after %d messages", d.limit)
+		}
+		msg := queue[0]
+		queue = queue[1:]
+		if _, ok := msg.(tea.QuitMsg); ok {
+			d.quit = true
+			continue
+		}
+		next, cmd := d.model.Update(msg)
+		d.model = next
+		d.model.View()
+		queue = append(queue, d.run([]tea.Cmd{cmd})...)
+	}
+}
+
+var cmdSliceType = reflect.TypeOf([]tea.Cmd(nil))
+
+// run executes cmds concurrently and returns their messages in command
+// order. tea.Batch results are expanded the same way; tea.Sequence results
+// run one command at a time, in order, as Bubble Tea runs them.
+func (d *Driver) run(cmds []tea.Cmd) []tea.Msg {
+	results := make([]chan tea.Msg, len(cmds))
+	for i, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		ch := make(chan tea.Msg, 1)
+		results[i] = ch
+		go func() { ch <- cmd() }()
+	}
+
+	deadline := time.Now().Add(d.timeout)
+	var out []tea.Msg
+	for _, ch := range results {
+		if ch == nil {
+			continue
+		}
+		msg, ok := wait(ch, deadline)
+		if !ok {
+			d.pending++
+			continue
+		}
+		if msg == nil {
+			continue
+		}
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			out = append(out, d.run(batch)...)
+			continue
+		}
+		if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().ConvertibleTo(cmdSliceType) {
+			// tea.Sequence's message type is unexported; any other []tea.Cmd is it.
+			for _, cmd := range v.Convert(cmdSliceType).Interface().([]tea.Cmd) {
+				out = append(out, d.run([]tea.Cmd{cmd})...)
+			}
+			continue
+		}
+		d.emitted = append(d.emitted, msg)
+		out = append(out, msg)
+	}
+	return out
+}
+
+// wait receives from ch until deadline. Every command in a batch gets the
+// same deadline, and each wait has its own timer, so one abandoned command
+// cannot leave the next one waiting forever.
+func wait(ch <-chan tea.Msg, deadline time.Time) (tea.Msg, bool) {
+	select {
+	case msg := <-ch:
+		return msg, true
+	default:
+	}
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case msg := <-ch:
+		return msg, true
+	case <-timer.C:
+		return nil, false
+	}
+}
+
+var namedKeys = map[string]rune{
+	"enter":     tea.KeyEnter,
+	"tab":       tea.KeyTab,
+	"backspace": tea.KeyBackspace,
+	"esc":       tea.KeyEscape,
+	"escape":    tea.KeyEscape,
+	"space":     tea.KeySpace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+	"delete":    tea.KeyDelete,
+	"insert":    tea.KeyInsert,
+	"f1":        tea.KeyF1,
+	"f2":        tea.KeyF2,
+	"f3":        tea.KeyF3,
+	"f4":        tea.KeyF4,
+	"f5":        tea.KeyF5,
+	"f6":        tea.KeyF6,
+	"f7":        tea.KeyF7,
+	"f8":        tea.KeyF8,
+	"f9":        tea.KeyF9,
+	"f10":       tea.KeyF10,
+	"f11":       tea.KeyF11,
+	"f12":       tea.KeyF12,
+}
+
+// Key builds a key press from its string form, the same form
+// tea.KeyPressMsg.String() and key.WithKeys use: optional "ctrl+", "alt+"
+// and "shift+" prefixes followed by a named key ("enter", "pgdown", "f5") or
+// a single character. Key(s).String() == s for every accepted s.
+func Key(s string) tea.KeyPressMsg {
+	var mod tea.KeyMod
+	rest := s
+	for {
+		switch {
+		case strings.HasPrefix(rest, "ctrl+") && len(rest) > len("ctrl+"):
+			mod |= tea.ModCtrl
+			rest = rest[len("ctrl+"):]
+			continue
+		case strings.HasPrefix(rest, "alt+") && len(rest) > len("alt+"):
+			mod |= tea.ModAlt
+			rest = rest[len("alt+"):]
+			continue
+		case strings.HasPrefix(rest, "shift+") && len(rest) > len("shift+"):
+			mod |= tea.ModShift
+			rest = rest[len("shift+"):]
+			continue
+		}
+		break
+	}
+	if code, ok := namedKeys[rest]; ok {
+		k := tea.Key{Code: code, Mod: mod}
+		if code == tea.KeySpace && mod == 0 {
+			k.Text = " "
+		}
+		return tea.KeyPressMsg(k)
+	}
+	runes := []rune(rest)
+	if len(runes) != 1 {
+		return tea.KeyPressMsg(tea.Key{Code: tea.KeyExtended, Text: rest, Mod: mod})
+	}
+	return runeKey(runes[0], mod)
+}
+
+func runeKey(r rune, mod tea.KeyMod) tea.KeyPressMsg {
+	k := tea.Key{Code: r, Mod: mod}
+	if mod == 0 {
+		k.Text = string(r)
+	}
+	if r == ' ' {
+		k.Code = tea.KeySpace
+	}
+	return tea.KeyPressMsg(k)
+}
@@ -0,0 +1,190 @@
+package testkit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+type tickMsg struct{}
+type doneMsg struct{ n int }
+
+// counter is a small app: keys change a count, "s" runs a sequence of
+// commands, "w" starts a command that never finishes, "b" a batch of two
+// such commands, "q" quits.
+type counter struct {
+	w, h  int
+	n     int
+	ticks int
+	done  []int
+}
+
+func (c *counter) Init() tea.Cmd { return func() tea.Msg { return doneMsg{n: -1} } }
+
+func (c *counter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		c.w, c.h = msg.Width, msg.Height
+	case tickMsg:
+		c.ticks++
+	case doneMsg:
+		c.done = append(c.done, msg.n)
+	case tea.KeyPressMsg:
+		switch msg.String() {
+		case "up":
+			c.n++
+		case "down":
+			c.n--
+		case "s":
+			return c, tea.Sequence(
+				func() tea.Msg { return doneMsg{n: 1} },
+				tea.Batch(
+					func() tea.Msg { return doneMsg{n: 2} },
+					func() tea.Msg { return doneMsg{n: 3} },
+				),
+			)
+		case "w":
+			return c, tea.Tick(time.Hour, func(time.Time) tea.Msg { return tickMsg{} })
+		case "b":
+			return c, tea.Batch(
+				tea.Tick(time.Hour, func(time.Time) tea.Msg { return tickMsg{} }),
+				tea.Tick(time.Hour, func(time.Time) tea.Msg { return tickMsg{} }),
+			)
+		case "q":
+			return c, tea.Quit
+		}
+	}
+	return c, nil
+}
+
+func (c *counter) View() tea.View {
+	return tea.NewView(fmt.Sprintf("%dx%d n=%d ticks=%d", c.w, c.h, c.n, c.ticks))
+}
+
+func TestDriverBootsAndSettles(t *testing.T) {
+	d := Boot(t, &counter{}, 80, 24)
+	d.RequireContains("80x24 n=0")
+	if got := Emitted[doneMsg](d); len(got) != 1 || got[0].n != -1 {
+		t.Fatalf("expected Init command message, got %v", got)
+	}
+
+	d.Press("up", "up", "down").RequireContains("n=1")
+	if len(d.Frames()) != 4 {
+		t.Fatalf("expected one frame per step, got %d", len(d.Frames()))
+	}
+	if got := d.Frames()[2].Plain(); got != "80x24 n=2 ticks=0" {
+		t.Fatalf("unexpected intermediate frame %q", got)
+	}
+}
+
+func TestDriverExpandsSequencesAndBatches(t *testing.T) {
+	d := Boot(t, &counter{}, 10, 1).Press("s")
+	got := d.Model().(*counter).done
+	want := []int{-1, 1, 2, 3}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("expected messages %v, got %v", want, got)
+	}
+}
+
+// starter is a counter whose Init runs cmd.
+type starter struct {
+	*counter
+	cmd tea.Cmd
+}
+
+func (s starter) Init() tea.Cmd { return s.cmd }
+
+func TestDriverRunsSequenceMembersOneAtATime(t *testing.T) {
+	first := false
+	m := &counter{}
+	Boot(t, starter{m, tea.Sequence(
+		func() tea.Msg { time.Sleep(5 * time.Millisecond); first = true; return nil },
+		func() tea.Msg {
+			if !first {
+				return doneMsg{n: 0}
+			}
+			return doneMsg{n: 2}
+		},
+	)}, 10, 1)
+	if fmt.Sprint(m.done) != "[2]" {
+		t.Fatalf("done = %v; the second command of a sequence ran before the first finished", m.done)
+	}
+}
+
+// fragile draws a frame that panics between a key and the message its
+// command sends back.
+type fragile struct{ busy bool }
+
+func (f *fragile) Init() tea.Cmd { return nil }
+
+func (f *fragile) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg.(type) {
+	case tea.KeyPressMsg:
+		f.busy = true
+		return f, func() tea.Msg { return doneMsg{} }
+	case doneMsg:
+		f.busy = false
+	}
+	return f, nil
+}
+
+func (f *fragile) View() tea.View {
+	if f.busy {
+		panic("intermediate frame")
+	}
+	return tea.NewView("idle")
+}
+
+func TestDriverRendersIntermediateFrames(t *testing.T) {
+	d := Boot(t, &fragile{}, 10, 1)
+	defer func() {
+		if recover() == nil {
+			t.Fatal("a panic in a frame between Update calls should surface")
+		}
+	}()
+	d.Press("x")
+}
+
+func TestDriverAbandonsSlowCommandsAndSimulatesTicks(t *testing.T) {
+	d := Boot(t, &counter{}, 10, 1, CmdTimeout(5*time.Millisecond)).Press("w")
+	if d.Pending() != 1 {
+		t.Fatalf("expected tick command to be abandoned, pending=%d", d.Pending())
+	}
+	d.Repeat(3, tickMsg{}).RequireContains("ticks=3")
+}
+
+func TestDriverAbandonsEverySlowCommandInABatch(t *testing.T) {
+	done := make(chan int, 1)
+	go func() {
+		d := Boot(t, &counter{}, 10, 1, CmdTimeout(5*time.Millisecond))
+		done <- d.Press("b").Pending()
+	}()
+	select {
+	case n := <-done:
+		if n != 2 {
+			t.Fatalf("expected both ticks to be abandoned, pending=%d", n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("driver hung on the second slow command in a batch")
+	}
+}
+
+func TestDriverResizeAndQuit(t *testing.T) {
+	d := Boot(t, &counter{}, 10, 1).Resize(40, 12).RequireContains("40x12")
+	if d.Quit() {
+		t.Fatal("did not expect quit yet")
+	}
+	if !d.Press("q").Quit() {
+		t.Fatal("expected tea.Quit to be observed")
+	}
+}
+
+func TestKeyRoundTrip(t *testing.T) {
+	for _, s := range []string{"a", "Z", "enter", "esc", "ctrl+k", "shift+tab", "alt+x", "ctrl+alt+up", "pgdown", "f5", "space", "/"} {
+		if got := Key(s).String(); got != s {
+			t.Fatalf("Key(%q).String() = %q", s, got)
+		}
+	}
+}
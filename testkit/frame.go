@@ -0,0 +1,187 @@
+// Package testkit provides test helpers for bento bricks, rooms and bentos:
+// normalized frames, golden snapshot files and theme sweeps.
+//
+// Frames are decoded into an Ultraviolet cell grid before they are compared,
+// so two renders that paint the same cells with different escape sequences
+// are equal, and a mismatch is reported cell by cell instead of as a wall of
+// ANSI.
+package testkit
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	uv "github.com/charmbracelet/ultraviolet"
+)
+
+// Frame is a rendered view decoded into a grid of cells. Its size is the
+// size of the content, not the size the view was asked to render at, so
+// overflow shows up in snapshots.
+type Frame struct {
+	buf *uv.Buffer
+}
+
+// NewFrame decodes an ANSI string into a Frame.
+func NewFrame(content string) Frame {
+	ss := uv.NewStyledString(content)
+	b := ss.Bounds()
+	scr := uv.NewScreenBuffer(b.Dx(), b.Dy())
+	ss.Draw(scr, scr.Bounds())
+	return Frame{buf: scr.Buffer}
+}
+
+// FrameOf renders a tea.View into a Frame.
+func FrameOf(v tea.View) Frame { return NewFrame(ViewString(v)) }
+
+// Width returns the frame width in cells.
+func (f Frame) Width() int {
+	if f.buf == nil {
+		return 0
+	}
+	return f.buf.Width()
+}
+
+// Height returns the frame height in rows.
+func (f Frame) Height() int {
+	if f.buf == nil {
+		return 0
+	}
+	return f.buf.Height()
+}
+
+// Cell returns the cell at (x, y), or nil when out of bounds.
+func (f Frame) Cell(x, y int) *uv.Cell {
+	if f.buf == nil {
+		return nil
+	}
+	return f.buf.CellAt(x, y)
+}
+
+// Lines returns the plain text of every row, padded to the frame width.
+func (f Frame) Lines() []string {
+	out := make([]string, f.Height())
+	for y := range out {
+		out[y] = plainLine(f.buf.Line(y))
+	}
+	return out
+}
+
+// Plain returns the frame as unstyled text, one row per line.
+func (f Frame) Plain() string { return strings.Join(f.Lines(), "\n") }
+
+// ANSI returns the frame as normalized ANSI: each row is re-serialized from
+// its cells with minimal SGR diffs and a trailing reset.
+func (f Frame) ANSI() string {
+	out := make([]string, f.Height())
+	for y := range out {
+		out[y] = f.buf.Line(y).Render()
+	}
+	return strings.Join(out, "\n")
+}
+
+// ViewString returns the string content of a tea.View.
+func ViewString(v tea.View) string {
+	if v.Content == nil {
+		return ""
+	}
+	if r, ok := v.Content.(interface{ Render() string }); ok {
+		return r.Render()
+	}
+	if s, ok := v.Content.(interface{ String() string }); ok {
+		return s.String()
+	}
+	return fmt.Sprint(v.Content)
+}
+
+func plainLine(l uv.Line) string {
+	var b strings.Builder
+	for x := 0; x < len(l); x++ {
+		c := l.At(x)
+		switch {
+		case c == nil:
+			b.WriteByte(' ')
+		case c.Width == 0:
+			// continuation of a wide cell
+		case c.Content == "":
+			b.WriteByte(' ')
+		default:
+			b.WriteString(c.Content)
+		}
+	}
+	return b.String()
+}
+
+// cellKind classifies how two cells differ.
+type cellKind int
+
+const (
+	cellSame cellKind = iota
+	cellStyle
+	cellText
+)
+
+func compareCells(want, got *uv.Cell) cellKind {
+	wc, gc := cellText0(want), cellText0(got)
+	if wc != gc {
+		return cellText
+	}
+	ws, gs := cellStyle0(want), cellStyle0(got)
+	if !ws.Equal(&gs) {
+		return cellStyle
+	}
+	return cellSame
+}
+
+func cellText0(c *uv.Cell) string {
+	if c == nil || c.Content == "" {
+		return " "
+	}
+	if c.Width == 0 {
+		return ""
+	}
+	return c.Content
+}
+
+func cellStyle0(c *uv.Cell) uv.Style {
+	if c == nil {
+		return uv.Style{}
+	}
+	return c.Style
+}
+
+func describeCell(c *uv.Cell) string {
+	st := cellStyle0(c)
+	parts := []string{fmt.Sprintf("%q", cellText0(c))}
+	if st.Fg != nil {
+		parts = append(parts, "fg="+hex(st.Fg))
+	}
+	if st.Bg != nil {
+		parts = append(parts, "bg="+hex(st.Bg))
+	}
+	attrs := []struct {
+		bit  uint8
+		name string
+	}{
+		{uv.AttrBold, "bold"},
+		{uv.AttrFaint, "faint"},
+		{uv.AttrItalic, "italic"},
+		{uv.AttrReverse, "reverse"},
+		{uv.AttrStrikethrough, "strike"},
+	}
+	for _, a := range attrs {
+		if st.Attrs&a.bit != 0 {
+			parts = append(parts, a.name)
+		}
+	}
+	if st.Underline != uv.UnderlineNone {
+		parts = append(parts, "underline")
+	}
+	return strings.Join(parts, " ")
+}
+
+func hex(c color.Color) string {
+	r, g, b, _ := c.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
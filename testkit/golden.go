@@ -0,0 +1,244 @@
+package testkit
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/cloudboy-jh/bentotui/theme"
+)
+
+// forceUpdate lets testkit's own tests rewrite goldens.
+var forceUpdate bool
+
+// Updating reports whether golden files are being rewritten:
+//
+//	BENTO_UPDATE_GOLDEN=1 go test ./...
+//
+// testkit does not define an -update flag, so it cannot clash with the one
+// many golden-file test packages declare. When the package under test does
+// define a boolean -update flag, that flag is honored too.
+func Updating() bool {
+	if forceUpdate || os.Getenv("BENTO_UPDATE_GOLDEN") != "" {
+		return true
+	}
+	if f := flag.Lookup("update"); f != nil {
+		if g, ok := f.Value.(flag.Getter); ok {
+			on, _ := g.Get().(bool)
+			return on
+		}
+	}
+	return false
+}
+
+// GoldenDir is where Snapshot keeps its files, relative to the package under
+// test.
+var GoldenDir = filepath.Join("testdata", "golden")
+
+// Format selects how a frame is stored in a golden file.
+type Format int
+
+const (
+	// FormatANSI stores normalized ANSI; colors and attributes are compared.
+	FormatANSI Format = iota
+	// FormatPlain stores text only; styling differences are ignored.
+	FormatPlain
+)
+
+// Case describes one render of a brick.
+type Case struct {
+	Width  int    // passed to SetSize
+	Height int    // passed to SetSize
+	Theme  string // preset name, "" = theme.DefaultName
+	Focus  bool   // Focus() before rendering, otherwise Blur()
+	Format Format
+}
+
+// Name returns a stable file-name-safe label for the case.
+func (c Case) Name() string {
+	name := fmt.Sprintf("%dx%d_%s", c.Width, c.Height, c.themeName())
+	if c.Focus {
+		name += "_focused"
+	}
+	return name
+}
+
+func (c Case) themeName() string {
+	if c.Theme == "" {
+		return theme.DefaultName
+	}
+	return c.Theme
+}
+
+// Viewer is anything with a Bubble Tea view — every brick, room-backed page
+// and bento model.
+type Viewer interface {
+	View() tea.View
+}
+
+// Render applies the case to v and renders it. The size, theme and focus
+// state are set through whichever of SetSize, SetTheme and Focus/Blur v
+// implements. The global theme is switched to the case theme for the
+// duration of the render, so bricks that fall back to theme.CurrentTheme()
+// see it too, and restored afterwards.
+func Render(v Viewer, c Case) Frame { return NewFrame(renderCase(v, c)) }
+
+// renderCase is Render before decoding: the raw view string.
+func renderCase(v Viewer, c Case) string {
+	t := theme.Preset(c.themeName())
+
+	prev := theme.CurrentThemeName()
+	_, _ = theme.SetTheme(c.themeName())
+	defer func() { _, _ = theme.SetTheme(prev) }()
+
+	if s, ok := v.(interface{ SetTheme(theme.Theme) }); ok {
+		s.SetTheme(t)
+	}
+	if s, ok := v.(interface{ SetSize(int, int) }); ok {
+		s.SetSize(c.Width, c.Height)
+	}
+	SetFocus(v, c.Focus)
+	return ViewString(v.View())
+}
+
+// SetFocus focuses or blurs v if it supports focus. Both the plain Focus()
+// and the Focus() tea.Cmd forms are recognized; the command is discarded.
+func SetFocus(v any, focused bool) {
+	if !focused {
+		if b, ok := v.(interface{ Blur() }); ok {
+			b.Blur()
+		}
+		return
+	}
+	switch f := v.(type) {
+	case interface{ Focus() }:
+		f.Focus()
+	case interface{ Focus() tea.Cmd }:
+		_ = f.Focus()
+	}
+}
+
+// Snapshot renders v with c and compares it against
+// GoldenDir/<name>.golden, rewriting the file when Updating().
+func Snapshot(t testing.TB, name string, v Viewer, c Case) {
+	t.Helper()
+	AssertGolden(t, filepath.Join(GoldenDir, name+".golden"), Render(v, c), c.Format)
+}
+
+// SweepThemes snapshots a fresh brick under every preset in theme.Names(),
+// one subtest per theme. newV is called once per preset so state does not
+// leak between renders. c.Theme is ignored.
+func SweepThemes(t *testing.T, name string, newV func() Viewer, c Case) {
+	t.Helper()
+	for _, preset := range theme.Names() {
+		c.Theme = preset
+		t.Run(preset, func(t *testing.T) {
+			Snapshot(t, name+"/"+c.Name(), newV(), c)
+		})
+	}
+}
+
+// AssertGolden compares got against the golden file at path. On mismatch the
+// test fails with a row-by-row diff that marks changed cells: ^ for text
+// changes and ~ for style-only changes.
+func AssertGolden(t testing.TB, path string, got Frame, format Format) {
+	t.Helper()
+	encoded := got.ANSI()
+	if format == FormatPlain {
+		encoded = got.Plain()
+	}
+	encoded += "\n"
+
+	if Updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("create golden dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(encoded), 0o644); err != nil {
+			t.Fatalf("write golden %s: %v", path, err)
+		}
+		return
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden %s: %v (run with BENTO_UPDATE_GOLDEN=1 to create it)", path, err)
+	}
+	if string(raw) == encoded {
+		return
+	}
+	want := NewFrame(strings.TrimSuffix(string(raw), "\n"))
+	if diff := Diff(want, got, format); diff != "" {
+		t.Fatalf("golden mismatch %s\n%s", path, diff)
+	}
+}
+
+// Diff returns a human-readable cell diff of two frames, or "" when they are
+// equal. Style differences are ignored for FormatPlain.
+func Diff(want, got Frame, format Format) string {
+	var b strings.Builder
+	if want.Width() != got.Width() || want.Height() != got.Height() {
+		fmt.Fprintf(&b, "size: want %dx%d, got %dx%d\n", want.Width(), want.Height(), got.Width(), got.Height())
+	}
+
+	const maxDetails = 10
+	wantLines, gotLines := want.Lines(), got.Lines()
+	rows := max(want.Height(), got.Height())
+	cols := max(want.Width(), got.Width())
+	var details []string
+	changed := 0
+
+	for y := 0; y < rows; y++ {
+		marks := make([]byte, cols)
+		dirty := false
+		for x := 0; x < cols; x++ {
+			marks[x] = ' '
+			kind := compareCells(want.Cell(x, y), got.Cell(x, y))
+			if kind == cellStyle && format == FormatPlain {
+				kind = cellSame
+			}
+			if kind == cellSame {
+				continue
+			}
+			dirty = true
+			changed++
+			marks[x] = '^'
+			if kind == cellStyle {
+				marks[x] = '~'
+			}
+			if len(details) < maxDetails {
+				details = append(details, fmt.Sprintf("  (%d,%d) want %s, got %s",
+					x, y, describeCell(want.Cell(x, y)), describeCell(got.Cell(x, y))))
+			}
+		}
+		if !dirty {
+			continue
+		}
+		fmt.Fprintf(&b, "row %d\n  want |%s|\n  got  |%s|\n        %s\n",
+			y, lineAt(wantLines, y), lineAt(gotLines, y), strings.TrimRight(string(marks), " "))
+	}
+
+	if changed == 0 && b.Len() == 0 {
+		return ""
+	}
+	fmt.Fprintf(&b, "%d cell(s) differ\n", changed)
+	if len(details) > 0 {
+		b.WriteString(strings.Join(details, "\n"))
+		if changed > len(details) {
+			fmt.Fprintf(&b, "\n  … %d more", changed-len(details))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func lineAt(lines []string, y int) string {
+	if y < len(lines) {
+		return lines[y]
+	}
+	return ""
+}
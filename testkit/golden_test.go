@@ -0,0 +1,139 @@
+package testkit
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/cloudboy-jh/bentotui/theme"
+	"github.com/cloudboy-jh/bentotui/theme/styles"
+)
+
+// stub is a minimal brick: themed, sized and focusable.
+type stub struct {
+	w, h    int
+	focused bool
+	theme   theme.Theme
+}
+
+func (s *stub) SetSize(w, h int)       { s.w, s.h = w, h }
+func (s *stub) SetTheme(t theme.Theme) { s.theme = t }
+func (s *stub) Focus()                 { s.focused = true }
+func (s *stub) Blur()                  { s.focused = false }
+func (s *stub) View() tea.View {
+	label := "idle"
+	if s.focused {
+		label = "focus"
+	}
+	rows := make([]string, s.h)
+	for i := range rows {
+		rows[i] = styles.Row(s.theme.Background(), s.theme.Text(), s.w, label)
+	}
+	return tea.NewView(strings.Join(rows, "\n"))
+}
+
+// userUpdate is the -update flag golden-file test packages often declare.
+// Importing testkit must not make that declaration panic.
+var userUpdate = flag.Bool("update", false, "rewrite golden files")
+
+func TestUpdatingHonorsPackageUpdateFlag(t *testing.T) {
+	t.Setenv("BENTO_UPDATE_GOLDEN", "")
+	if Updating() {
+		t.Fatal("not updating by default")
+	}
+	*userUpdate = true
+	t.Cleanup(func() { *userUpdate = false })
+	if !Updating() {
+		t.Fatal("a package's own -update flag should turn updating on")
+	}
+}
+
+func withUpdate(t *testing.T) {
+	t.Helper()
+	prev := forceUpdate
+	forceUpdate = true
+	t.Cleanup(func() { forceUpdate = prev })
+}
+
+func TestRenderAppliesCase(t *testing.T) {
+	f := Render(&stub{}, Case{Width: 8, Height: 2, Theme: "nord", Focus: true})
+	if f.Width() != 8 || f.Height() != 2 {
+		t.Fatalf("expected 8x2 frame, got %dx%d", f.Width(), f.Height())
+	}
+	if got := f.Lines()[0]; got != "focus   " {
+		t.Fatalf("unexpected row %q", got)
+	}
+	want := theme.Preset("nord").Background()
+	if c := f.Cell(7, 1); c == nil || hex(c.Style.Bg) != hex(want) {
+		t.Fatalf("expected nord background on padding cell, got %+v", c)
+	}
+	if theme.CurrentThemeName() != theme.DefaultName {
+		t.Fatalf("expected global theme restored, got %q", theme.CurrentThemeName())
+	}
+}
+
+func TestAssertGoldenRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stub.golden")
+	c := Case{Width: 6, Height: 1}
+
+	t.Run("update", func(t *testing.T) {
+		withUpdate(t)
+		AssertGolden(t, path, Render(&stub{}, c), FormatANSI)
+	})
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), "\x1b[") {
+		t.Fatalf("expected ANSI golden, got %q", raw)
+	}
+	AssertGolden(t, path, Render(&stub{}, c), FormatANSI)
+}
+
+func TestDiffMarksChangedCells(t *testing.T) {
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("#ff0000"))
+	blue := lipgloss.NewStyle().Foreground(lipgloss.Color("#0000ff"))
+	want := NewFrame(red.Render("abc") + "d")
+	got := NewFrame(red.Render("axc") + blue.Render("d"))
+
+	diff := Diff(want, got, FormatANSI)
+	if !strings.Contains(diff, "  want |abcd|\n  got  |axcd|\n         ^ ~\n") {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+	if !strings.Contains(diff, "2 cell(s) differ") || !strings.Contains(diff, "(3,0) want \"d\", got \"d\" fg=#0000ff") {
+		t.Fatalf("expected cell details:\n%s", diff)
+	}
+	if plain := Diff(want, got, FormatPlain); !strings.Contains(plain, "1 cell(s) differ") {
+		t.Fatalf("plain diff should ignore style:\n%s", plain)
+	}
+	if Diff(want, want, FormatANSI) != "" {
+		t.Fatal("expected identical frames to have no diff")
+	}
+}
+
+func TestDiffReportsSizeChange(t *testing.T) {
+	diff := Diff(NewFrame("ab\ncd"), NewFrame("ab"), FormatPlain)
+	if !strings.Contains(diff, "size: want 2x2, got 2x1") {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+}
+
+func TestSweepThemesWritesOneGoldenPerPreset(t *testing.T) {
+	dir := t.TempDir()
+	prev := GoldenDir
+	GoldenDir = dir
+	t.Cleanup(func() { GoldenDir = prev })
+	withUpdate(t)
+
+	SweepThemes(t, "stub", func() Viewer { return &stub{} }, Case{Width: 4, Height: 1})
+	for _, name := range theme.Names() {
+		if _, err := os.Stat(filepath.Join(dir, "stub", "4x1_"+name+".golden")); err != nil {
+			t.Fatalf("missing golden for %s: %v", name, err)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"charm.land/lipgloss/v2"
 	"github.com/charmbracelet/x/ansi"
 	"image/color"
+	"strings"
 )
 
 // Row returns a fully-painted row string of exactly width cells.
@@ -47,5 +48,13 @@ func RowClip(bg, fg color.Color, width int, content string) string {
 	return Row(bg, fg, width, ClipANSI(content, width))
 }
 
+// Reopen re-applies the row colors after every full SGR reset in content.
+// Styled spans end with a reset, which would otherwise leave the text and
+// padding that follow them without a background.
+func Reopen(content string, bg, fg color.Color) string {
+	pen := ansi.NewStyle().BackgroundColor(bg).ForegroundColor(fg).String()
+	return strings.NewReplacer("\x1b[m", "\x1b[m"+pen, "\x1b[0m", "\x1b[0m"+pen).Replace(content)
+}
+
 // InputStyles returns a fully-themed textinput.Styles struct.
 // Kept here as a utility so bricks don't need to import bubbles/textinput directly.
@@ -35,3 +35,16 @@ func TestRowWidth(t *testing.T) {
 		t.Fatalf("expected row width 20, got %d", lipgloss.Width(row))
 	}
 }
+
+func TestReopenKeepsRowBackground(t *testing.T) {
+	th := theme.Preset(theme.DefaultName)
+	span := lipgloss.NewStyle().Foreground(th.Error()).Render("err")
+	pen := ansi.NewStyle().BackgroundColor(th.BackgroundPanel()).ForegroundColor(th.Text()).String()
+	got := Reopen(span+" tail", th.BackgroundPanel(), th.Text())
+	if want := span + pen + " tail"; got != want {
+		t.Fatalf("Reopen = %q, want %q", got, want)
+	}
+	if ansi.Strip(got) != "err tail" {
+		t.Fatalf("Reopen changed the text: %q", ansi.Strip(got))
+	}
+}
@@ -83,8 +83,9 @@ type Theme interface {
 	DiffHighlightAdded() color.Color
 	DiffHighlightRemoved() color.Color
 
-	// Syntax — token colors fed to chroma for syntax highlighting inside diffs.
-	// bento-diffs builds a dynamic chroma XML style from these.
+	// Syntax — token colors for syntax highlighting. The code brick paints
+	// its tokens with these; bento-diffs builds a dynamic chroma XML style
+	// from them.
 	SyntaxKeyword() color.Color
 	SyntaxType() color.Color
 	SyntaxFunction() color.Color